@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -15,7 +16,10 @@ import (
 	"github.com/kk7453603/RybakovTestGo/internal/adapters/grpc"
 	"github.com/kk7453603/RybakovTestGo/internal/adapters/repository"
 	"github.com/kk7453603/RybakovTestGo/internal/config"
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
 	"github.com/kk7453603/RybakovTestGo/internal/core/services"
+	"github.com/kk7453603/RybakovTestGo/internal/migrations"
+	"github.com/kk7453603/RybakovTestGo/internal/scheduler"
 )
 
 func main() {
@@ -29,13 +33,112 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
-	currencyRepo, priceRepo := repository.NewPostgresRepository(db)
+	migrator, err := migrations.New(db)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(migrator, os.Args[2:]); err != nil {
+			log.Fatalf("❌ Migration failed: %v", err)
+		}
+		return
+	}
+
+	pending, err := migrator.Pending(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to check migrations: %v", err)
+	}
+	if len(pending) > 0 {
+		log.Fatalf("❌ Database schema is outdated: %d pending migrations (first: %d_%s), run `server migrate up`",
+			len(pending), pending[0].Version, pending[0].Name)
+	}
+
+	currencyRepo, postgresPriceRepo := repository.NewPostgresRepository(db)
 
-	priceProvider := repository.NewExternalPriceProvider(*cfg)
+	priceRepo, priceSubscriber := services.NewPriceBroadcaster(postgresPriceRepo)
 
-	currencyService := services.NewCurrencyService(currencyRepo, priceRepo, priceProvider)
+	catalogRepo := repository.NewCoinCatalogRepository(db)
+	services.SeedOverrides(context.Background(), catalogRepo, repository.DefaultCoinIDs)
+	catalogService := services.NewCoinCatalogService(catalogRepo, repository.NewCoinListProvider(*cfg))
 
-	grpcServer := grpc.NewGRPCServer(currencyService, cfg.GRPC.Port, cfg.Server.Port)
+	priceProvider, historyProvider := repository.NewExternalPriceProvider(*cfg, catalogService)
+
+	currencyService := services.NewCurrencyService(currencyRepo, priceRepo, priceProvider, priceSubscriber, catalogService, cfg.Quotes.Defaults)
+
+	maintenanceRepo := repository.NewPriceMaintenanceRepository(db)
+	backfillService := services.NewBackfillService(
+		currencyRepo, postgresPriceRepo, repository.NewBackfillRepository(db), maintenanceRepo, historyProvider,
+		services.BackfillOptions{
+			ChunkSize:  cfg.Backfill.ChunkSize,
+			ChunkDelay: cfg.Backfill.ChunkDelay,
+			RetryDelay: cfg.Backfill.RetryDelay,
+			MaxRetries: cfg.Backfill.MaxRetries,
+		},
+	)
+	if err := backfillService.ResumeBackfills(context.Background()); err != nil {
+		log.Printf("⚠️  Не удалось возобновить загрузку истории: %v", err)
+	}
+
+	alertService := services.NewAlertService(
+		repository.NewAlertRepository(db), currencyRepo, priceRepo, priceSubscriber, repository.NewLogAlertNotifier(),
+		services.AlertOptions{
+			DefaultCooldown: cfg.Alerts.DefaultCooldown,
+			MaxPriceAge:     cfg.Alerts.MaxPriceAge,
+		},
+	)
+	alertService.Start()
+
+	webhookRepo := repository.NewWebhookRepository(db)
+	webhookService := services.NewWebhookService(
+		webhookRepo, repository.NewWebhookSender(cfg.Webhooks.Timeout), priceSubscriber,
+		services.WebhookOptions{
+			Timeout:      cfg.Webhooks.Timeout,
+			MaxAttempts:  cfg.Webhooks.MaxAttempts,
+			RetryBase:    cfg.Webhooks.RetryBase,
+			RetryMax:     cfg.Webhooks.RetryMax,
+			DisableAfter: cfg.Webhooks.DisableAfter,
+			PollInterval: cfg.Webhooks.PollInterval,
+			Workers:      cfg.Webhooks.Workers,
+			MaxPriceAge:  cfg.Webhooks.MaxPriceAge,
+		},
+	)
+	webhookService.Start()
+
+	jobs := scheduler.New()
+	if cfg.Collector.Enabled {
+		collector := scheduler.NewPriceCollector(currencyRepo, priceRepo, priceProvider)
+		jobs.Add("price-collector", cfg.Collector.Interval, cfg.Collector.Jitter, collector.Collect)
+	}
+	if cfg.Retention.MaintenanceInterval > 0 {
+		partitions := scheduler.NewPartitionManager(
+			maintenanceRepo,
+			domain.RetentionPolicy{Default: cfg.Retention.Default, PerSymbol: cfg.Retention.PerSymbol},
+			cfg.Retention.PartitionsAhead,
+		)
+		jobs.Add("price-partitions", cfg.Retention.MaintenanceInterval, time.Minute, partitions.Maintain)
+	}
+	if cfg.Rollup.Interval > 0 {
+		compactor := scheduler.NewRollupCompactor(maintenanceRepo, cfg.Rollup.HourlyAfter, cfg.Rollup.DailyAfter)
+		jobs.Add("price-rollups", cfg.Rollup.Interval, time.Minute, compactor.Compact)
+	}
+	if cfg.Purge.After > 0 && cfg.Purge.Interval > 0 {
+		purger := scheduler.NewCurrencyPurger(currencyRepo, cfg.Purge.After, cfg.Purge.WithPrices)
+		jobs.Add("currency-purge", cfg.Purge.Interval, time.Minute, purger.Purge)
+	}
+	if cfg.Catalog.RefreshInterval > 0 {
+		refresher := scheduler.NewCatalogRefresher(catalogService)
+		jobs.Add("coin-catalog", cfg.Catalog.RefreshInterval, time.Minute, refresher.Refresh)
+	}
+	if cfg.Webhooks.LogRetention > 0 && cfg.Webhooks.LogCleanupInterval > 0 {
+		cleaner := scheduler.NewWebhookLogCleaner(webhookRepo, cfg.Webhooks.LogRetention)
+		jobs.Add("webhook-log", cfg.Webhooks.LogCleanupInterval, time.Minute, cleaner.Clean)
+	}
+	jobs.Start()
+
+	// События о добавлении и удалении валют публикуются только для вызовов через API
+	currencyEvents := services.NewCurrencyEvents(currencyService, webhookService)
+	grpcServer := grpc.NewGRPCServer(currencyEvents, catalogService, backfillService, alertService, webhookService, cfg.GRPC.Port, cfg.Server.Port)
 
 	serverErr := make(chan error, 1)
 
@@ -54,12 +157,59 @@ func main() {
 	}
 
 	log.Println("🛑 Shutting down server...")
+	jobs.Stop()
+	backfillService.Stop()
+	alertService.Stop()
+	webhookService.Stop()
 	grpcServer.Stop()
 
 	time.Sleep(2 * time.Second)
 	log.Println("✅ Server stopped successfully")
 }
 
+// runMigrate выполняет команду migrate up|down|status
+func runMigrate(migrator *migrations.Migrator, args []string) error {
+	ctx := context.Background()
+
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("✅ Applied %d migrations", applied)
+	case "down":
+		migration, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		if migration == nil {
+			log.Println("ℹ️  Nothing to roll back")
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied " + status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%-30s %s\n", status.Version, status.Name, state)
+		}
+	default:
+		return fmt.Errorf("unknown command %q, expected up, down or status", command)
+	}
+
+	return nil
+}
+
 func connectDatabase(cfg config.DatabaseConfig) (*gorm.DB, error) {
 	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=%s",
 		cfg.Host, cfg.User, cfg.Password, cfg.DBName, cfg.Port, cfg.SSLMode, cfg.Timezone)
@@ -0,0 +1,303 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+	currencyv1 "github.com/kk7453603/RybakovTestGo/pkg/api/gen"
+)
+
+type AlertHandler struct {
+	currencyv1.UnimplementedAlertServiceServer
+	alerts ports.AlertService
+}
+
+func NewAlertHandler(alerts ports.AlertService) *AlertHandler {
+	return &AlertHandler{alerts: alerts}
+}
+
+func (h *AlertHandler) CreateAlertRule(ctx context.Context, req *currencyv1.CreateAlertRuleRequest) (*currencyv1.AlertRule, error) {
+	if req.Rule == nil || req.Rule.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "rule.symbol is required")
+	}
+
+	rule := &domain.AlertRule{
+		Symbol:      req.Rule.Symbol,
+		Quote:       req.Rule.Quote,
+		Condition:   protoToAlertCondition(req.Rule.Condition),
+		Enabled:     req.Rule.Enabled == nil || *req.Rule.Enabled,
+		Description: req.Rule.Description,
+	}
+
+	var err error
+	if rule.Threshold, err = protoToThreshold(req.Rule.Threshold); err != nil {
+		return nil, err
+	}
+	if rule.Window, err = protoToRuleDuration(req.Rule.Window, "window"); err != nil {
+		return nil, err
+	}
+	if rule.Cooldown, err = protoToRuleDuration(req.Rule.Cooldown, "cooldown"); err != nil {
+		return nil, err
+	}
+
+	created, err := h.alerts.CreateRule(ctx, rule)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return h.domainToProtoAlertRule(created), nil
+}
+
+func (h *AlertHandler) GetAlertRule(ctx context.Context, req *currencyv1.GetAlertRuleRequest) (*currencyv1.AlertRule, error) {
+	rule, err := h.alerts.GetRule(ctx, req.Id)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return h.domainToProtoAlertRule(rule), nil
+}
+
+func (h *AlertHandler) ListAlertRules(ctx context.Context, req *currencyv1.ListAlertRulesRequest) (*currencyv1.ListAlertRulesResponse, error) {
+	rules, err := h.alerts.ListRules(ctx, req.Symbol)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	protoRules := make([]*currencyv1.AlertRule, len(rules))
+	for i, rule := range rules {
+		protoRules[i] = h.domainToProtoAlertRule(rule)
+	}
+
+	return &currencyv1.ListAlertRulesResponse{Rules: protoRules}, nil
+}
+
+func (h *AlertHandler) UpdateAlertRule(ctx context.Context, req *currencyv1.UpdateAlertRuleRequest) (*currencyv1.AlertRule, error) {
+	if req.Rule == nil || req.Rule.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "rule.id is required")
+	}
+
+	update, err := h.protoToAlertRuleUpdate(req.Rule, req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := h.alerts.UpdateRule(ctx, req.Rule.Id, update)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return h.domainToProtoAlertRule(rule), nil
+}
+
+func (h *AlertHandler) DeleteAlertRule(ctx context.Context, req *currencyv1.DeleteAlertRuleRequest) (*emptypb.Empty, error) {
+	if err := h.alerts.DeleteRule(ctx, req.Id); err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *AlertHandler) ListAlertTriggers(ctx context.Context, req *currencyv1.ListAlertTriggersRequest) (*currencyv1.ListAlertTriggersResponse, error) {
+	if req.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	page, err := h.alerts.ListTriggers(ctx, domain.AlertTriggerQuery{
+		RuleID:    req.RuleId,
+		Symbol:    req.Symbol,
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	triggers := make([]*currencyv1.AlertTrigger, len(page.Triggers))
+	for i, trigger := range page.Triggers {
+		triggers[i] = h.domainToProtoAlertTrigger(trigger)
+	}
+
+	return &currencyv1.ListAlertTriggersResponse{
+		Triggers:      triggers,
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+// protoToAlertRuleUpdate собирает изменение из полей, перечисленных в маске.
+// Без маски обновляются все заполненные изменяемые поля, поля только для чтения игнорируются.
+func (h *AlertHandler) protoToAlertRuleUpdate(rule *currencyv1.AlertRule, mask *fieldmaskpb.FieldMask) (domain.AlertRuleUpdate, error) {
+	var update domain.AlertRuleUpdate
+
+	paths := mask.GetPaths()
+	if len(paths) == 0 {
+		if rule.Condition != currencyv1.AlertCondition_ALERT_CONDITION_UNSPECIFIED {
+			paths = append(paths, "condition")
+		}
+		if rule.Threshold != nil {
+			paths = append(paths, "threshold")
+		}
+		if rule.Window != nil {
+			paths = append(paths, "window")
+		}
+		if rule.Cooldown != nil {
+			paths = append(paths, "cooldown")
+		}
+		if rule.Enabled != nil {
+			paths = append(paths, "enabled")
+		}
+		if rule.Description != "" {
+			paths = append(paths, "description")
+		}
+	}
+	if len(paths) == 0 {
+		return update, status.Error(codes.InvalidArgument, "nothing to update")
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "condition":
+			condition := protoToAlertCondition(rule.Condition)
+			update.Condition = &condition
+		case "threshold":
+			threshold, err := protoToThreshold(rule.Threshold)
+			if err != nil {
+				return update, err
+			}
+			update.Threshold = &threshold
+		case "window":
+			window, err := protoToRuleDuration(rule.Window, "window")
+			if err != nil {
+				return update, err
+			}
+			update.Window = &window
+		case "cooldown":
+			cooldown, err := protoToRuleDuration(rule.Cooldown, "cooldown")
+			if err != nil {
+				return update, err
+			}
+			update.Cooldown = &cooldown
+		case "enabled":
+			enabled := rule.GetEnabled()
+			update.Enabled = &enabled
+		case "description":
+			description := rule.Description
+			update.Description = &description
+		case "id", "symbol", "quote", "armed", "last_triggered_at", "created_at", "updated_at":
+			// Правило привязано к паре символ-котировка, остальные поля только для чтения - игнорируем
+		default:
+			return update, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", path)
+		}
+	}
+
+	return update, nil
+}
+
+// protoToThreshold читает порог из точного значения, а если оно пусто - из units и nanos
+func protoToThreshold(value *currencyv1.Decimal) (domain.Decimal, error) {
+	if value == nil {
+		return domain.Decimal{}, nil
+	}
+	if value.Value == "" {
+		return domain.NewDecimal(value.Units*1_000_000_000+int64(value.Nanos), 9).Trim(), nil
+	}
+
+	threshold, err := domain.ParseDecimal(value.Value)
+	if err != nil {
+		return domain.Decimal{}, status.Error(codes.InvalidArgument, domain.ErrInvalidAlertThreshold.Error())
+	}
+	return threshold, nil
+}
+
+func protoToRuleDuration(value *durationpb.Duration, field string) (time.Duration, error) {
+	if value == nil {
+		return 0, nil
+	}
+	if err := value.CheckValid(); err != nil || value.AsDuration() < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid %s", field)
+	}
+	return value.AsDuration(), nil
+}
+
+func protoToAlertCondition(condition currencyv1.AlertCondition) domain.AlertCondition {
+	switch condition {
+	case currencyv1.AlertCondition_ALERT_CONDITION_ABOVE:
+		return domain.AlertAbove
+	case currencyv1.AlertCondition_ALERT_CONDITION_BELOW:
+		return domain.AlertBelow
+	case currencyv1.AlertCondition_ALERT_CONDITION_PERCENT_CHANGE:
+		return domain.AlertPercentChange
+	default:
+		return ""
+	}
+}
+
+func alertConditionToProto(condition domain.AlertCondition) currencyv1.AlertCondition {
+	switch condition {
+	case domain.AlertAbove:
+		return currencyv1.AlertCondition_ALERT_CONDITION_ABOVE
+	case domain.AlertBelow:
+		return currencyv1.AlertCondition_ALERT_CONDITION_BELOW
+	case domain.AlertPercentChange:
+		return currencyv1.AlertCondition_ALERT_CONDITION_PERCENT_CHANGE
+	default:
+		return currencyv1.AlertCondition_ALERT_CONDITION_UNSPECIFIED
+	}
+}
+
+func (h *AlertHandler) domainToProtoAlertRule(rule *domain.AlertRule) *currencyv1.AlertRule {
+	enabled := rule.Enabled
+	protoRule := &currencyv1.AlertRule{
+		Id:          rule.ID,
+		Symbol:      rule.Symbol,
+		Quote:       rule.Quote,
+		Condition:   alertConditionToProto(rule.Condition),
+		Threshold:   decimalToProto(rule.Threshold),
+		Enabled:     &enabled,
+		Armed:       rule.Armed,
+		Description: rule.Description,
+		CreatedAt:   timestamppb.New(rule.CreatedAt),
+		UpdatedAt:   timestamppb.New(rule.UpdatedAt),
+	}
+	if rule.Window > 0 {
+		protoRule.Window = durationpb.New(rule.Window)
+	}
+	if rule.Cooldown > 0 {
+		protoRule.Cooldown = durationpb.New(rule.Cooldown)
+	}
+	if rule.LastTriggeredAt != nil {
+		protoRule.LastTriggeredAt = timestamppb.New(*rule.LastTriggeredAt)
+	}
+	return protoRule
+}
+
+func (h *AlertHandler) domainToProtoAlertTrigger(trigger *domain.AlertTrigger) *currencyv1.AlertTrigger {
+	protoTrigger := &currencyv1.AlertTrigger{
+		Id:          trigger.ID,
+		RuleId:      trigger.RuleID,
+		Symbol:      trigger.Symbol,
+		Quote:       trigger.Quote,
+		Condition:   alertConditionToProto(trigger.Condition),
+		Threshold:   decimalToProto(trigger.Threshold),
+		Price:       decimalToProto(trigger.Price),
+		PriceTime:   timestamppb.New(trigger.PriceTime),
+		TriggeredAt: timestamppb.New(trigger.TriggeredAt),
+	}
+	if trigger.Condition == domain.AlertPercentChange {
+		protoTrigger.ReferencePrice = decimalToProto(trigger.ReferencePrice)
+		protoTrigger.ChangePercent = decimalToProto(trigger.ChangePercent)
+	}
+	return protoTrigger
+}
+
+func (h *AlertHandler) handleError(err error) error {
+	return domainErrorToStatus(err)
+}
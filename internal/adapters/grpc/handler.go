@@ -2,11 +2,14 @@ package grpc
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
@@ -16,12 +19,16 @@ import (
 
 type CurrencyHandler struct {
 	currencyv1.UnimplementedCurrencyServiceServer
-	service ports.CurrencyService
+	service  ports.CurrencyService
+	catalog  ports.CoinCatalogService
+	backfill ports.BackfillService
 }
 
-func NewCurrencyHandler(service ports.CurrencyService) *CurrencyHandler {
+func NewCurrencyHandler(service ports.CurrencyService, catalog ports.CoinCatalogService, backfill ports.BackfillService) *CurrencyHandler {
 	return &CurrencyHandler{
-		service: service,
+		service:  service,
+		catalog:  catalog,
+		backfill: backfill,
 	}
 }
 
@@ -34,7 +41,20 @@ func (h *CurrencyHandler) AddCurrency(ctx context.Context, req *currencyv1.AddCu
 		return nil, status.Error(codes.InvalidArgument, "name is required")
 	}
 
-	currency, err := h.service.AddCurrency(ctx, req.Symbol, req.Name)
+	decimals := domain.DefaultDecimals
+	if req.Decimals != nil {
+		decimals = int(*req.Decimals)
+	}
+
+	currency, err := h.service.AddCurrency(ctx, &domain.Currency{
+		Symbol:      req.Symbol,
+		Name:        req.Name,
+		Quotes:      req.Quotes,
+		Decimals:    decimals,
+		Tags:        req.Tags,
+		Description: req.Description,
+		Tracking:    protoToTrackingState(req.Tracking),
+	})
 	if err != nil {
 		return nil, h.handleError(err)
 	}
@@ -57,6 +77,57 @@ func (h *CurrencyHandler) RemoveCurrency(ctx context.Context, req *currencyv1.Re
 	return &emptypb.Empty{}, nil
 }
 
+func (h *CurrencyHandler) UpdateCurrency(ctx context.Context, req *currencyv1.UpdateCurrencyRequest) (*currencyv1.CurrencyResponse, error) {
+	if req.Currency == nil || req.Currency.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "currency.symbol is required")
+	}
+
+	update, err := h.protoToCurrencyUpdate(req.Currency, req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+
+	currency, err := h.service.UpdateCurrency(ctx, req.Currency.Symbol, update)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &currencyv1.CurrencyResponse{
+		Currency: h.domainToProtoCurrency(currency),
+	}, nil
+}
+
+func (h *CurrencyHandler) RestoreCurrency(ctx context.Context, req *currencyv1.RestoreCurrencyRequest) (*currencyv1.CurrencyResponse, error) {
+	if req.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "symbol is required")
+	}
+
+	currency, err := h.service.RestoreCurrency(ctx, req.Symbol)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &currencyv1.CurrencyResponse{
+		Currency: h.domainToProtoCurrency(currency),
+	}, nil
+}
+
+func (h *CurrencyHandler) ListRemovedCurrencies(ctx context.Context, req *emptypb.Empty) (*currencyv1.ListCurrenciesResponse, error) {
+	currencies, err := h.service.ListRemovedCurrencies(ctx)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	protoCurrencies := make([]*currencyv1.Currency, len(currencies))
+	for i, currency := range currencies {
+		protoCurrencies[i] = h.domainToProtoCurrency(currency)
+	}
+
+	return &currencyv1.ListCurrenciesResponse{
+		Currencies: protoCurrencies,
+	}, nil
+}
+
 func (h *CurrencyHandler) GetCurrencyPrice(ctx context.Context, req *currencyv1.GetCurrencyPriceRequest) (*currencyv1.CurrencyPriceResponse, error) {
 	if req.Symbol == "" {
 		return nil, status.Error(codes.InvalidArgument, "symbol is required")
@@ -67,29 +138,142 @@ func (h *CurrencyHandler) GetCurrencyPrice(ctx context.Context, req *currencyv1.
 		timestamp = req.Timestamp.AsTime()
 	}
 
-	price, err := h.service.GetCurrencyPrice(ctx, req.Symbol, timestamp)
+	lookup, err := h.protoToPriceLookup(req.Mode, req.MaxStaleness)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.service.GetCurrencyPrice(ctx, req.Symbol, req.Quote, timestamp, lookup)
 	if err != nil {
 		return nil, h.handleError(err)
 	}
 
 	return &currencyv1.CurrencyPriceResponse{
-		Price: h.domainToProtoCurrencyPrice(price),
+		Price:         h.domainToProtoCurrencyPrice(result.Price),
+		RequestedTime: timestamppb.New(result.RequestedTime),
+		Distance:      durationpb.New(result.Distance),
 	}, nil
 }
 
-func (h *CurrencyHandler) ListCurrencies(ctx context.Context, req *emptypb.Empty) (*currencyv1.ListCurrenciesResponse, error) {
-	currencies, err := h.service.ListCurrencies(ctx)
+func (h *CurrencyHandler) BatchGetPrices(ctx context.Context, req *currencyv1.BatchGetPricesRequest) (*currencyv1.BatchGetPricesResponse, error) {
+	lookup, err := h.protoToPriceLookup(req.Mode, req.MaxStaleness)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamps := make([]time.Time, len(req.Timestamps))
+	for i, timestamp := range req.Timestamps {
+		timestamps[i] = timestamp.AsTime()
+	}
+
+	results, err := h.service.BatchGetPrices(ctx, domain.BatchPriceQuery{
+		Symbols:    req.Symbols,
+		Timestamps: timestamps,
+		Quote:      req.Quote,
+		Lookup:     lookup,
+	})
 	if err != nil {
 		return nil, h.handleError(err)
 	}
 
-	protoCurrencies := make([]*currencyv1.Currency, len(currencies))
-	for i, currency := range currencies {
+	protoResults := make([]*currencyv1.BatchPriceResult, len(results))
+	for i, item := range results {
+		protoResult := &currencyv1.BatchPriceResult{Symbol: item.Symbol}
+		if !item.Timestamp.IsZero() {
+			protoResult.RequestedTime = timestamppb.New(item.Timestamp)
+		}
+
+		if item.Err != nil {
+			st := status.Convert(h.handleError(item.Err))
+			protoResult.Error = &currencyv1.BatchPriceError{Code: int32(st.Code()), Message: st.Message()}
+		} else {
+			protoResult.Price = h.domainToProtoCurrencyPrice(item.Result.Price)
+			protoResult.Distance = durationpb.New(item.Result.Distance)
+		}
+		protoResults[i] = protoResult
+	}
+
+	return &currencyv1.BatchGetPricesResponse{Results: protoResults}, nil
+}
+
+func (h *CurrencyHandler) Convert(ctx context.Context, req *currencyv1.ConvertRequest) (*currencyv1.ConvertResponse, error) {
+	if req.From == "" || req.To == "" {
+		return nil, status.Error(codes.InvalidArgument, "from and to are required")
+	}
+
+	amount := domain.NewDecimal(1, 0)
+	if req.Amount != "" {
+		parsed, err := domain.ParseDecimal(req.Amount)
+		if err != nil {
+			return nil, h.handleError(domain.ErrInvalidAmount)
+		}
+		amount = parsed
+	}
+
+	lookup, err := h.protoToPriceLookup(req.Mode, req.MaxStaleness)
+	if err != nil {
+		return nil, err
+	}
+
+	var timestamp time.Time
+	if req.Timestamp != nil {
+		timestamp = req.Timestamp.AsTime()
+	}
+
+	conversion, err := h.service.Convert(ctx, domain.ConversionQuery{
+		From:      req.From,
+		To:        req.To,
+		Amount:    amount,
+		Timestamp: timestamp,
+		Lookup:    lookup,
+	})
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &currencyv1.ConvertResponse{
+		From:            conversion.From,
+		To:              conversion.To,
+		Amount:          h.domainToProtoDecimal(conversion.Amount),
+		Rate:            h.domainToProtoDecimal(conversion.Rate),
+		ConvertedAmount: h.domainToProtoDecimal(conversion.ConvertedAmount),
+		FromLeg:         h.domainToProtoConversionLeg(conversion.FromLeg),
+		ToLeg:           h.domainToProtoConversionLeg(conversion.ToLeg),
+	}, nil
+}
+
+func (h *CurrencyHandler) ListCurrencies(ctx context.Context, req *currencyv1.ListCurrenciesRequest) (*currencyv1.ListCurrenciesResponse, error) {
+	if req.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	order, err := domain.ParseCurrencyOrder(req.OrderBy)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	page, err := h.service.ListCurrencies(ctx, domain.CurrencyListQuery{
+		Filter: domain.CurrencyFilter{
+			Tag:        req.Tag,
+			NamePrefix: req.NamePrefix,
+			Tracking:   protoToTrackingState(req.Tracking),
+		},
+		Order:     order,
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	protoCurrencies := make([]*currencyv1.Currency, len(page.Currencies))
+	for i, currency := range page.Currencies {
 		protoCurrencies[i] = h.domainToProtoCurrency(currency)
 	}
 
 	return &currencyv1.ListCurrenciesResponse{
-		Currencies: protoCurrencies,
+		Currencies:    protoCurrencies,
+		NextPageToken: page.NextPageToken,
 	}, nil
 }
 
@@ -106,57 +290,412 @@ func (h *CurrencyHandler) GetPriceHistory(ctx context.Context, req *currencyv1.G
 		endTime = req.EndTime.AsTime()
 	}
 
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+
 	limit := int(req.Limit)
-	if limit <= 0 {
+	if limit == 0 {
 		limit = 100
 	}
 
-	prices, err := h.service.GetPriceHistory(ctx, req.Symbol, startTime, endTime, limit)
+	page, err := h.service.GetPriceHistory(ctx, domain.PriceHistoryQuery{
+		Symbol:    req.Symbol,
+		Quote:     req.Quote,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Ascending: req.Order == currencyv1.SortOrder_SORT_ORDER_ASC,
+		PageSize:  limit,
+		PageToken: req.PageToken,
+	})
 	if err != nil {
 		return nil, h.handleError(err)
 	}
 
-	protoPrices := make([]*currencyv1.CurrencyPrice, len(prices))
-	for i, price := range prices {
+	protoPrices := make([]*currencyv1.CurrencyPrice, len(page.Prices))
+	for i, price := range page.Prices {
 		protoPrices[i] = h.domainToProtoCurrencyPrice(price)
 	}
 
 	return &currencyv1.PriceHistoryResponse{
-		Prices: protoPrices,
+		Prices:        protoPrices,
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+func (h *CurrencyHandler) GetCandles(ctx context.Context, req *currencyv1.GetCandlesRequest) (*currencyv1.CandlesResponse, error) {
+	if req.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "symbol is required")
+	}
+
+	if req.Interval == "" {
+		return nil, status.Error(codes.InvalidArgument, "interval is required")
+	}
+
+	var startTime, endTime time.Time
+	if req.StartTime != nil {
+		startTime = req.StartTime.AsTime()
+	}
+	if req.EndTime != nil {
+		endTime = req.EndTime.AsTime()
+	}
+
+	candles, err := h.service.GetCandles(ctx, req.Symbol, req.Quote, req.Interval, startTime, endTime)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	protoCandles := make([]*currencyv1.Candle, len(candles))
+	for i, candle := range candles {
+		protoCandles[i] = &currencyv1.Candle{
+			OpenTime:   timestamppb.New(candle.OpenTime),
+			Open:       candle.Open.Float64(),
+			High:       candle.High.Float64(),
+			Low:        candle.Low.Float64(),
+			Close:      candle.Close.Float64(),
+			Count:      candle.Count,
+			OpenValue:  h.domainToProtoDecimal(candle.Open),
+			HighValue:  h.domainToProtoDecimal(candle.High),
+			LowValue:   h.domainToProtoDecimal(candle.Low),
+			CloseValue: h.domainToProtoDecimal(candle.Close),
+		}
+	}
+
+	return &currencyv1.CandlesResponse{
+		Symbol:   strings.ToUpper(req.Symbol),
+		Quote:    domain.NormalizeQuote(req.Quote),
+		Interval: req.Interval,
+		Candles:  protoCandles,
+	}, nil
+}
+
+func (h *CurrencyHandler) StreamPrices(req *currencyv1.StreamPricesRequest, stream currencyv1.CurrencyService_StreamPricesServer) error {
+	ctx := stream.Context()
+
+	updates, unsubscribe, err := h.service.StreamPrices(ctx, req.Symbols)
+	if err != nil {
+		return h.handleError(err)
+	}
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case price, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(h.domainToProtoCurrencyPrice(price)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (h *CurrencyHandler) BackfillHistory(ctx context.Context, req *currencyv1.BackfillHistoryRequest) (*currencyv1.BackfillJob, error) {
+	if req.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "symbol is required")
+	}
+
+	if req.StartTime == nil {
+		return nil, status.Error(codes.InvalidArgument, "start_time is required")
+	}
+
+	var endTime time.Time
+	if req.EndTime != nil {
+		endTime = req.EndTime.AsTime()
+	}
+
+	job, err := h.backfill.StartBackfill(ctx, req.Symbol, req.Quote, req.StartTime.AsTime(), endTime)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return h.domainToProtoBackfillJob(job), nil
+}
+
+func (h *CurrencyHandler) GetBackfillJob(ctx context.Context, req *currencyv1.GetBackfillJobRequest) (*currencyv1.BackfillJob, error) {
+	if req.Id <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	job, err := h.backfill.GetBackfillJob(ctx, req.Id)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return h.domainToProtoBackfillJob(job), nil
+}
+
+func (h *CurrencyHandler) SetCoinOverride(ctx context.Context, req *currencyv1.SetCoinOverrideRequest) (*currencyv1.CoinOverride, error) {
+	if req.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "symbol is required")
+	}
+
+	if req.CoinId == "" {
+		return nil, status.Error(codes.InvalidArgument, "coin_id is required")
+	}
+
+	override, err := h.catalog.SetOverride(ctx, req.Symbol, req.CoinId)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return h.domainToProtoCoinOverride(override), nil
+}
+
+func (h *CurrencyHandler) DeleteCoinOverride(ctx context.Context, req *currencyv1.DeleteCoinOverrideRequest) (*emptypb.Empty, error) {
+	if req.Symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "symbol is required")
+	}
+
+	if err := h.catalog.DeleteOverride(ctx, req.Symbol); err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *CurrencyHandler) ListCoinOverrides(ctx context.Context, req *emptypb.Empty) (*currencyv1.ListCoinOverridesResponse, error) {
+	overrides, err := h.catalog.ListOverrides(ctx)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	protoOverrides := make([]*currencyv1.CoinOverride, len(overrides))
+	for i, override := range overrides {
+		protoOverrides[i] = h.domainToProtoCoinOverride(override)
+	}
+
+	return &currencyv1.ListCoinOverridesResponse{
+		Overrides: protoOverrides,
 	}, nil
 }
 
 func (h *CurrencyHandler) domainToProtoCurrency(currency *domain.Currency) *currencyv1.Currency {
-	return &currencyv1.Currency{
-		Id:        currency.ID,
-		Symbol:    currency.Symbol,
-		Name:      currency.Name,
-		CreatedAt: timestamppb.New(currency.CreatedAt),
-		UpdatedAt: timestamppb.New(currency.UpdatedAt),
+	protoCurrency := &currencyv1.Currency{
+		Id:             currency.ID,
+		Symbol:         currency.Symbol,
+		Name:           currency.Name,
+		Quotes:         currency.Quotes,
+		CreatedAt:      timestamppb.New(currency.CreatedAt),
+		UpdatedAt:      timestamppb.New(currency.UpdatedAt),
+		Decimals:       int32(currency.Decimals),
+		Tags:           currency.Tags,
+		ProviderCoinId: currency.ProviderCoinID,
+		Description:    currency.Description,
+		Tracking:       trackingStateToProto(currency.Tracking),
+	}
+	if currency.DeletedAt != nil {
+		protoCurrency.DeletedAt = timestamppb.New(*currency.DeletedAt)
+	}
+	if !currency.MarketCap.IsZero() {
+		protoCurrency.MarketCap = h.domainToProtoDecimal(currency.MarketCap)
+	}
+	return protoCurrency
+}
+
+// protoToCurrencyUpdate собирает изменение из полей, перечисленных в маске.
+// Без маски обновляются все заполненные изменяемые поля, поля только для чтения игнорируются.
+func (h *CurrencyHandler) protoToCurrencyUpdate(currency *currencyv1.Currency, mask *fieldmaskpb.FieldMask) (domain.CurrencyUpdate, error) {
+	var update domain.CurrencyUpdate
+
+	paths := mask.GetPaths()
+	if len(paths) == 0 {
+		if currency.Name != "" {
+			paths = append(paths, "name")
+		}
+		if len(currency.Quotes) > 0 {
+			paths = append(paths, "quotes")
+		}
+		if currency.Decimals != 0 {
+			paths = append(paths, "decimals")
+		}
+		if len(currency.Tags) > 0 {
+			paths = append(paths, "tags")
+		}
+		if currency.Description != "" {
+			paths = append(paths, "description")
+		}
+		if currency.Tracking != currencyv1.TrackingState_TRACKING_STATE_UNSPECIFIED {
+			paths = append(paths, "tracking")
+		}
+	}
+	if len(paths) == 0 {
+		return update, status.Error(codes.InvalidArgument, "nothing to update")
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "name":
+			name := currency.Name
+			update.Name = &name
+		case "quotes":
+			update.Quotes = append([]string{}, currency.Quotes...)
+		case "decimals":
+			decimals := int(currency.Decimals)
+			update.Decimals = &decimals
+		case "tags":
+			update.Tags = append([]string{}, currency.Tags...)
+		case "description":
+			description := currency.Description
+			update.Description = &description
+		case "tracking":
+			tracking := protoToTrackingState(currency.Tracking)
+			if tracking == "" {
+				return update, status.Error(codes.InvalidArgument, "tracking is required")
+			}
+			update.Tracking = &tracking
+		case "symbol", "id", "created_at", "updated_at", "deleted_at", "provider_coin_id", "market_cap":
+			// Символ идентифицирует валюту, остальные поля только для чтения - игнорируем
+		default:
+			return update, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", path)
+		}
+	}
+
+	return update, nil
+}
+
+func (h *CurrencyHandler) protoToPriceLookup(mode currencyv1.LookupMode, maxStaleness *durationpb.Duration) (domain.PriceLookup, error) {
+	lookup := domain.PriceLookup{Mode: protoToLookupMode(mode)}
+	if maxStaleness != nil {
+		if err := maxStaleness.CheckValid(); err != nil || maxStaleness.AsDuration() < 0 {
+			return lookup, status.Error(codes.InvalidArgument, "invalid max_staleness")
+		}
+		lookup.MaxStaleness = maxStaleness.AsDuration()
+	}
+	return lookup, nil
+}
+
+func protoToLookupMode(mode currencyv1.LookupMode) domain.LookupMode {
+	switch mode {
+	case currencyv1.LookupMode_LOOKUP_MODE_NEXT:
+		return domain.LookupNext
+	case currencyv1.LookupMode_LOOKUP_MODE_NEAREST:
+		return domain.LookupNearest
+	case currencyv1.LookupMode_LOOKUP_MODE_INTERPOLATE:
+		return domain.LookupInterpolate
+	case currencyv1.LookupMode_LOOKUP_MODE_PREVIOUS, currencyv1.LookupMode_LOOKUP_MODE_UNSPECIFIED:
+		return domain.LookupPrevious
+	default:
+		return domain.LookupMode(mode.String())
+	}
+}
+
+func protoToTrackingState(state currencyv1.TrackingState) domain.TrackingState {
+	switch state {
+	case currencyv1.TrackingState_TRACKING_STATE_ACTIVE:
+		return domain.TrackingActive
+	case currencyv1.TrackingState_TRACKING_STATE_PAUSED:
+		return domain.TrackingPaused
+	default:
+		return ""
+	}
+}
+
+func trackingStateToProto(state domain.TrackingState) currencyv1.TrackingState {
+	switch state {
+	case domain.TrackingActive:
+		return currencyv1.TrackingState_TRACKING_STATE_ACTIVE
+	case domain.TrackingPaused:
+		return currencyv1.TrackingState_TRACKING_STATE_PAUSED
+	default:
+		return currencyv1.TrackingState_TRACKING_STATE_UNSPECIFIED
 	}
 }
 
 func (h *CurrencyHandler) domainToProtoCurrencyPrice(price *domain.CurrencyPrice) *currencyv1.CurrencyPrice {
 	return &currencyv1.CurrencyPrice{
-		Id:        price.ID,
-		Symbol:    price.Symbol,
-		Price:     price.Price,
-		Timestamp: timestamppb.New(price.Timestamp),
+		Id:          price.ID,
+		Symbol:      price.Symbol,
+		Quote:       price.Quote,
+		Price:       price.Price.Float64(),
+		PriceValue:  h.domainToProtoDecimal(price.Price),
+		Sources:     price.Sources,
+		Source:      price.Source,
+		IsSynthetic: price.IsSynthetic,
+		Timestamp:   timestamppb.New(price.Timestamp),
+	}
+}
+
+func (h *CurrencyHandler) domainToProtoDecimal(value domain.Decimal) *currencyv1.Decimal {
+	return decimalToProto(value)
+}
+
+func decimalToProto(value domain.Decimal) *currencyv1.Decimal {
+	units, nanos := value.UnitsNanos()
+	return &currencyv1.Decimal{
+		Value: value.String(),
+		Units: units,
+		Nanos: nanos,
+	}
+}
+
+func (h *CurrencyHandler) domainToProtoConversionLeg(leg *domain.PriceLookupResult) *currencyv1.ConversionLeg {
+	if leg == nil {
+		return nil
+	}
+	return &currencyv1.ConversionLeg{
+		Price:    h.domainToProtoCurrencyPrice(leg.Price),
+		Distance: durationpb.New(leg.Distance),
+	}
+}
+
+func (h *CurrencyHandler) domainToProtoBackfillJob(job *domain.BackfillJob) *currencyv1.BackfillJob {
+	return &currencyv1.BackfillJob{
+		Id:            job.ID,
+		Symbol:        job.Symbol,
+		Quote:         job.Quote,
+		StartTime:     timestamppb.New(job.StartTime),
+		EndTime:       timestamppb.New(job.EndTime),
+		Cursor:        timestamppb.New(job.Cursor),
+		Status:        job.Status,
+		Progress:      job.Progress(),
+		FetchedCount:  job.FetchedCount,
+		InsertedCount: job.InsertedCount,
+		Error:         job.Error,
+		CreatedAt:     timestamppb.New(job.CreatedAt),
+		UpdatedAt:     timestamppb.New(job.UpdatedAt),
+	}
+}
+
+func (h *CurrencyHandler) domainToProtoCoinOverride(override *domain.CoinOverride) *currencyv1.CoinOverride {
+	return &currencyv1.CoinOverride{
+		Symbol:    override.Symbol,
+		CoinId:    override.CoinID,
+		UpdatedAt: timestamppb.New(override.UpdatedAt),
 	}
 }
 
 func (h *CurrencyHandler) handleError(err error) error {
+	return domainErrorToStatus(err)
+}
+
+// domainErrorToStatus переводит ошибки домена в коды gRPC, общие для всех сервисов
+func domainErrorToStatus(err error) error {
 	switch err {
-	case domain.ErrCurrencyNotFound:
+	case domain.ErrCurrencyNotFound, domain.ErrBackfillJobNotFound, domain.ErrPriceNotFound, domain.ErrAlertRuleNotFound,
+		domain.ErrWebhookEndpointNotFound:
 		return status.Error(codes.NotFound, err.Error())
 	case domain.ErrCurrencyAlreadyExists:
 		return status.Error(codes.AlreadyExists, err.Error())
-	case domain.ErrInvalidCurrencySymbol, domain.ErrInvalidCurrencyName, domain.ErrInvalidPrice:
+	case domain.ErrInvalidCurrencySymbol, domain.ErrInvalidCurrencyName, domain.ErrInvalidPrice,
+		domain.ErrInvalidCandleInterval, domain.ErrCandleIntervalTooFine, domain.ErrInvalidTimeRange, domain.ErrInvalidQuote,
+		domain.ErrQuoteNotTracked, domain.ErrCoinNotInCatalog, domain.ErrInvalidDecimals,
+		domain.ErrInvalidTag, domain.ErrInvalidTrackingState, domain.ErrInvalidPageToken, domain.ErrInvalidOrderBy,
+		domain.ErrInvalidLookupMode, domain.ErrBatchTooLarge, domain.ErrInvalidAmount,
+		domain.ErrInvalidAlertCondition, domain.ErrInvalidAlertThreshold, domain.ErrInvalidAlertWindow, domain.ErrInvalidAlertCooldown,
+		domain.ErrInvalidWebhookURL, domain.ErrInvalidWebhookEvent, domain.ErrInvalidDeliveryStatus:
 		return status.Error(codes.InvalidArgument, err.Error())
+	case domain.ErrAmbiguousSymbol, domain.ErrCurrencyRemoved:
+		return status.Error(codes.FailedPrecondition, err.Error())
 	case domain.ErrDatabaseConnection:
 		return status.Error(codes.Internal, "internal server error")
 	case domain.ErrExternalAPIUnavailable:
 		return status.Error(codes.Unavailable, "external service unavailable")
+	case domain.ErrRateLimited:
+		return status.Error(codes.Unavailable, "external service rate limit exceeded, retry later")
 	default:
 		return status.Error(codes.Internal, "internal server error")
 	}
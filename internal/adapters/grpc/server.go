@@ -23,20 +23,26 @@ import (
 )
 
 type GRPCServer struct {
-	server       *grpc.Server
-	handler      *CurrencyHandler
-	grpcPort     int
-	httpPort     int
-	grpcStarted  chan struct{}
-	healthServer *health.Server
+	server         *grpc.Server
+	handler        *CurrencyHandler
+	alertHandler   *AlertHandler
+	webhookHandler *WebhookHandler
+	grpcPort       int
+	httpPort       int
+	grpcStarted    chan struct{}
+	healthServer   *health.Server
 }
 
-func NewGRPCServer(service ports.CurrencyService, grpcPort, httpPort int) *GRPCServer {
+func NewGRPCServer(service ports.CurrencyService, catalog ports.CoinCatalogService, backfill ports.BackfillService, alerts ports.AlertService, webhooks ports.WebhookService, grpcPort, httpPort int) *GRPCServer {
 	server := grpc.NewServer()
-	handler := NewCurrencyHandler(service)
+	handler := NewCurrencyHandler(service, catalog, backfill)
+	alertHandler := NewAlertHandler(alerts)
+	webhookHandler := NewWebhookHandler(webhooks)
 	healthServer := health.NewServer()
 
 	currencyv1.RegisterCurrencyServiceServer(server, handler)
+	currencyv1.RegisterAlertServiceServer(server, alertHandler)
+	currencyv1.RegisterWebhookServiceServer(server, webhookHandler)
 
 	grpc_health_v1.RegisterHealthServer(server, healthServer)
 	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
@@ -44,12 +50,14 @@ func NewGRPCServer(service ports.CurrencyService, grpcPort, httpPort int) *GRPCS
 	reflection.Register(server)
 
 	return &GRPCServer{
-		server:       server,
-		handler:      handler,
-		grpcPort:     grpcPort,
-		httpPort:     httpPort,
-		grpcStarted:  make(chan struct{}),
-		healthServer: healthServer,
+		server:         server,
+		handler:        handler,
+		alertHandler:   alertHandler,
+		webhookHandler: webhookHandler,
+		grpcPort:       grpcPort,
+		httpPort:       httpPort,
+		grpcStarted:    make(chan struct{}),
+		healthServer:   healthServer,
 	}
 }
 
@@ -107,6 +115,7 @@ func (s *GRPCServer) startHTTPGateway() error {
 	mux := runtime.NewServeMux(
 		runtime.WithErrorHandler(s.customErrorHandler),
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{}),
+		runtime.WithMarshalerOption(eventStreamMIME, &eventStreamMarshaler{}),
 		runtime.WithIncomingHeaderMatcher(s.customHeaderMatcher),
 	)
 
@@ -133,6 +142,18 @@ func (s *GRPCServer) startHTTPGateway() error {
 		return fmt.Errorf("failed to register gateway: %w", err)
 	}
 
+	err = currencyv1.RegisterAlertServiceHandler(ctx, mux, conn)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to register alert gateway: %w", err)
+	}
+
+	err = currencyv1.RegisterWebhookServiceHandler(ctx, mux, conn)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to register webhook gateway: %w", err)
+	}
+
 	log.Printf("✅ Gateway registered successfully")
 
 	handler := s.corsMiddleware(TimeValidationMiddleware(s.loggingMiddleware(mux)))
@@ -184,7 +205,7 @@ func (s *GRPCServer) customErrorHandler(ctx context.Context, mux *runtime.ServeM
 func (s *GRPCServer) corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Accept")
 
 		if r.Method == "OPTIONS" {
@@ -212,6 +233,19 @@ func (s *GRPCServer) Stop() {
 	if s.healthServer != nil {
 		s.healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
 	}
-	s.server.GracefulStop()
+
+	// Потоковые RPC не завершаются сами, поэтому ждём их ограниченное время
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		log.Println("⚠️  Graceful stop timeout, closing active streams")
+		s.server.Stop()
+	}
 	log.Println("✅ Servers stopped")
 }
@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+const eventStreamMIME = "text/event-stream"
+
+// eventStreamMarshaler отдаёт потоковые ответы gateway в формате Server-Sent Events.
+// Выбирается, когда клиент присылает заголовок Accept: text/event-stream.
+type eventStreamMarshaler struct {
+	runtime.JSONPb
+}
+
+func (m *eventStreamMarshaler) ContentType(_ interface{}) string {
+	return eventStreamMIME
+}
+
+func (m *eventStreamMarshaler) Marshal(v interface{}) ([]byte, error) {
+	data, err := m.JSONPb.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte("data: "), data...), nil
+}
+
+func (m *eventStreamMarshaler) Delimiter() []byte {
+	return []byte("\n\n")
+}
@@ -0,0 +1,285 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+	currencyv1 "github.com/kk7453603/RybakovTestGo/pkg/api/gen"
+)
+
+type WebhookHandler struct {
+	currencyv1.UnimplementedWebhookServiceServer
+	webhooks ports.WebhookService
+}
+
+func NewWebhookHandler(webhooks ports.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhooks: webhooks}
+}
+
+func (h *WebhookHandler) CreateWebhookEndpoint(ctx context.Context, req *currencyv1.CreateWebhookEndpointRequest) (*currencyv1.WebhookEndpoint, error) {
+	if req.Endpoint == nil || req.Endpoint.Url == "" {
+		return nil, status.Error(codes.InvalidArgument, "endpoint.url is required")
+	}
+
+	endpoint, err := h.webhooks.CreateEndpoint(ctx, &domain.WebhookEndpoint{
+		URL:         req.Endpoint.Url,
+		Events:      protoToWebhookEvents(req.Endpoint.Events),
+		Description: req.Endpoint.Description,
+		Enabled:     req.Endpoint.Enabled == nil || *req.Endpoint.Enabled,
+	})
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	protoEndpoint := h.domainToProtoWebhookEndpoint(endpoint)
+	protoEndpoint.Secret = endpoint.Secret
+	return protoEndpoint, nil
+}
+
+func (h *WebhookHandler) GetWebhookEndpoint(ctx context.Context, req *currencyv1.GetWebhookEndpointRequest) (*currencyv1.WebhookEndpoint, error) {
+	endpoint, err := h.webhooks.GetEndpoint(ctx, req.Id)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return h.domainToProtoWebhookEndpoint(endpoint), nil
+}
+
+func (h *WebhookHandler) ListWebhookEndpoints(ctx context.Context, _ *emptypb.Empty) (*currencyv1.ListWebhookEndpointsResponse, error) {
+	endpoints, err := h.webhooks.ListEndpoints(ctx)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	protoEndpoints := make([]*currencyv1.WebhookEndpoint, len(endpoints))
+	for i, endpoint := range endpoints {
+		protoEndpoints[i] = h.domainToProtoWebhookEndpoint(endpoint)
+	}
+
+	return &currencyv1.ListWebhookEndpointsResponse{Endpoints: protoEndpoints}, nil
+}
+
+func (h *WebhookHandler) UpdateWebhookEndpoint(ctx context.Context, req *currencyv1.UpdateWebhookEndpointRequest) (*currencyv1.WebhookEndpoint, error) {
+	if req.Endpoint == nil || req.Endpoint.Id == 0 {
+		return nil, status.Error(codes.InvalidArgument, "endpoint.id is required")
+	}
+
+	update, err := h.protoToWebhookEndpointUpdate(req.Endpoint, req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := h.webhooks.UpdateEndpoint(ctx, req.Endpoint.Id, update)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return h.domainToProtoWebhookEndpoint(endpoint), nil
+}
+
+func (h *WebhookHandler) DeleteWebhookEndpoint(ctx context.Context, req *currencyv1.DeleteWebhookEndpointRequest) (*emptypb.Empty, error) {
+	if err := h.webhooks.DeleteEndpoint(ctx, req.Id); err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *WebhookHandler) ListWebhookDeliveries(ctx context.Context, req *currencyv1.ListWebhookDeliveriesRequest) (*currencyv1.ListWebhookDeliveriesResponse, error) {
+	if req.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+
+	page, err := h.webhooks.ListDeliveries(ctx, domain.WebhookDeliveryQuery{
+		EndpointID: req.EndpointId,
+		Status:     protoToDeliveryStatus(req.Status),
+		PageSize:   int(req.PageSize),
+		PageToken:  req.PageToken,
+	})
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	deliveries := make([]*currencyv1.WebhookDelivery, len(page.Deliveries))
+	for i, delivery := range page.Deliveries {
+		deliveries[i] = h.domainToProtoWebhookDelivery(delivery)
+	}
+
+	return &currencyv1.ListWebhookDeliveriesResponse{
+		Deliveries:    deliveries,
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+// protoToWebhookEndpointUpdate собирает изменение из полей, перечисленных в маске.
+// Без маски обновляются все заполненные изменяемые поля, поля только для чтения игнорируются.
+func (h *WebhookHandler) protoToWebhookEndpointUpdate(endpoint *currencyv1.WebhookEndpoint, mask *fieldmaskpb.FieldMask) (domain.WebhookEndpointUpdate, error) {
+	var update domain.WebhookEndpointUpdate
+
+	paths := mask.GetPaths()
+	if len(paths) == 0 {
+		if endpoint.Url != "" {
+			paths = append(paths, "url")
+		}
+		if len(endpoint.Events) > 0 {
+			paths = append(paths, "events")
+		}
+		if endpoint.Description != "" {
+			paths = append(paths, "description")
+		}
+		if endpoint.Enabled != nil {
+			paths = append(paths, "enabled")
+		}
+	}
+	if len(paths) == 0 {
+		return update, status.Error(codes.InvalidArgument, "nothing to update")
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "url":
+			url := endpoint.Url
+			update.URL = &url
+		case "events":
+			update.Events = protoToWebhookEvents(endpoint.Events)
+			if update.Events == nil {
+				update.Events = []domain.WebhookEvent{}
+			}
+		case "description":
+			description := endpoint.Description
+			update.Description = &description
+		case "enabled":
+			enabled := endpoint.GetEnabled()
+			update.Enabled = &enabled
+		case "id", "secret", "consecutive_failures", "disabled_at", "created_at", "updated_at":
+			// Секрет задаётся только при создании, остальные поля только для чтения - игнорируем
+		default:
+			return update, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", path)
+		}
+	}
+
+	return update, nil
+}
+
+func protoToWebhookEvents(events []currencyv1.WebhookEvent) []domain.WebhookEvent {
+	if len(events) == 0 {
+		return nil
+	}
+
+	result := make([]domain.WebhookEvent, len(events))
+	for i, event := range events {
+		result[i] = protoToWebhookEvent(event)
+	}
+	return result
+}
+
+func protoToWebhookEvent(event currencyv1.WebhookEvent) domain.WebhookEvent {
+	switch event {
+	case currencyv1.WebhookEvent_WEBHOOK_EVENT_PRICE_UPDATED:
+		return domain.EventPriceUpdated
+	case currencyv1.WebhookEvent_WEBHOOK_EVENT_CURRENCY_ADDED:
+		return domain.EventCurrencyAdded
+	case currencyv1.WebhookEvent_WEBHOOK_EVENT_CURRENCY_REMOVED:
+		return domain.EventCurrencyRemoved
+	default:
+		return ""
+	}
+}
+
+func webhookEventToProto(event domain.WebhookEvent) currencyv1.WebhookEvent {
+	switch event {
+	case domain.EventPriceUpdated:
+		return currencyv1.WebhookEvent_WEBHOOK_EVENT_PRICE_UPDATED
+	case domain.EventCurrencyAdded:
+		return currencyv1.WebhookEvent_WEBHOOK_EVENT_CURRENCY_ADDED
+	case domain.EventCurrencyRemoved:
+		return currencyv1.WebhookEvent_WEBHOOK_EVENT_CURRENCY_REMOVED
+	default:
+		return currencyv1.WebhookEvent_WEBHOOK_EVENT_UNSPECIFIED
+	}
+}
+
+func protoToDeliveryStatus(deliveryStatus currencyv1.WebhookDeliveryStatus) string {
+	switch deliveryStatus {
+	case currencyv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED:
+		return ""
+	case currencyv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING:
+		return domain.DeliveryPending
+	case currencyv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_SUCCEEDED:
+		return domain.DeliverySucceeded
+	case currencyv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED:
+		return domain.DeliveryFailed
+	default:
+		// Неизвестное значение отклонит сервис
+		return deliveryStatus.String()
+	}
+}
+
+func deliveryStatusToProto(deliveryStatus string) currencyv1.WebhookDeliveryStatus {
+	switch deliveryStatus {
+	case domain.DeliveryPending:
+		return currencyv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING
+	case domain.DeliverySucceeded:
+		return currencyv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_SUCCEEDED
+	case domain.DeliveryFailed:
+		return currencyv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED
+	default:
+		return currencyv1.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED
+	}
+}
+
+func (h *WebhookHandler) domainToProtoWebhookEndpoint(endpoint *domain.WebhookEndpoint) *currencyv1.WebhookEndpoint {
+	events := make([]currencyv1.WebhookEvent, len(endpoint.Events))
+	for i, event := range endpoint.Events {
+		events[i] = webhookEventToProto(event)
+	}
+
+	enabled := endpoint.Enabled
+	protoEndpoint := &currencyv1.WebhookEndpoint{
+		Id:                  endpoint.ID,
+		Url:                 endpoint.URL,
+		Events:              events,
+		Description:         endpoint.Description,
+		Enabled:             &enabled,
+		ConsecutiveFailures: int32(endpoint.ConsecutiveFailures),
+		CreatedAt:           timestamppb.New(endpoint.CreatedAt),
+		UpdatedAt:           timestamppb.New(endpoint.UpdatedAt),
+	}
+	if endpoint.DisabledAt != nil {
+		protoEndpoint.DisabledAt = timestamppb.New(*endpoint.DisabledAt)
+	}
+	return protoEndpoint
+}
+
+func (h *WebhookHandler) domainToProtoWebhookDelivery(delivery *domain.WebhookDelivery) *currencyv1.WebhookDelivery {
+	protoDelivery := &currencyv1.WebhookDelivery{
+		Id:             delivery.ID,
+		EndpointId:     delivery.EndpointID,
+		EventId:        delivery.EventID,
+		Event:          webhookEventToProto(delivery.Event),
+		Payload:        string(delivery.Payload),
+		Status:         deliveryStatusToProto(delivery.Status),
+		Attempts:       int32(delivery.Attempts),
+		LastStatusCode: int32(delivery.LastStatusCode),
+		LastError:      delivery.LastError,
+		CreatedAt:      timestamppb.New(delivery.CreatedAt),
+	}
+	if !delivery.IsFinished() {
+		protoDelivery.NextAttemptAt = timestamppb.New(delivery.NextAttemptAt)
+	}
+	if delivery.DeliveredAt != nil {
+		protoDelivery.DeliveredAt = timestamppb.New(*delivery.DeliveredAt)
+	}
+	return protoDelivery
+}
+
+func (h *WebhookHandler) handleError(err error) error {
+	return domainErrorToStatus(err)
+}
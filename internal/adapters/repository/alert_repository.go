@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+type AlertRuleModel struct {
+	ID              int64          `gorm:"primaryKey;autoIncrement"`
+	Symbol          string         `gorm:"not null;size:10"`
+	Quote           string         `gorm:"not null;size:10"`
+	Condition       string         `gorm:"not null;size:20"`
+	Threshold       domain.Decimal `gorm:"type:numeric;not null"`
+	WindowSeconds   int64          `gorm:"not null"`
+	CooldownSeconds int64          `gorm:"not null"`
+	Enabled         bool           `gorm:"not null"`
+	Armed           bool           `gorm:"not null"`
+	Description     string         `gorm:"not null"`
+	LastTriggeredAt *time.Time
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime"`
+}
+
+func (AlertRuleModel) TableName() string {
+	return "alert_rules"
+}
+
+func (m AlertRuleModel) toDomain() *domain.AlertRule {
+	return &domain.AlertRule{
+		ID:              m.ID,
+		Symbol:          m.Symbol,
+		Quote:           m.Quote,
+		Condition:       domain.AlertCondition(m.Condition),
+		Threshold:       m.Threshold,
+		Window:          time.Duration(m.WindowSeconds) * time.Second,
+		Cooldown:        time.Duration(m.CooldownSeconds) * time.Second,
+		Enabled:         m.Enabled,
+		Armed:           m.Armed,
+		Description:     m.Description,
+		LastTriggeredAt: m.LastTriggeredAt,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+func alertRuleToModel(rule *domain.AlertRule) *AlertRuleModel {
+	return &AlertRuleModel{
+		ID:              rule.ID,
+		Symbol:          rule.Symbol,
+		Quote:           rule.Quote,
+		Condition:       string(rule.Condition),
+		Threshold:       rule.Threshold,
+		WindowSeconds:   int64(rule.Window / time.Second),
+		CooldownSeconds: int64(rule.Cooldown / time.Second),
+		Enabled:         rule.Enabled,
+		Armed:           rule.Armed,
+		Description:     rule.Description,
+		LastTriggeredAt: rule.LastTriggeredAt,
+		CreatedAt:       rule.CreatedAt,
+		UpdatedAt:       rule.UpdatedAt,
+	}
+}
+
+type AlertTriggerModel struct {
+	ID             int64           `gorm:"primaryKey;autoIncrement"`
+	RuleID         int64           `gorm:"not null"`
+	Symbol         string          `gorm:"not null;size:10"`
+	Quote          string          `gorm:"not null;size:10"`
+	Condition      string          `gorm:"not null;size:20"`
+	Threshold      domain.Decimal  `gorm:"type:numeric;not null"`
+	Price          domain.Decimal  `gorm:"type:numeric;not null"`
+	PriceTime      time.Time       `gorm:"not null"`
+	ReferencePrice *domain.Decimal `gorm:"type:numeric"`
+	ChangePercent  *domain.Decimal `gorm:"type:numeric"`
+	TriggeredAt    time.Time       `gorm:"not null"`
+}
+
+func (AlertTriggerModel) TableName() string {
+	return "alert_triggers"
+}
+
+func (m AlertTriggerModel) toDomain() *domain.AlertTrigger {
+	trigger := &domain.AlertTrigger{
+		ID:          m.ID,
+		RuleID:      m.RuleID,
+		Symbol:      m.Symbol,
+		Quote:       m.Quote,
+		Condition:   domain.AlertCondition(m.Condition),
+		Threshold:   m.Threshold,
+		Price:       m.Price,
+		PriceTime:   m.PriceTime,
+		TriggeredAt: m.TriggeredAt,
+	}
+	if m.ReferencePrice != nil {
+		trigger.ReferencePrice = *m.ReferencePrice
+	}
+	if m.ChangePercent != nil {
+		trigger.ChangePercent = *m.ChangePercent
+	}
+	return trigger
+}
+
+func alertTriggerToModel(trigger *domain.AlertTrigger) *AlertTriggerModel {
+	model := &AlertTriggerModel{
+		RuleID:      trigger.RuleID,
+		Symbol:      trigger.Symbol,
+		Quote:       trigger.Quote,
+		Condition:   string(trigger.Condition),
+		Threshold:   trigger.Threshold,
+		Price:       trigger.Price,
+		PriceTime:   trigger.PriceTime,
+		TriggeredAt: trigger.TriggeredAt,
+	}
+	// Изменение в процентах есть только у percent_change, для остальных условий колонки остаются NULL
+	if trigger.Condition == domain.AlertPercentChange {
+		model.ReferencePrice = &trigger.ReferencePrice
+		model.ChangePercent = &trigger.ChangePercent
+	}
+	return model
+}
+
+type alertRepository struct {
+	db *gorm.DB
+}
+
+func NewAlertRepository(db *gorm.DB) ports.AlertRepository {
+	return &alertRepository{db: db}
+}
+
+func (r *alertRepository) CreateRule(ctx context.Context, rule *domain.AlertRule) error {
+	model := alertRuleToModel(rule)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	rule.ID = model.ID
+	rule.CreatedAt = model.CreatedAt
+	rule.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *alertRepository) GetRule(ctx context.Context, id int64) (*domain.AlertRule, error) {
+	var model AlertRuleModel
+	result := r.db.WithContext(ctx).First(&model, id)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, domain.ErrAlertRuleNotFound
+		}
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	return model.toDomain(), nil
+}
+
+func (r *alertRepository) ListRules(ctx context.Context, symbol string) ([]*domain.AlertRule, error) {
+	query := r.db.WithContext(ctx).Order("id")
+	if symbol != "" {
+		query = query.Where("symbol = ?", symbol)
+	}
+	return r.findRules(query)
+}
+
+func (r *alertRepository) ListEnabledRules(ctx context.Context, symbol, quote string) ([]*domain.AlertRule, error) {
+	query := r.db.WithContext(ctx).
+		Where("symbol = ? AND quote = ? AND enabled", symbol, quote).
+		Order("id")
+	return r.findRules(query)
+}
+
+func (r *alertRepository) findRules(query *gorm.DB) ([]*domain.AlertRule, error) {
+	var models []AlertRuleModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	rules := make([]*domain.AlertRule, len(models))
+	for i, model := range models {
+		rules[i] = model.toDomain()
+	}
+
+	return rules, nil
+}
+
+func (r *alertRepository) UpdateRule(ctx context.Context, rule *domain.AlertRule) error {
+	model := alertRuleToModel(rule)
+	model.UpdatedAt = time.Now()
+
+	// last_triggered_at меняет только RecordTrigger, чтобы изменение правила не сбрасывало охлаждение
+	result := r.db.WithContext(ctx).
+		Model(&AlertRuleModel{}).
+		Where("id = ?", rule.ID).
+		Select("condition", "threshold", "window_seconds", "cooldown_seconds", "enabled", "armed", "description", "updated_at").
+		Updates(model)
+
+	if result.Error != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrAlertRuleNotFound
+	}
+
+	rule.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *alertRepository) DeleteRule(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).Delete(&AlertRuleModel{}, id)
+
+	if result.Error != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrAlertRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *alertRepository) RecordTrigger(ctx context.Context, trigger *domain.AlertTrigger, notAfter time.Time) (bool, error) {
+	recorded := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Условия на last_triggered_at и armed не дают двум одновременным проверкам записать два срабатывания.
+		// Правило уровня после срабатывания ждёт возврата цены за порог, percent_change остаётся взведённым.
+		query := tx.Model(&AlertRuleModel{}).
+			Where("id = ? AND enabled AND (last_triggered_at IS NULL OR last_triggered_at <= ?)", trigger.RuleID, notAfter)
+		level := trigger.Condition == domain.AlertAbove || trigger.Condition == domain.AlertBelow
+		if level {
+			query = query.Where("armed")
+		}
+		result := query.UpdateColumns(map[string]interface{}{
+			"last_triggered_at": trigger.TriggeredAt,
+			"armed":             !level,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		model := alertTriggerToModel(trigger)
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		trigger.ID = model.ID
+		recorded = true
+		return nil
+	})
+	if err != nil {
+		return false, domain.ErrDatabaseConnection
+	}
+
+	return recorded, nil
+}
+
+func (r *alertRepository) RearmRule(ctx context.Context, id int64) error {
+	err := r.db.WithContext(ctx).
+		Model(&AlertRuleModel{}).
+		Where("id = ? AND NOT armed", id).
+		UpdateColumn("armed", true).Error
+	if err != nil {
+		return domain.ErrDatabaseConnection
+	}
+	return nil
+}
+
+func (r *alertRepository) ListTriggers(ctx context.Context, ruleID int64, symbol string, beforeID int64, limit int) ([]*domain.AlertTrigger, error) {
+	query := r.db.WithContext(ctx).Order("id DESC").Limit(limit)
+	if ruleID != 0 {
+		query = query.Where("rule_id = ?", ruleID)
+	}
+	if symbol != "" {
+		query = query.Where("symbol = ?", symbol)
+	}
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+
+	var models []AlertTriggerModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	triggers := make([]*domain.AlertTrigger, len(models))
+	for i, model := range models {
+		triggers[i] = model.toDomain()
+	}
+
+	return triggers, nil
+}
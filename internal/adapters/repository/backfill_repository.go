@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+type BackfillJobModel struct {
+	ID            int64     `gorm:"primaryKey;autoIncrement"`
+	Symbol        string    `gorm:"index;not null;size:10"`
+	Quote         string    `gorm:"not null;size:10"`
+	StartTime     time.Time `gorm:"not null"`
+	EndTime       time.Time `gorm:"not null"`
+	Cursor        time.Time `gorm:"not null"`
+	Status        string    `gorm:"index;not null;size:20"`
+	FetchedCount  int64     `gorm:"not null;default:0"`
+	InsertedCount int64     `gorm:"not null;default:0"`
+	Error         string    `gorm:"type:text"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+}
+
+func (BackfillJobModel) TableName() string {
+	return "backfill_jobs"
+}
+
+func (m BackfillJobModel) toDomain() *domain.BackfillJob {
+	return &domain.BackfillJob{
+		ID:            m.ID,
+		Symbol:        m.Symbol,
+		Quote:         m.Quote,
+		StartTime:     m.StartTime,
+		EndTime:       m.EndTime,
+		Cursor:        m.Cursor,
+		Status:        m.Status,
+		FetchedCount:  m.FetchedCount,
+		InsertedCount: m.InsertedCount,
+		Error:         m.Error,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+func backfillJobToModel(job *domain.BackfillJob) *BackfillJobModel {
+	return &BackfillJobModel{
+		ID:            job.ID,
+		Symbol:        job.Symbol,
+		Quote:         job.Quote,
+		StartTime:     job.StartTime,
+		EndTime:       job.EndTime,
+		Cursor:        job.Cursor,
+		Status:        job.Status,
+		FetchedCount:  job.FetchedCount,
+		InsertedCount: job.InsertedCount,
+		Error:         job.Error,
+		CreatedAt:     job.CreatedAt,
+	}
+}
+
+type backfillRepository struct {
+	db *gorm.DB
+}
+
+func NewBackfillRepository(db *gorm.DB) ports.BackfillRepository {
+	return &backfillRepository{db: db}
+}
+
+func (r *backfillRepository) CreateJob(ctx context.Context, job *domain.BackfillJob) error {
+	model := backfillJobToModel(job)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	job.ID = model.ID
+	job.CreatedAt = model.CreatedAt
+	job.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *backfillRepository) GetJob(ctx context.Context, id int64) (*domain.BackfillJob, error) {
+	var model BackfillJobModel
+	result := r.db.WithContext(ctx).First(&model, id)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, domain.ErrBackfillJobNotFound
+		}
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	return model.toDomain(), nil
+}
+
+func (r *backfillRepository) UpdateJob(ctx context.Context, job *domain.BackfillJob) error {
+	model := backfillJobToModel(job)
+
+	result := r.db.WithContext(ctx).Save(model)
+	if result.Error != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	job.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *backfillRepository) ListUnfinishedJobs(ctx context.Context) ([]*domain.BackfillJob, error) {
+	var models []BackfillJobModel
+	result := r.db.WithContext(ctx).
+		Where("status IN ?", []string{domain.BackfillPending, domain.BackfillRunning}).
+		Order("id").
+		Find(&models)
+
+	if result.Error != nil {
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	jobs := make([]*domain.BackfillJob, len(models))
+	for i, model := range models {
+		jobs[i] = model.toDomain()
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+const (
+	binanceBaseURL     = "https://api.binance.com"
+	binanceKlinesLimit = 1000
+)
+
+// binanceQuotes - котировки, которые на Binance торгуются через стейблкоин
+var binanceQuotes = map[string]string{
+	"USD": "USDT",
+}
+
+type binanceProvider struct {
+	client  *http.Client
+	baseURL string
+}
+
+func NewBinanceProvider(baseURL string, client *http.Client) ports.ExternalPriceProvider {
+	if baseURL == "" {
+		baseURL = binanceBaseURL
+	}
+
+	return &binanceProvider{
+		client:  client,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+type binanceTickerResponse struct {
+	Symbol string      `json:"symbol"`
+	Price  json.Number `json:"price"`
+}
+
+func (p *binanceProvider) pair(symbol, quote string) string {
+	if mapped, ok := binanceQuotes[quote]; ok {
+		quote = mapped
+	}
+	return strings.ToUpper(symbol) + quote
+}
+
+func (p *binanceProvider) GetCurrentPrice(ctx context.Context, symbol, quote string) (*domain.CurrencyPrice, error) {
+	quote = domain.NormalizeQuote(quote)
+	url := fmt.Sprintf("%s/api/v3/ticker/price?symbol=%s", p.baseURL, p.pair(symbol, quote))
+
+	var data binanceTickerResponse
+	if err := getJSON(ctx, p.client, url, nil, &data); err != nil {
+		return nil, err
+	}
+
+	price, err := domain.ParseDecimal(data.Price.String())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrExternalAPIUnavailable, err)
+	}
+
+	return &domain.CurrencyPrice{
+		Symbol:    strings.ToUpper(symbol),
+		Quote:     quote,
+		Price:     price,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+func (p *binanceProvider) GetHistoricalPrices(ctx context.Context, symbol, quote string, startTime, endTime time.Time) ([]*domain.CurrencyPrice, error) {
+	quote = domain.NormalizeQuote(quote)
+	startTime, endTime = defaultHistoryRange(startTime, endTime)
+
+	interval := "1d"
+	switch historyStep(startTime, endTime) {
+	case 5 * time.Minute:
+		interval = "5m"
+	case time.Hour:
+		interval = "1h"
+	}
+
+	url := fmt.Sprintf("%s/api/v3/klines?symbol=%s&interval=%s&startTime=%d&endTime=%d&limit=%d",
+		p.baseURL, p.pair(symbol, quote), interval, startTime.UnixMilli(), endTime.UnixMilli(), binanceKlinesLimit)
+
+	// Свеча: [open time, open, high, low, close, volume, close time, ...]
+	var klines [][]json.RawMessage
+	if err := getJSON(ctx, p.client, url, nil, &klines); err != nil {
+		return nil, err
+	}
+
+	prices := make([]*domain.CurrencyPrice, 0, len(klines))
+	for _, kline := range klines {
+		if len(kline) < 5 {
+			continue
+		}
+
+		var openTime int64
+		var closePrice string
+		if json.Unmarshal(kline[0], &openTime) != nil || json.Unmarshal(kline[4], &closePrice) != nil {
+			continue
+		}
+
+		price, err := domain.ParseDecimal(closePrice)
+		if err != nil {
+			continue
+		}
+
+		prices = append(prices, &domain.CurrencyPrice{
+			Symbol:    strings.ToUpper(symbol),
+			Quote:     quote,
+			Price:     price,
+			Timestamp: time.UnixMilli(openTime),
+		})
+	}
+
+	return prices, nil
+}
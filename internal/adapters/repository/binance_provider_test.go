@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+func TestBinanceCurrentPrice(t *testing.T) {
+	server := jsonServer(t, "/api/v3/ticker/price", `{"symbol":"BTCUSDT","price":"117416.12000000"}`, func(r *http.Request) {
+		// USD торгуется на Binance через USDT
+		if got := r.URL.Query().Get("symbol"); got != "BTCUSDT" {
+			t.Errorf("symbol = %q, want BTCUSDT", got)
+		}
+	})
+
+	price, err := NewBinanceProvider(server.URL, server.Client()).GetCurrentPrice(context.Background(), "btc", "usd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price.Symbol != "BTC" || price.Quote != "USD" {
+		t.Errorf("pair = %s/%s, want BTC/USD", price.Symbol, price.Quote)
+	}
+	checkPrice(t, price, "117416.12", time.Time{})
+}
+
+func TestBinanceHistoricalPrices(t *testing.T) {
+	end := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	start := end.Add(-2 * time.Hour)
+	body := `[
+		[1709330400000, "61000.0", "61500.0", "60900.0", "61200.5", "10.5", 1709330699999],
+		[1709330700000, "61200.5"],
+		[1709331000000, "61200.5", "61300.0", "61100.0", "61250.25", "3.2", 1709331299999]
+	]`
+	server := jsonServer(t, "/api/v3/klines", body, func(r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("interval") != "5m" || query.Get("symbol") != "ETHUSDT" {
+			t.Errorf("query = %v, want 5m klines for ETHUSDT", query)
+		}
+	})
+
+	prices, err := NewBinanceProvider(server.URL, server.Client()).GetHistoricalPrices(context.Background(), "ETH", "USD", start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Неполная свеча пропускается
+	if len(prices) != 2 {
+		t.Fatalf("got %d prices, want 2", len(prices))
+	}
+	checkPrice(t, prices[0], "61200.5", time.UnixMilli(1709330400000))
+	checkPrice(t, prices[1], "61250.25", time.UnixMilli(1709331000000))
+}
+
+func TestBinanceStatusMapping(t *testing.T) {
+	checkStatusMapping(t, func(baseURL string) ports.ExternalPriceProvider {
+		return NewBinanceProvider(baseURL, http.DefaultClient)
+	})
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+const catalogBatchSize = 1000
+
+// overridesSeededMarker - отметка в coin_catalog_state о добавленных привязках по умолчанию
+const overridesSeededMarker = "overrides_seeded"
+
+type CoinCatalogModel struct {
+	CoinID    string    `gorm:"primaryKey;size:150"`
+	Symbol    string    `gorm:"index;not null;size:50"`
+	Name      string    `gorm:"not null;size:200"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (CoinCatalogModel) TableName() string {
+	return "coin_catalog"
+}
+
+type CoinOverrideModel struct {
+	Symbol    string    `gorm:"primaryKey;size:50"`
+	CoinID    string    `gorm:"not null;size:150"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (CoinOverrideModel) TableName() string {
+	return "coin_id_overrides"
+}
+
+func (m CoinOverrideModel) toDomain() *domain.CoinOverride {
+	return &domain.CoinOverride{
+		Symbol:    m.Symbol,
+		CoinID:    m.CoinID,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+type coinCatalogRepository struct {
+	db *gorm.DB
+}
+
+func NewCoinCatalogRepository(db *gorm.DB) ports.CoinCatalogRepository {
+	return &coinCatalogRepository{db: db}
+}
+
+func (r *coinCatalogRepository) ReplaceCatalog(ctx context.Context, coins []domain.Coin) error {
+	models := make([]CoinCatalogModel, 0, len(coins))
+	seen := make(map[string]struct{}, len(coins))
+	for _, coin := range coins {
+		if _, ok := seen[coin.ID]; ok || coin.ID == "" {
+			continue
+		}
+		seen[coin.ID] = struct{}{}
+		models = append(models, CoinCatalogModel{
+			CoinID: coin.ID,
+			Symbol: coin.Symbol,
+			Name:   coin.Name,
+		})
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&CoinCatalogModel{}).Error; err != nil {
+			return err
+		}
+		if len(models) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(models, catalogBatchSize).Error
+	})
+	if err != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	return nil
+}
+
+func (r *coinCatalogRepository) FindBySymbol(ctx context.Context, symbol string) ([]domain.Coin, error) {
+	var models []CoinCatalogModel
+	result := r.db.WithContext(ctx).
+		Where("UPPER(symbol) = UPPER(?)", symbol).
+		Order("coin_id").
+		Find(&models)
+
+	if result.Error != nil {
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	coins := make([]domain.Coin, len(models))
+	for i, model := range models {
+		coins[i] = domain.Coin{
+			ID:     model.CoinID,
+			Symbol: model.Symbol,
+			Name:   model.Name,
+		}
+	}
+
+	return coins, nil
+}
+
+func (r *coinCatalogRepository) CoinExists(ctx context.Context, coinID string) (bool, error) {
+	var count int64
+	result := r.db.WithContext(ctx).Model(&CoinCatalogModel{}).Where("coin_id = ?", coinID).Count(&count)
+	if result.Error != nil {
+		return false, domain.ErrDatabaseConnection
+	}
+
+	return count > 0, nil
+}
+
+func (r *coinCatalogRepository) CatalogSize(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&CoinCatalogModel{}).Count(&count).Error; err != nil {
+		return 0, domain.ErrDatabaseConnection
+	}
+
+	return count, nil
+}
+
+func (r *coinCatalogRepository) GetOverride(ctx context.Context, symbol string) (*domain.CoinOverride, error) {
+	var model CoinOverrideModel
+	result := r.db.WithContext(ctx).Where("symbol = ?", symbol).First(&model)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, domain.ErrCurrencyNotFound
+		}
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	return model.toDomain(), nil
+}
+
+func (r *coinCatalogRepository) ListOverrides(ctx context.Context) ([]*domain.CoinOverride, error) {
+	var models []CoinOverrideModel
+	if err := r.db.WithContext(ctx).Order("symbol").Find(&models).Error; err != nil {
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	overrides := make([]*domain.CoinOverride, len(models))
+	for i, model := range models {
+		overrides[i] = model.toDomain()
+	}
+
+	return overrides, nil
+}
+
+func (r *coinCatalogRepository) SaveOverride(ctx context.Context, override *domain.CoinOverride) error {
+	model := &CoinOverrideModel{
+		Symbol: override.Symbol,
+		CoinID: override.CoinID,
+	}
+
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "symbol"}},
+		DoUpdates: clause.AssignmentColumns([]string{"coin_id", "updated_at"}),
+	}).Create(model)
+	if result.Error != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	override.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *coinCatalogRepository) CreateOverrideIfMissing(ctx context.Context, override *domain.CoinOverride) error {
+	model := &CoinOverrideModel{
+		Symbol: override.Symbol,
+		CoinID: override.CoinID,
+	}
+
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(model)
+	if result.Error != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	return nil
+}
+
+func (r *coinCatalogRepository) OverridesSeeded(ctx context.Context) (bool, error) {
+	var seeded bool
+	result := r.db.WithContext(ctx).Raw(
+		`SELECT EXISTS (SELECT 1 FROM coin_catalog_state WHERE name = ?)`, overridesSeededMarker,
+	).Scan(&seeded)
+
+	if result.Error != nil {
+		return false, domain.ErrDatabaseConnection
+	}
+	return seeded, nil
+}
+
+func (r *coinCatalogRepository) MarkOverridesSeeded(ctx context.Context) error {
+	result := r.db.WithContext(ctx).Exec(
+		`INSERT INTO coin_catalog_state (name) VALUES (?) ON CONFLICT (name) DO NOTHING`, overridesSeededMarker,
+	)
+	if result.Error != nil {
+		return domain.ErrDatabaseConnection
+	}
+	return nil
+}
+
+func (r *coinCatalogRepository) DeleteOverride(ctx context.Context, symbol string) error {
+	result := r.db.WithContext(ctx).Where("symbol = ?", symbol).Delete(&CoinOverrideModel{})
+
+	if result.Error != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrCurrencyNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+const coinCapBaseURL = "https://api.coincap.io/v2"
+
+// coinCapAssetIDs - идентификаторы CoinCap, отличающиеся от идентификаторов CoinGecko
+var coinCapAssetIDs = map[string]string{
+	"MATIC": "polygon",
+	"AVAX":  "avalanche",
+	"DOGE":  "dogecoin",
+	"XRP":   "xrp",
+}
+
+type coinCapProvider struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+}
+
+func NewCoinCapProvider(baseURL, apiKey string, client *http.Client) ports.ExternalPriceProvider {
+	if baseURL == "" {
+		baseURL = coinCapBaseURL
+	}
+
+	return &coinCapProvider{
+		client:  client,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+	}
+}
+
+type coinCapAssetResponse struct {
+	Data struct {
+		ID       string      `json:"id"`
+		PriceUSD json.Number `json:"priceUsd"`
+	} `json:"data"`
+	Timestamp int64 `json:"timestamp"`
+}
+
+type coinCapHistoryResponse struct {
+	Data []struct {
+		PriceUSD json.Number `json:"priceUsd"`
+		Time     int64       `json:"time"`
+	} `json:"data"`
+}
+
+func (p *coinCapProvider) assetID(symbol string) (string, error) {
+	symbol = strings.ToUpper(symbol)
+	if id, ok := coinCapAssetIDs[symbol]; ok {
+		return id, nil
+	}
+	if id, ok := DefaultCoinIDs[symbol]; ok {
+		return id, nil
+	}
+	return "", domain.ErrCurrencyNotFound
+}
+
+func (p *coinCapProvider) headers() map[string]string {
+	if p.apiKey == "" {
+		return nil
+	}
+	return map[string]string{"Authorization": "Bearer " + p.apiKey}
+}
+
+// CoinCap отдаёт цены только в долларах
+func (p *coinCapProvider) checkQuote(quote string) error {
+	if quote != "USD" {
+		return domain.ErrQuoteNotTracked
+	}
+	return nil
+}
+
+func (p *coinCapProvider) GetCurrentPrice(ctx context.Context, symbol, quote string) (*domain.CurrencyPrice, error) {
+	quote = domain.NormalizeQuote(quote)
+	if err := p.checkQuote(quote); err != nil {
+		return nil, err
+	}
+
+	id, err := p.assetID(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var data coinCapAssetResponse
+	if err := getJSON(ctx, p.client, fmt.Sprintf("%s/assets/%s", p.baseURL, id), p.headers(), &data); err != nil {
+		return nil, err
+	}
+
+	price, err := domain.ParseDecimal(data.Data.PriceUSD.String())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrExternalAPIUnavailable, err)
+	}
+
+	timestamp := time.Now()
+	if data.Timestamp > 0 {
+		timestamp = time.UnixMilli(data.Timestamp)
+	}
+
+	return &domain.CurrencyPrice{
+		Symbol:    strings.ToUpper(symbol),
+		Quote:     quote,
+		Price:     price,
+		Timestamp: timestamp,
+	}, nil
+}
+
+func (p *coinCapProvider) GetHistoricalPrices(ctx context.Context, symbol, quote string, startTime, endTime time.Time) ([]*domain.CurrencyPrice, error) {
+	quote = domain.NormalizeQuote(quote)
+	if err := p.checkQuote(quote); err != nil {
+		return nil, err
+	}
+
+	id, err := p.assetID(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime, endTime = defaultHistoryRange(startTime, endTime)
+
+	interval := "d1"
+	switch historyStep(startTime, endTime) {
+	case 5 * time.Minute:
+		interval = "m5"
+	case time.Hour:
+		interval = "h1"
+	}
+
+	url := fmt.Sprintf("%s/assets/%s/history?interval=%s&start=%d&end=%d",
+		p.baseURL, id, interval, startTime.UnixMilli(), endTime.UnixMilli())
+
+	var data coinCapHistoryResponse
+	if err := getJSON(ctx, p.client, url, p.headers(), &data); err != nil {
+		return nil, err
+	}
+
+	prices := make([]*domain.CurrencyPrice, 0, len(data.Data))
+	for _, point := range data.Data {
+		price, err := domain.ParseDecimal(point.PriceUSD.String())
+		if err != nil {
+			continue
+		}
+
+		prices = append(prices, &domain.CurrencyPrice{
+			Symbol:    strings.ToUpper(symbol),
+			Quote:     quote,
+			Price:     price,
+			Timestamp: time.UnixMilli(point.Time),
+		})
+	}
+
+	return prices, nil
+}
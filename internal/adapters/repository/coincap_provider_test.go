@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+func TestCoinCapCurrentPrice(t *testing.T) {
+	body := `{"data":{"id":"bitcoin","symbol":"BTC","priceUsd":"50000.5123456789"},"timestamp":1709337600000}`
+	server := jsonServer(t, "/assets/bitcoin", body, func(r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q, want Bearer secret", got)
+		}
+	})
+
+	price, err := NewCoinCapProvider(server.URL, "secret", server.Client()).GetCurrentPrice(context.Background(), "BTC", "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPrice(t, price, "50000.5123456789", time.UnixMilli(1709337600000))
+}
+
+func TestCoinCapRejectsWithoutRequest(t *testing.T) {
+	// Запрос не должен уйти: адрес недоступен
+	provider := NewCoinCapProvider("http://127.0.0.1:0", "", http.DefaultClient)
+
+	if _, err := provider.GetCurrentPrice(context.Background(), "BTC", "EUR"); !errors.Is(err, domain.ErrQuoteNotTracked) {
+		t.Errorf("EUR quote: err = %v, want %v", err, domain.ErrQuoteNotTracked)
+	}
+	if _, err := provider.GetCurrentPrice(context.Background(), "UNKNOWNCOIN", "USD"); !errors.Is(err, domain.ErrCurrencyNotFound) {
+		t.Errorf("unknown symbol: err = %v, want %v", err, domain.ErrCurrencyNotFound)
+	}
+}
+
+func TestCoinCapHistoricalPrices(t *testing.T) {
+	end := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, 0, -7)
+	body := `{"data":[
+		{"priceUsd":"3400.25","time":1709251200000},
+		{"priceUsd":null,"time":1709254800000},
+		{"priceUsd":"3410.5","time":1709258400000}
+	]}`
+	server := jsonServer(t, "/assets/ethereum/history", body, func(r *http.Request) {
+		if got := r.URL.Query().Get("interval"); got != "h1" {
+			t.Errorf("interval = %q, want h1", got)
+		}
+	})
+
+	prices, err := NewCoinCapProvider(server.URL, "", server.Client()).GetHistoricalPrices(context.Background(), "ETH", "USD", start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 2 {
+		t.Fatalf("got %d prices, want 2", len(prices))
+	}
+	checkPrice(t, prices[0], "3400.25", time.UnixMilli(1709251200000))
+	checkPrice(t, prices[1], "3410.5", time.UnixMilli(1709258400000))
+}
+
+func TestCoinCapStatusMapping(t *testing.T) {
+	checkStatusMapping(t, func(baseURL string) ports.ExternalPriceProvider {
+		return NewCoinCapProvider(baseURL, "", http.DefaultClient)
+	})
+}
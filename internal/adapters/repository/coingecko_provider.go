@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+const coinGeckoBaseURL = "https://api.coingecko.com/api/v3"
+
+// DefaultCoinIDs - идентификаторы монет CoinGecko для популярных тикеров.
+// Используются как начальные привязки для неоднозначных тикеров каталога.
+var DefaultCoinIDs = map[string]string{
+	"BTC":   "bitcoin",
+	"ETH":   "ethereum",
+	"ADA":   "cardano",
+	"SOL":   "solana",
+	"DOT":   "polkadot",
+	"LINK":  "chainlink",
+	"MATIC": "matic-network",
+	"AVAX":  "avalanche-2",
+	"UNI":   "uniswap",
+	"LTC":   "litecoin",
+	"XRP":   "ripple",
+	"DOGE":  "dogecoin",
+}
+
+type coinGeckoProvider struct {
+	client   *http.Client
+	baseURL  string
+	resolver ports.CoinResolver
+	apiKey   string
+}
+
+func NewCoinGeckoProvider(baseURL, apiKey string, client *http.Client, resolver ports.CoinResolver) ports.ExternalPriceProvider {
+	return newCoinGeckoProvider(baseURL, apiKey, client, resolver)
+}
+
+// NewCoinGeckoCatalog возвращает загрузчик каталога монет CoinGecko (/coins/list)
+func NewCoinGeckoCatalog(baseURL, apiKey string, client *http.Client) ports.CoinListProvider {
+	return newCoinGeckoProvider(baseURL, apiKey, client, nil)
+}
+
+func newCoinGeckoProvider(baseURL, apiKey string, client *http.Client, resolver ports.CoinResolver) *coinGeckoProvider {
+	if baseURL == "" {
+		baseURL = coinGeckoBaseURL
+	}
+
+	return &coinGeckoProvider{
+		client:   client,
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		resolver: resolver,
+		apiKey:   apiKey,
+	}
+}
+
+// coinGeckoPriceResponse - цены монеты по котировкам (usd, eur, ...) и last_updated_at.
+// json.Number сохраняет десятичное представление цены без округления до float64.
+type coinGeckoPriceResponse map[string]json.Number
+
+type coinGeckoHistoryResponse struct {
+	Prices [][]json.Number `json:"prices"`
+}
+
+type coinGeckoListItem struct {
+	ID     string `json:"id"`
+	Symbol string `json:"symbol"`
+	Name   string `json:"name"`
+}
+
+func (p *coinGeckoProvider) headers() map[string]string {
+	return map[string]string{"Authorization": "Apikey " + p.apiKey}
+}
+
+func (p *coinGeckoProvider) ListCoins(ctx context.Context) ([]domain.Coin, error) {
+	url := fmt.Sprintf("%s/coins/list", p.baseURL)
+
+	log.Printf("🌐 Запрос каталога монет: %s", url)
+
+	var items []coinGeckoListItem
+	if err := getJSON(ctx, p.client, url, p.headers(), &items); err != nil {
+		return nil, err
+	}
+
+	coins := make([]domain.Coin, len(items))
+	for i, item := range items {
+		coins[i] = domain.Coin{
+			ID:     item.ID,
+			Symbol: strings.ToUpper(item.Symbol),
+			Name:   item.Name,
+		}
+	}
+
+	return coins, nil
+}
+
+func (p *coinGeckoProvider) GetCurrentPrice(ctx context.Context, symbol, quote string) (*domain.CurrencyPrice, error) {
+	quote = domain.NormalizeQuote(quote)
+	coinID, err := p.resolver.ResolveCoinID(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/simple/price?ids=%s&vs_currencies=%s&include_last_updated_at=true&include_market_cap=true",
+		p.baseURL, coinID, strings.ToLower(quote))
+
+	log.Printf("🌐 Запрос текущей цены %s: %s", symbol, url)
+
+	var data map[string]coinGeckoPriceResponse
+	if err := getJSON(ctx, p.client, url, p.headers(), &data); err != nil {
+		return nil, err
+	}
+
+	priceData, exists := data[coinID]
+	if !exists {
+		return nil, domain.ErrCurrencyNotFound
+	}
+
+	rawPrice, exists := priceData[strings.ToLower(quote)]
+	if !exists {
+		return nil, domain.ErrQuoteNotTracked
+	}
+
+	price, err := domain.ParseDecimal(rawPrice.String())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrExternalAPIUnavailable, err)
+	}
+
+	timestamp := time.Now()
+	if lastUpdatedAt, err := priceData["last_updated_at"].Int64(); err == nil && lastUpdatedAt > 0 {
+		timestamp = time.Unix(lastUpdatedAt, 0)
+	}
+
+	var marketCap domain.Decimal
+	if rawMarketCap, ok := priceData[strings.ToLower(quote)+"_market_cap"]; ok {
+		marketCap, _ = domain.ParseDecimal(rawMarketCap.String())
+	}
+
+	return &domain.CurrencyPrice{
+		Symbol:    strings.ToUpper(symbol),
+		Quote:     quote,
+		Price:     price,
+		Timestamp: timestamp,
+		MarketCap: marketCap,
+	}, nil
+}
+
+func (p *coinGeckoProvider) GetHistoricalPrices(ctx context.Context, symbol, quote string, startTime, endTime time.Time) ([]*domain.CurrencyPrice, error) {
+	quote = domain.NormalizeQuote(quote)
+	coinID, err := p.resolver.ResolveCoinID(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime, endTime = defaultHistoryRange(startTime, endTime)
+
+	url := fmt.Sprintf("%s/coins/%s/market_chart/range?vs_currency=%s&from=%d&to=%d",
+		p.baseURL, coinID, strings.ToLower(quote), startTime.Unix(), endTime.Unix())
+
+	log.Printf("🌐 Запрос истории %s: %s", symbol, url)
+
+	var data coinGeckoHistoryResponse
+	if err := getJSON(ctx, p.client, url, p.headers(), &data); err != nil {
+		return nil, err
+	}
+
+	var prices []*domain.CurrencyPrice
+	for _, pricePoint := range data.Prices {
+		if len(pricePoint) >= 2 {
+			millis, err := pricePoint[0].Float64()
+			if err != nil {
+				continue
+			}
+			price, err := domain.ParseDecimal(pricePoint[1].String())
+			if err != nil {
+				continue
+			}
+
+			prices = append(prices, &domain.CurrencyPrice{
+				Symbol:    strings.ToUpper(symbol),
+				Quote:     quote,
+				Price:     price,
+				Timestamp: time.Unix(int64(millis/1000), 0),
+			})
+		}
+	}
+
+	return prices, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// staticResolver - CoinResolver по фиксированной таблице
+type staticResolver map[string]string
+
+func (r staticResolver) ResolveCoinID(ctx context.Context, symbol string) (string, error) {
+	if id, ok := r[strings.ToUpper(symbol)]; ok {
+		return id, nil
+	}
+	return "", domain.ErrCoinNotInCatalog
+}
+
+var testResolver = staticResolver{"BTC": "bitcoin", "ETH": "ethereum"}
+
+func TestCoinGeckoCurrentPrice(t *testing.T) {
+	body := `{"bitcoin":{"eur":45000.123456789012,"eur_market_cap":880000000000.5,"last_updated_at":1709337600}}`
+	server := jsonServer(t, "/simple/price", body, func(r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("ids") != "bitcoin" || query.Get("vs_currencies") != "eur" {
+			t.Errorf("query = %v, want bitcoin in eur", query)
+		}
+		if got := r.Header.Get("Authorization"); got != "Apikey key" {
+			t.Errorf("Authorization = %q, want Apikey key", got)
+		}
+	})
+
+	price, err := NewCoinGeckoProvider(server.URL, "key", server.Client(), testResolver).GetCurrentPrice(context.Background(), "BTC", "EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// json.Number сохраняет все знаки цены
+	checkPrice(t, price, "45000.123456789012", time.Unix(1709337600, 0))
+	if price.MarketCap.String() != "880000000000.5" {
+		t.Errorf("market cap = %s, want 880000000000.5", price.MarketCap)
+	}
+}
+
+func TestCoinGeckoMissingData(t *testing.T) {
+	tests := []struct {
+		body string
+		want error
+	}{
+		{`{}`, domain.ErrCurrencyNotFound},
+		{`{"bitcoin":{"usd":50000}}`, domain.ErrQuoteNotTracked},
+	}
+
+	for _, tt := range tests {
+		server := jsonServer(t, "/simple/price", tt.body, nil)
+		_, err := NewCoinGeckoProvider(server.URL, "", server.Client(), testResolver).GetCurrentPrice(context.Background(), "BTC", "EUR")
+		if !errors.Is(err, tt.want) {
+			t.Errorf("body %s: err = %v, want %v", tt.body, err, tt.want)
+		}
+	}
+
+	provider := NewCoinGeckoProvider("http://127.0.0.1:0", "", http.DefaultClient, testResolver)
+	if _, err := provider.GetCurrentPrice(context.Background(), "NOPE", "USD"); !errors.Is(err, domain.ErrCoinNotInCatalog) {
+		t.Errorf("unresolved symbol: err = %v, want %v", err, domain.ErrCoinNotInCatalog)
+	}
+}
+
+func TestCoinGeckoHistoricalPrices(t *testing.T) {
+	end := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, 0, -1)
+	body := `{"prices":[[1709251200000,3400.25],[1709254800000],[1709258400000,3410.5]]}`
+	server := jsonServer(t, "/coins/ethereum/market_chart/range", body, func(r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("from") != "1709251200" || query.Get("to") != "1709337600" {
+			t.Errorf("query = %v, want unix seconds range", query)
+		}
+	})
+
+	prices, err := NewCoinGeckoProvider(server.URL, "", server.Client(), testResolver).GetHistoricalPrices(context.Background(), "ETH", "USD", start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 2 {
+		t.Fatalf("got %d prices, want 2", len(prices))
+	}
+	checkPrice(t, prices[0], "3400.25", time.Unix(1709251200, 0))
+	checkPrice(t, prices[1], "3410.5", time.Unix(1709258400, 0))
+}
+
+func TestCoinGeckoStatusMapping(t *testing.T) {
+	checkStatusMapping(t, func(baseURL string) ports.ExternalPriceProvider {
+		return NewCoinGeckoProvider(baseURL, "", http.DefaultClient, testResolver)
+	})
+}
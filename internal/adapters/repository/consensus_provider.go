@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+const consensusSource = "consensus"
+
+// medianPrecision - знаков после запятой при усреднении двух средних значений
+const medianPrecision = 18
+
+// consensusPriceProvider опрашивает всех провайдеров параллельно, отбрасывает выбросы
+// относительно медианы и возвращает медиану оставшихся цен
+type consensusPriceProvider struct {
+	providers    []RegisteredProvider
+	maxDeviation domain.Decimal
+	minSources   int
+}
+
+// NewConsensusPriceProvider создаёт провайдер консенсусной цены. maxDeviation - допустимое
+// относительное отклонение от медианы (0.02 = 2%), minSources - минимум согласных источников.
+func NewConsensusPriceProvider(maxDeviation domain.Decimal, minSources int, providers ...RegisteredProvider) ports.ExternalPriceProvider {
+	sorted := append([]RegisteredProvider(nil), providers...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority < sorted[j].Priority
+	})
+
+	return &consensusPriceProvider{
+		providers:    sorted,
+		maxDeviation: maxDeviation,
+		minSources:   max(minSources, 1),
+	}
+}
+
+func (p *consensusPriceProvider) GetCurrentPrice(ctx context.Context, symbol, quote string) (*domain.CurrencyPrice, error) {
+	quotes, unknown := p.collect(ctx, symbol, quote)
+	if unknown {
+		return nil, domain.ErrCurrencyNotFound
+	}
+	if len(quotes) < p.minSources {
+		log.Printf("⚠️  Консенсус %s/%s: ответили %d источников из %d, нужно %d",
+			symbol, quote, len(quotes), len(p.providers), p.minSources)
+		return nil, domain.ErrInsufficientSources
+	}
+
+	median := medianPrice(quotes)
+	accepted := make([]*domain.CurrencyPrice, 0, len(quotes))
+	for _, q := range quotes {
+		if withinDeviation(q.Price, median, p.maxDeviation) {
+			accepted = append(accepted, q)
+			continue
+		}
+		log.Printf("⚠️  Консенсус %s/%s: отброшена цена %s от %s (медиана %s)",
+			symbol, quote, q.Price, q.Source, median)
+	}
+
+	if len(accepted) < p.minSources {
+		return nil, domain.ErrInsufficientSources
+	}
+
+	result := &domain.CurrencyPrice{
+		Symbol: accepted[0].Symbol,
+		Quote:  accepted[0].Quote,
+		Price:  medianPrice(accepted),
+		Source: consensusSource,
+	}
+	for _, q := range accepted {
+		result.Sources = append(result.Sources, q.Source)
+		if q.Timestamp.After(result.Timestamp) {
+			result.Timestamp = q.Timestamp
+		}
+		if result.MarketCap.IsZero() {
+			result.MarketCap = q.MarketCap
+		}
+	}
+
+	return result, nil
+}
+
+// GetHistoricalPrices не усредняет историю, а берёт её у первого ответившего провайдера.
+// Rate limit отдельных провайдеров наружу не передаётся: иначе реестр поставил бы на паузу весь консенсус.
+func (p *consensusPriceProvider) GetHistoricalPrices(ctx context.Context, symbol, quote string, startTime, endTime time.Time) ([]*domain.CurrencyPrice, error) {
+	unknown, empty := len(p.providers) > 0, false
+	for _, provider := range p.providers {
+		prices, err := provider.Provider.GetHistoricalPrices(ctx, symbol, quote, startTime, endTime)
+		if err != nil {
+			unknown = unknown && isUnknownSymbol(err)
+			log.Printf("⚠️  Консенсус: провайдер %s не вернул историю %s: %v", provider.Name, symbol, err)
+			continue
+		}
+		if len(prices) == 0 {
+			unknown, empty = false, true
+			continue
+		}
+
+		for _, price := range prices {
+			if price.Source == "" {
+				price.Source = provider.Name
+			}
+		}
+		return prices, nil
+	}
+
+	switch {
+	case unknown:
+		return nil, domain.ErrCurrencyNotFound
+	case empty:
+		return nil, domain.ErrNoHistoricalPrices
+	default:
+		return nil, domain.ErrExternalAPIUnavailable
+	}
+}
+
+// collect опрашивает провайдеров параллельно. unknown - ни один провайдер не знает тикер.
+func (p *consensusPriceProvider) collect(ctx context.Context, symbol, quote string) (quotes []*domain.CurrencyPrice, unknown bool) {
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	unknown = len(p.providers) > 0
+
+	for _, provider := range p.providers {
+		wg.Add(1)
+		go func(provider RegisteredProvider) {
+			defer wg.Done()
+
+			price, err := provider.Provider.GetCurrentPrice(ctx, symbol, quote)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				unknown = unknown && isUnknownSymbol(err)
+				log.Printf("⚠️  Консенсус: провайдер %s не вернул цену %s: %v", provider.Name, symbol, err)
+				return
+			}
+			unknown = false
+			if !price.IsValidPrice() {
+				return
+			}
+
+			price.Source = provider.Name
+			quotes = append(quotes, price)
+		}(provider)
+	}
+	wg.Wait()
+
+	return quotes, unknown
+}
+
+func medianPrice(prices []*domain.CurrencyPrice) domain.Decimal {
+	values := make([]domain.Decimal, len(prices))
+	for i, price := range prices {
+		values[i] = price.Price
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Cmp(values[j]) < 0
+	})
+
+	middle := len(values) / 2
+	if len(values)%2 == 1 {
+		return values[middle]
+	}
+
+	median, _ := values[middle-1].Add(values[middle]).Div(domain.NewDecimal(2, 0), medianPrecision)
+	return median
+}
+
+// withinDeviation проверяет |price - median| <= median * maxDeviation
+func withinDeviation(price, median, maxDeviation domain.Decimal) bool {
+	return price.Sub(median).Abs().Cmp(median.Mul(maxDeviation)) <= 0
+}
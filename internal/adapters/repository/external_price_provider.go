@@ -1,236 +1,116 @@
 package repository
 
 import (
-	"context"
-	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
-	"strings"
-	"time"
 
 	"github.com/kk7453603/RybakovTestGo/internal/config"
 	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
 	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
 )
 
-type externalPriceProvider struct {
-	client     *http.Client
-	baseURL    string
-	symbolToID map[string]string
-	ApiKey     string
-}
-
-func NewExternalPriceProvider(cfg config.Config) ports.ExternalPriceProvider {
-	symbolToID := map[string]string{
-		"BTC":   "bitcoin",
-		"ETH":   "ethereum",
-		"ADA":   "cardano",
-		"SOL":   "solana",
-		"DOT":   "polkadot",
-		"LINK":  "chainlink",
-		"MATIC": "matic-network",
-		"AVAX":  "avalanche-2",
-		"UNI":   "uniswap",
-		"LTC":   "litecoin",
-		"XRP":   "ripple",
-		"DOGE":  "dogecoin",
-	}
+const consensusMode = "consensus"
 
-	return &externalPriceProvider{
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		baseURL:    "https://api.coingecko.com/api/v3",
-		symbolToID: symbolToID,
-		ApiKey:     cfg.APIToken,
+// NewExternalPriceProvider собирает реестр провайдеров цен из конфигурации. Второй реестр - те же
+// провайдеры без резервного для загрузки истории: синтетические цены ей не нужны, и при недоступности
+// провайдеров она должна повторить запрос позже. Паузы после rate limit у реестров общие.
+func NewExternalPriceProvider(cfg config.Config, resolver ports.CoinResolver) (ports.ExternalPriceProvider, ports.ExternalPriceProvider) {
+	client := &http.Client{
+		Timeout: cfg.Providers.Timeout,
 	}
-}
 
-type coinGeckoPriceResponse struct {
-	USD           float64 `json:"usd"`
-	LastUpdatedAt int64   `json:"last_updated_at"`
-}
-
-type coinGeckoHistoryResponse struct {
-	Prices [][]float64 `json:"prices"`
-}
-
-func (p *externalPriceProvider) GetCurrentPrice(ctx context.Context, symbol string) (*domain.CurrencyPrice, error) {
-	coinID, exists := p.symbolToID[strings.ToUpper(symbol)]
-	if !exists {
-		log.Printf("⚠️  Неподдерживаемая валюта: %s, используем fallback", symbol)
-		return p.getFallbackPrice(symbol), nil
-	}
-
-	url := fmt.Sprintf("%s/simple/price?ids=%s&vs_currencies=usd&include_last_updated_at=true",
-		p.baseURL, coinID)
-
-	log.Printf("🌐 Запрос текущей цены %s: %s", symbol, url)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		log.Printf("❌ Ошибка создания запроса: %v", err)
-		return p.getFallbackPrice(symbol), nil
-	}
-
-	req.Header.Set("User-Agent", "CryptoService/1.0")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "Apikey "+p.ApiKey)
-	resp, err := p.client.Do(req)
-	if err != nil {
-		log.Printf("❌ HTTP ошибка: %v", err)
-		return p.getFallbackPrice(symbol), nil
-	}
-	defer resp.Body.Close()
-
-	log.Printf("📊 Статус ответа: %d", resp.StatusCode)
-
-	if resp.StatusCode == 429 {
-		log.Printf("⚠️  Rate limit, используем fallback")
-		return p.getFallbackPrice(symbol), nil
-	}
+	var providers []RegisteredProvider
+	for _, providerCfg := range cfg.Providers.Providers {
+		provider := newProvider(providerCfg, cfg, client, resolver)
+		if provider == nil {
+			log.Printf("⚠️  Неизвестный провайдер цен: %s, пропускаем", providerCfg.Name)
+			continue
+		}
 
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("❌ Неожиданный статус: %d", resp.StatusCode)
-		return p.getFallbackPrice(symbol), nil
+		providers = append(providers, RegisteredProvider{
+			Name:     providerCfg.Name,
+			Priority: providerCfg.Priority,
+			Provider: provider,
+		})
+		log.Printf("🔌 Провайдер цен %s (приоритет %d)", providerCfg.Name, providerCfg.Priority)
 	}
 
-	var data map[string]coinGeckoPriceResponse
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		log.Printf("❌ Ошибка JSON: %v", err)
-		return p.getFallbackPrice(symbol), nil
+	cooldowns := newProviderCooldowns(cfg.Providers.RateLimitCooldown)
+	if cfg.Providers.Mode == consensusMode {
+		providers = []RegisteredProvider{{
+			Name:     consensusSource,
+			Priority: 0,
+			Provider: newConsensusProvider(cfg.Providers.Consensus, providers, cooldowns),
+		}}
 	}
 
-	priceData, exists := data[coinID]
-	if !exists {
-		log.Printf("❌ Нет данных для %s", symbol)
-		return nil, domain.ErrCurrencyNotFound
-	}
+	history := newProviderRegistry(cooldowns, providers...)
 
-	timestamp := time.Now()
-	if priceData.LastUpdatedAt > 0 {
-		timestamp = time.Unix(priceData.LastUpdatedAt, 0)
+	if cfg.Providers.FallbackEnabled {
+		providers = append(providers, RegisteredProvider{
+			Name:     domain.SourceFallback,
+			Priority: int(^uint(0) >> 1),
+			Provider: NewFallbackPriceProvider(),
+		})
 	}
 
-	log.Printf("✅ Цена %s: $%.2f", symbol, priceData.USD)
-
-	return &domain.CurrencyPrice{
-		Symbol:    strings.ToUpper(symbol),
-		Price:     priceData.USD,
-		Timestamp: timestamp,
-	}, nil
+	return newProviderRegistry(cooldowns, providers...), history
 }
 
-func (p *externalPriceProvider) GetHistoricalPrices(ctx context.Context, symbol string, startTime, endTime time.Time) ([]*domain.CurrencyPrice, error) {
-	coinID, exists := p.symbolToID[strings.ToUpper(symbol)]
-	if !exists {
-		log.Printf("⚠️  Неподдерживаемая валюта для истории: %s", symbol)
-		return p.getFallbackHistoricalPrices(symbol), nil
-	}
-
-	// Если время не указано, берем последние 30 дней
-	if startTime.IsZero() {
-		startTime = time.Now().AddDate(0, 0, -30)
-	}
-	if endTime.IsZero() {
-		endTime = time.Now()
-	}
-
-	from := startTime.Unix()
-	to := endTime.Unix()
-
-	url := fmt.Sprintf("%s/coins/%s/market_chart/range?vs_currency=usd&from=%d&to=%d",
-		p.baseURL, coinID, from, to)
-
-	log.Printf("🌐 Запрос истории %s: %s", symbol, url)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		log.Printf("❌ Ошибка создания запроса истории: %v", err)
-		return p.getFallbackHistoricalPrices(symbol), nil
-	}
-
-	req.Header.Set("User-Agent", "CryptoService/1.0")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "Apikey "+p.ApiKey)
-
-	resp, err := p.client.Do(req)
-	if err != nil {
-		log.Printf("❌ HTTP ошибка истории: %v", err)
-		return p.getFallbackHistoricalPrices(symbol), nil
-	}
-	defer resp.Body.Close()
-
-	log.Printf("📊 Статус ответа истории: %d", resp.StatusCode)
-
-	if resp.StatusCode == 429 {
-		log.Printf("⚠️  Rate limit для истории, используем fallback")
-		return p.getFallbackHistoricalPrices(symbol), nil
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("❌ Неожиданный статус истории: %d", resp.StatusCode)
-		return p.getFallbackHistoricalPrices(symbol), nil
-	}
-
-	var data coinGeckoHistoryResponse
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		log.Printf("❌ Ошибка JSON истории: %v", err)
-		return p.getFallbackHistoricalPrices(symbol), nil
-	}
-
-	var prices []*domain.CurrencyPrice
-	for i, pricePoint := range data.Prices {
-		if len(pricePoint) >= 2 && i < 50 { // Ограничиваем количество точек
-			timestamp := time.Unix(int64(pricePoint[0]/1000), 0)
-			price := pricePoint[1]
-
-			prices = append(prices, &domain.CurrencyPrice{
-				Symbol:    strings.ToUpper(symbol),
-				Price:     price,
-				Timestamp: timestamp,
-			})
+func newProvider(providerCfg config.ProviderConfig, cfg config.Config, client *http.Client, resolver ports.CoinResolver) ports.ExternalPriceProvider {
+	switch providerCfg.Name {
+	case "coingecko":
+		apiKey := providerCfg.APIKey
+		if apiKey == "" {
+			apiKey = cfg.APIToken
 		}
+		return NewCoinGeckoProvider(providerCfg.BaseURL, apiKey, client, resolver)
+	case "binance":
+		return NewBinanceProvider(providerCfg.BaseURL, client)
+	case "kraken":
+		return NewKrakenProvider(providerCfg.BaseURL, client)
+	case "coincap":
+		return NewCoinCapProvider(providerCfg.BaseURL, providerCfg.APIKey, client)
+	default:
+		return nil
 	}
-
-	log.Printf("✅ Получено %d исторических точек для %s", len(prices), symbol)
-	return prices, nil
 }
 
-// Fallback методы для случая недоступности API
-func (p *externalPriceProvider) getFallbackPrice(symbol string) *domain.CurrencyPrice {
-	prices := map[string]float64{
-		"BTC": 117416.0,
-		"ETH": 3200.0,
-		"ADA": 0.45,
-		"SOL": 140.0,
+// newConsensusProvider оборачивает провайдеров в паузы после rate limit: консенсус опрашивает их
+// в обход реестра, и без этого провайдер, ответивший 429, опрашивался бы при каждом запросе
+func newConsensusProvider(cfg config.ConsensusConfig, providers []RegisteredProvider, cooldowns *providerCooldowns) ports.ExternalPriceProvider {
+	maxDeviation, err := domain.ParseDecimal(cfg.MaxDeviation)
+	if err != nil || maxDeviation.Sign() < 0 {
+		log.Printf("⚠️  Некорректный CONSENSUS_MAX_DEVIATION=%q, используем 0.02", cfg.MaxDeviation)
+		maxDeviation = domain.NewDecimal(2, 2)
 	}
 
-	price, exists := prices[strings.ToUpper(symbol)]
-	if !exists {
-		price = 100.0
-	}
+	log.Printf("🤝 Режим консенсуса: %d провайдеров, отклонение %s, минимум %d источников",
+		len(providers), maxDeviation, cfg.MinSources)
 
-	return &domain.CurrencyPrice{
-		Symbol:    strings.ToUpper(symbol),
-		Price:     price,
-		Timestamp: time.Now(),
+	limited := make([]RegisteredProvider, len(providers))
+	for i, provider := range providers {
+		limited[i] = provider
+		limited[i].Provider = &rateLimitedProvider{name: provider.Name, provider: provider.Provider, cooldowns: cooldowns}
 	}
-}
 
-func (p *externalPriceProvider) getFallbackHistoricalPrices(symbol string) []*domain.CurrencyPrice {
-	basePrice := p.getFallbackPrice(symbol).Price
-	var prices []*domain.CurrencyPrice
+	return NewConsensusPriceProvider(maxDeviation, cfg.MinSources, limited...)
+}
 
-	for i := 0; i < 10; i++ {
-		variation := 1.0 + (float64(i%5)-2)*0.01 // Небольшие вариации ±2%
-		prices = append(prices, &domain.CurrencyPrice{
-			Symbol:    strings.ToUpper(symbol),
-			Price:     basePrice * variation,
-			Timestamp: time.Now().AddDate(0, 0, -i),
-		})
+// NewCoinListProvider возвращает загрузчик каталога монет CoinGecko с настройками из конфигурации
+func NewCoinListProvider(cfg config.Config) ports.CoinListProvider {
+	client := &http.Client{
+		Timeout: cfg.Providers.Timeout,
 	}
 
-	return prices
+	baseURL, apiKey := "", cfg.APIToken
+	for _, providerCfg := range cfg.Providers.Providers {
+		if providerCfg.Name == "coingecko" {
+			baseURL = providerCfg.BaseURL
+			if providerCfg.APIKey != "" {
+				apiKey = providerCfg.APIKey
+			}
+		}
+	}
+	return NewCoinGeckoCatalog(baseURL, apiKey, client)
 }
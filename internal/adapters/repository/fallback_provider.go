@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// fallbackPriceProvider отдаёт предустановленные цены, когда все внешние API недоступны
+type fallbackPriceProvider struct{}
+
+func NewFallbackPriceProvider() ports.ExternalPriceProvider {
+	return &fallbackPriceProvider{}
+}
+
+func (p *fallbackPriceProvider) GetCurrentPrice(ctx context.Context, symbol, quote string) (*domain.CurrencyPrice, error) {
+	price := p.getFallbackPrice(symbol, domain.NormalizeQuote(quote))
+	if price == nil {
+		return nil, domain.ErrCurrencyNotFound
+	}
+	return price, nil
+}
+
+func (p *fallbackPriceProvider) GetHistoricalPrices(ctx context.Context, symbol, quote string, startTime, endTime time.Time) ([]*domain.CurrencyPrice, error) {
+	base := p.getFallbackPrice(symbol, domain.NormalizeQuote(quote))
+	if base == nil {
+		return nil, domain.ErrCurrencyNotFound
+	}
+	return p.getFallbackHistoricalPrices(base), nil
+}
+
+// getFallbackPrice возвращает nil для неизвестных символов и для котировок кроме domain.DefaultQuote:
+// предустановленные цены заданы только в ней
+func (p *fallbackPriceProvider) getFallbackPrice(symbol, quote string) *domain.CurrencyPrice {
+	if quote != domain.DefaultQuote {
+		return nil
+	}
+
+	prices := map[string]domain.Decimal{
+		"BTC": domain.NewDecimal(117416, 0),
+		"ETH": domain.NewDecimal(3200, 0),
+		"ADA": domain.NewDecimal(45, 2),
+		"SOL": domain.NewDecimal(140, 0),
+	}
+
+	price, exists := prices[strings.ToUpper(symbol)]
+	if !exists {
+		return nil
+	}
+
+	return &domain.CurrencyPrice{
+		Symbol:      strings.ToUpper(symbol),
+		Quote:       quote,
+		Price:       price,
+		Timestamp:   time.Now(),
+		Source:      domain.SourceFallback,
+		IsSynthetic: true,
+	}
+}
+
+func (p *fallbackPriceProvider) getFallbackHistoricalPrices(base *domain.CurrencyPrice) []*domain.CurrencyPrice {
+	var prices []*domain.CurrencyPrice
+
+	for i := 0; i < 10; i++ {
+		variation := domain.NewDecimal(int64(100+(i%5)-2), 2) // Небольшие вариации ±2%
+		prices = append(prices, &domain.CurrencyPrice{
+			Symbol:      base.Symbol,
+			Quote:       base.Quote,
+			Price:       base.Price.Mul(variation),
+			Timestamp:   time.Now().AddDate(0, 0, -i),
+			Source:      domain.SourceFallback,
+			IsSynthetic: true,
+		})
+	}
+
+	return prices
+}
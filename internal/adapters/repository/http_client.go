@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+)
+
+// getJSON выполняет GET-запрос к внешнему API и декодирует JSON-ответ.
+// Числа декодируются как json.Number, чтобы не терять точность цен.
+func getJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrExternalAPIUnavailable, err)
+	}
+
+	req.Header.Set("User-Agent", "CryptoService/1.0")
+	req.Header.Set("Accept", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrExternalAPIUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusTeapot:
+		io.Copy(io.Discard, resp.Body)
+		return domain.ErrRateLimited
+	case resp.StatusCode == http.StatusNotFound:
+		io.Copy(io.Discard, resp.Body)
+		return domain.ErrCurrencyNotFound
+	case resp.StatusCode != http.StatusOK:
+		io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("%w: unexpected status %d", domain.ErrExternalAPIUnavailable, resp.StatusCode)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(out); err != nil {
+		return fmt.Errorf("%w: invalid JSON: %v", domain.ErrExternalAPIUnavailable, err)
+	}
+
+	return nil
+}
+
+// historyStep подбирает шаг исторических данных под длину периода
+func historyStep(startTime, endTime time.Time) time.Duration {
+	switch span := endTime.Sub(startTime); {
+	case span <= 24*time.Hour:
+		return 5 * time.Minute
+	case span <= 30*24*time.Hour:
+		return time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// defaultHistoryRange подставляет последние 30 дней, если границы периода не указаны
+func defaultHistoryRange(startTime, endTime time.Time) (time.Time, time.Time) {
+	if startTime.IsZero() {
+		startTime = time.Now().AddDate(0, 0, -30)
+	}
+	if endTime.IsZero() {
+		endTime = time.Now()
+	}
+	return startTime, endTime
+}
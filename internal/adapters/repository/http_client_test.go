@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// jsonServer отвечает телом body на запросы к path; check проверяет запрос, если задан
+func jsonServer(t *testing.T, path, body string, check func(r *http.Request)) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			t.Errorf("unexpected path %q, want %q", r.URL.Path, path)
+			http.NotFound(w, r)
+			return
+		}
+		if check != nil {
+			check(r)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// checkStatusMapping проверяет, что провайдер переводит коды ответа API в ошибки домена
+func checkStatusMapping(t *testing.T, newProvider func(baseURL string) ports.ExternalPriceProvider) {
+	t.Helper()
+
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusTooManyRequests, domain.ErrRateLimited},
+		{http.StatusTeapot, domain.ErrRateLimited},
+		{http.StatusNotFound, domain.ErrCurrencyNotFound},
+		{http.StatusInternalServerError, domain.ErrExternalAPIUnavailable},
+	}
+
+	end := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+		}))
+		provider := newProvider(server.URL)
+
+		if _, err := provider.GetCurrentPrice(context.Background(), "BTC", "USD"); !errors.Is(err, tt.want) {
+			t.Errorf("GetCurrentPrice with status %d: err = %v, want %v", tt.status, err, tt.want)
+		}
+		if _, err := provider.GetHistoricalPrices(context.Background(), "BTC", "USD", end.AddDate(0, 0, -1), end); !errors.Is(err, tt.want) {
+			t.Errorf("GetHistoricalPrices with status %d: err = %v, want %v", tt.status, err, tt.want)
+		}
+		server.Close()
+	}
+}
+
+func checkPrice(t *testing.T, price *domain.CurrencyPrice, want string, timestamp time.Time) {
+	t.Helper()
+
+	wantPrice, err := domain.ParseDecimal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price.Price.Cmp(wantPrice) != 0 {
+		t.Errorf("price = %s, want %s", price.Price, want)
+	}
+	if !timestamp.IsZero() && !price.Timestamp.Equal(timestamp) {
+		t.Errorf("timestamp = %v, want %v", price.Timestamp, timestamp)
+	}
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+const krakenBaseURL = "https://api.kraken.com"
+
+// krakenAssets - тикеры, под которыми Kraken знает монеты
+var krakenAssets = map[string]string{
+	"BTC":  "XBT",
+	"DOGE": "XDG",
+}
+
+type krakenProvider struct {
+	client  *http.Client
+	baseURL string
+}
+
+func NewKrakenProvider(baseURL string, client *http.Client) ports.ExternalPriceProvider {
+	if baseURL == "" {
+		baseURL = krakenBaseURL
+	}
+
+	return &krakenProvider{
+		client:  client,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// krakenResponse - общий конверт ответов Kraken, result зависит от метода
+type krakenResponse struct {
+	Error  []string                   `json:"error"`
+	Result map[string]json.RawMessage `json:"result"`
+}
+
+type krakenTicker struct {
+	// C - последняя сделка: [цена, объём]
+	C []string `json:"c"`
+}
+
+func (p *krakenProvider) pair(symbol, quote string) string {
+	symbol = strings.ToUpper(symbol)
+	if asset, ok := krakenAssets[symbol]; ok {
+		symbol = asset
+	}
+	return symbol + quote
+}
+
+func (p *krakenProvider) call(ctx context.Context, url string) (map[string]json.RawMessage, error) {
+	var data krakenResponse
+	if err := getJSON(ctx, p.client, url, nil, &data); err != nil {
+		return nil, err
+	}
+
+	if len(data.Error) > 0 {
+		if strings.Contains(strings.Join(data.Error, ";"), "Unknown asset pair") {
+			return nil, domain.ErrCurrencyNotFound
+		}
+		if strings.Contains(strings.Join(data.Error, ";"), "Rate limit") {
+			return nil, domain.ErrRateLimited
+		}
+		return nil, fmt.Errorf("%w: %s", domain.ErrExternalAPIUnavailable, strings.Join(data.Error, "; "))
+	}
+
+	return data.Result, nil
+}
+
+func (p *krakenProvider) GetCurrentPrice(ctx context.Context, symbol, quote string) (*domain.CurrencyPrice, error) {
+	quote = domain.NormalizeQuote(quote)
+	url := fmt.Sprintf("%s/0/public/Ticker?pair=%s", p.baseURL, p.pair(symbol, quote))
+
+	result, err := p.call(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	// Kraken возвращает пару под собственным именем (например XXBTZUSD), запрошена одна пара
+	for _, raw := range result {
+		var ticker krakenTicker
+		if err := json.Unmarshal(raw, &ticker); err != nil || len(ticker.C) == 0 {
+			continue
+		}
+
+		price, err := domain.ParseDecimal(ticker.C[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrExternalAPIUnavailable, err)
+		}
+
+		return &domain.CurrencyPrice{
+			Symbol:    strings.ToUpper(symbol),
+			Quote:     quote,
+			Price:     price,
+			Timestamp: time.Now(),
+		}, nil
+	}
+
+	return nil, domain.ErrCurrencyNotFound
+}
+
+func (p *krakenProvider) GetHistoricalPrices(ctx context.Context, symbol, quote string, startTime, endTime time.Time) ([]*domain.CurrencyPrice, error) {
+	quote = domain.NormalizeQuote(quote)
+	startTime, endTime = defaultHistoryRange(startTime, endTime)
+
+	url := fmt.Sprintf("%s/0/public/OHLC?pair=%s&interval=%d&since=%d",
+		p.baseURL, p.pair(symbol, quote), int(historyStep(startTime, endTime).Minutes()), startTime.Unix())
+
+	result, err := p.call(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var prices []*domain.CurrencyPrice
+	for key, raw := range result {
+		if key == "last" {
+			continue
+		}
+
+		// Свеча: [time, open, high, low, close, vwap, volume, count]
+		var candles [][]json.RawMessage
+		if err := json.Unmarshal(raw, &candles); err != nil {
+			continue
+		}
+
+		for _, candle := range candles {
+			if len(candle) < 5 {
+				continue
+			}
+
+			var openTime int64
+			var closePrice string
+			if json.Unmarshal(candle[0], &openTime) != nil || json.Unmarshal(candle[4], &closePrice) != nil {
+				continue
+			}
+
+			timestamp := time.Unix(openTime, 0)
+			if timestamp.After(endTime) {
+				continue
+			}
+
+			price, err := domain.ParseDecimal(closePrice)
+			if err != nil {
+				continue
+			}
+
+			prices = append(prices, &domain.CurrencyPrice{
+				Symbol:    strings.ToUpper(symbol),
+				Quote:     quote,
+				Price:     price,
+				Timestamp: timestamp,
+			})
+		}
+	}
+
+	return prices, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+func TestKrakenCurrentPrice(t *testing.T) {
+	body := `{"error":[],"result":{"XXBTZUSD":{"a":["50001.0","1","1.000"],"c":["50000.10000","0.01"]}}}`
+	server := jsonServer(t, "/0/public/Ticker", body, func(r *http.Request) {
+		// BTC называется на Kraken XBT
+		if got := r.URL.Query().Get("pair"); got != "XBTUSD" {
+			t.Errorf("pair = %q, want XBTUSD", got)
+		}
+	})
+
+	price, err := NewKrakenProvider(server.URL, server.Client()).GetCurrentPrice(context.Background(), "BTC", "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPrice(t, price, "50000.1", time.Time{})
+}
+
+func TestKrakenErrors(t *testing.T) {
+	tests := []struct {
+		body string
+		want error
+	}{
+		{`{"error":["EQuery:Unknown asset pair"]}`, domain.ErrCurrencyNotFound},
+		{`{"error":["EAPI:Rate limit exceeded"]}`, domain.ErrRateLimited},
+		{`{"error":["EService:Unavailable"]}`, domain.ErrExternalAPIUnavailable},
+		{`{"error":[],"result":{}}`, domain.ErrCurrencyNotFound},
+	}
+
+	for _, tt := range tests {
+		server := jsonServer(t, "/0/public/Ticker", tt.body, nil)
+		_, err := NewKrakenProvider(server.URL, server.Client()).GetCurrentPrice(context.Background(), "BTC", "USD")
+		if !errors.Is(err, tt.want) {
+			t.Errorf("body %s: err = %v, want %v", tt.body, err, tt.want)
+		}
+	}
+}
+
+func TestKrakenHistoricalPrices(t *testing.T) {
+	end := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, 0, -2)
+	body := `{"error":[],"result":{"XETHZUSD":[
+		[1709305200,"3400.0","3410.0","3390.0","3405.5","3401.0","12.5",42],
+		[1709308800,"3405.5","3420.0","3400.0","3418.75","3410.0","8.1",30],
+		[1709341200,"3418.75","3430.0","3410.0","3425.0","3420.0","1.0",3]
+	],"last":1709341200}}`
+	server := jsonServer(t, "/0/public/OHLC", body, func(r *http.Request) {
+		if got := r.URL.Query().Get("interval"); got != "60" {
+			t.Errorf("interval = %q, want 60", got)
+		}
+	})
+
+	prices, err := NewKrakenProvider(server.URL, server.Client()).GetHistoricalPrices(context.Background(), "ETH", "USD", start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Свеча после конца периода отбрасывается
+	if len(prices) != 2 {
+		t.Fatalf("got %d prices, want 2", len(prices))
+	}
+	checkPrice(t, prices[0], "3405.5", time.Unix(1709305200, 0))
+	checkPrice(t, prices[1], "3418.75", time.Unix(1709308800, 0))
+}
+
+func TestKrakenStatusMapping(t *testing.T) {
+	checkStatusMapping(t, func(baseURL string) ports.ExternalPriceProvider {
+		return NewKrakenProvider(baseURL, http.DefaultClient)
+	})
+}
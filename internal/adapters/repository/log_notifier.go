@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// logAlertNotifier пишет сработавшие оповещения в лог сервиса. Используется, когда
+// другой канал доставки не настроен.
+type logAlertNotifier struct{}
+
+func NewLogAlertNotifier() ports.AlertNotifier {
+	return &logAlertNotifier{}
+}
+
+func (n *logAlertNotifier) Notify(ctx context.Context, rule *domain.AlertRule, trigger *domain.AlertTrigger) error {
+	if trigger.Condition == domain.AlertPercentChange {
+		log.Printf("🔔 Оповещение #%d: %s/%s изменилась на %s%% (%s -> %s) за %v",
+			rule.ID, trigger.Symbol, trigger.Quote, trigger.ChangePercent, trigger.ReferencePrice, trigger.Price, rule.Window)
+		return nil
+	}
+
+	log.Printf("🔔 Оповещение #%d: %s/%s = %s (%s %s)",
+		rule.ID, trigger.Symbol, trigger.Quote, trigger.Price, trigger.Condition, trigger.Threshold)
+	return nil
+}
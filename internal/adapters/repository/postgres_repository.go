@@ -2,38 +2,100 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
 	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Модели для GORM
 type CurrencyModel struct {
-	ID        int64     `gorm:"primaryKey;autoIncrement"`
-	Symbol    string    `gorm:"uniqueIndex;not null;size:10"`
-	Name      string    `gorm:"not null;size:100"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	ID             int64          `gorm:"primaryKey;autoIncrement"`
+	Symbol         string         `gorm:"uniqueIndex;not null;size:10"`
+	Name           string         `gorm:"not null;size:100"`
+	Quotes         stringArray    `gorm:"type:text[];not null;default:'{USD}'"`
+	Decimals       int            `gorm:"not null"`
+	Tags           stringArray    `gorm:"type:text[];not null"`
+	ProviderCoinID string         `gorm:"not null;size:100"`
+	Description    string         `gorm:"not null"`
+	Tracking       string         `gorm:"not null;size:10"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+
+	// MarketCap читается из currency_market_caps только в ListPage
+	MarketCap *domain.Decimal `gorm:"->;-:migration"`
 }
 
 func (CurrencyModel) TableName() string {
 	return "currencies"
 }
 
+func (m CurrencyModel) toDomain() *domain.Currency {
+	currency := &domain.Currency{
+		ID:             m.ID,
+		Symbol:         m.Symbol,
+		Name:           m.Name,
+		Quotes:         []string(m.Quotes),
+		Decimals:       m.Decimals,
+		Tags:           []string(m.Tags),
+		ProviderCoinID: m.ProviderCoinID,
+		Description:    m.Description,
+		Tracking:       domain.TrackingState(m.Tracking),
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+	if m.MarketCap != nil {
+		currency.MarketCap = *m.MarketCap
+	}
+	if m.DeletedAt.Valid {
+		deletedAt := m.DeletedAt.Time
+		currency.DeletedAt = &deletedAt
+	}
+	return currency
+}
+
 type CurrencyPriceModel struct {
-	ID        int64     `gorm:"primaryKey;autoIncrement"`
-	Symbol    string    `gorm:"index;not null;size:10"`
-	Price     float64   `gorm:"not null"`
-	Timestamp time.Time `gorm:"index;not null"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
+	ID          int64          `gorm:"primaryKey;autoIncrement"`
+	Symbol      string         `gorm:"index;not null;size:10"`
+	Quote       string         `gorm:"index;not null;size:10;default:USD"`
+	Price       domain.Decimal `gorm:"type:numeric;not null"`
+	Timestamp   time.Time      `gorm:"index;not null"`
+	Source      string         `gorm:"not null;size:50;default:manual"`
+	IsSynthetic bool           `gorm:"not null;default:false"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime"`
 }
 
 func (CurrencyPriceModel) TableName() string {
 	return "currency_prices"
 }
 
+func (m CurrencyPriceModel) toDomain() *domain.CurrencyPrice {
+	return &domain.CurrencyPrice{
+		ID:          m.ID,
+		Symbol:      m.Symbol,
+		Quote:       m.Quote,
+		Price:       m.Price,
+		Timestamp:   m.Timestamp,
+		Source:      m.Source,
+		IsSynthetic: m.IsSynthetic,
+	}
+}
+
+// priceHistoryView - сырые цены вместе с ценами закрытия часовых и дневных агрегатов
+const priceHistoryView = "price_history"
+
+// priceBatchSize - количество строк в одном INSERT при пакетном сохранении
+const priceBatchSize = 1000
+
+// priceConflictColumns - ключ уникальности цены, по которому SavePrice выполняет upsert
+var priceConflictColumns = []clause.Column{{Name: "symbol"}, {Name: "quote"}, {Name: "timestamp"}, {Name: "source"}}
+
 type postgresRepository struct {
 	db *gorm.DB
 }
@@ -41,18 +103,26 @@ type postgresRepository struct {
 func NewPostgresRepository(db *gorm.DB) (ports.CurrencyRepository, ports.PriceRepository) {
 	repo := &postgresRepository{db: db}
 
-	db.AutoMigrate(&CurrencyModel{}, &CurrencyPriceModel{})
-
 	return repo, repo
 }
 
-func (r *postgresRepository) Create(ctx context.Context, currency *domain.Currency) error {
-	model := &CurrencyModel{
-		Symbol:    currency.Symbol,
-		Name:      currency.Name,
-		CreatedAt: currency.CreatedAt,
-		UpdatedAt: currency.UpdatedAt,
+func currencyToModel(currency *domain.Currency) *CurrencyModel {
+	return &CurrencyModel{
+		Symbol:         currency.Symbol,
+		Name:           currency.Name,
+		Quotes:         stringArray(currency.Quotes),
+		Decimals:       currency.Decimals,
+		Tags:           stringArray(currency.Tags),
+		ProviderCoinID: currency.ProviderCoinID,
+		Description:    currency.Description,
+		Tracking:       string(currency.Tracking),
+		CreatedAt:      currency.CreatedAt,
+		UpdatedAt:      currency.UpdatedAt,
 	}
+}
+
+func (r *postgresRepository) Create(ctx context.Context, currency *domain.Currency) error {
+	model := currencyToModel(currency)
 
 	result := r.db.WithContext(ctx).Create(model)
 	if result.Error != nil {
@@ -74,13 +144,23 @@ func (r *postgresRepository) GetBySymbol(ctx context.Context, symbol string) (*d
 		return nil, domain.ErrDatabaseConnection
 	}
 
-	return &domain.Currency{
-		ID:        model.ID,
-		Symbol:    model.Symbol,
-		Name:      model.Name,
-		CreatedAt: model.CreatedAt,
-		UpdatedAt: model.UpdatedAt,
-	}, nil
+	return model.toDomain(), nil
+}
+
+func (r *postgresRepository) GetBySymbols(ctx context.Context, symbols []string) ([]*domain.Currency, error) {
+	var models []CurrencyModel
+	result := r.db.WithContext(ctx).Where("symbol IN ?", symbols).Find(&models)
+
+	if result.Error != nil {
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	currencies := make([]*domain.Currency, len(models))
+	for i, model := range models {
+		currencies[i] = model.toDomain()
+	}
+
+	return currencies, nil
 }
 
 func (r *postgresRepository) List(ctx context.Context) ([]*domain.Currency, error) {
@@ -93,18 +173,127 @@ func (r *postgresRepository) List(ctx context.Context) ([]*domain.Currency, erro
 
 	currencies := make([]*domain.Currency, len(models))
 	for i, model := range models {
-		currencies[i] = &domain.Currency{
-			ID:        model.ID,
-			Symbol:    model.Symbol,
-			Name:      model.Name,
-			CreatedAt: model.CreatedAt,
-			UpdatedAt: model.UpdatedAt,
+		currencies[i] = model.toDomain()
+	}
+
+	return currencies, nil
+}
+
+// currencySortColumns - колонки сортировки списка валют
+var currencySortColumns = map[domain.CurrencySortField]string{
+	domain.SortBySymbol:    "currencies.symbol",
+	domain.SortByCreatedAt: "currencies.created_at",
+	domain.SortByMarketCap: "mc.market_cap",
+}
+
+func (r *postgresRepository) ListPage(ctx context.Context, filter domain.CurrencyFilter, order domain.CurrencyOrder, after *domain.CurrencyPosition, limit int) ([]*domain.Currency, error) {
+	column, ok := currencySortColumns[order.Field]
+	if !ok {
+		return nil, domain.ErrInvalidOrderBy
+	}
+	direction, compare := "ASC", ">"
+	if order.Descending {
+		direction, compare = "DESC", "<"
+	}
+
+	query := r.db.WithContext(ctx).
+		Model(&CurrencyModel{}).
+		Select("currencies.*, mc.market_cap").
+		Joins("LEFT JOIN currency_market_caps mc ON mc.symbol = currencies.symbol")
+
+	if filter.Tag != "" {
+		query = query.Where("? = ANY(currencies.tags)", strings.ToLower(filter.Tag))
+	}
+	if filter.NamePrefix != "" {
+		query = query.Where(`currencies.name ILIKE ? ESCAPE '\'`, escapeLike(filter.NamePrefix)+"%")
+	}
+	if filter.Tracking != "" {
+		query = query.Where("currencies.tracking = ?", string(filter.Tracking))
+	}
+
+	// Продолжение ищется по ключу (значение сортировки, id), а не по смещению, поэтому вставки, удаления
+	// и обновление капитализации между запросами не сдвигают страницы. NULL всегда в конце списка.
+	if after != nil {
+		if after.Value == "" {
+			query = query.Where(column+" IS NULL AND currencies.id "+compare+" ?", after.ID)
+		} else {
+			value, err := currencySortValue(order.Field, after.Value)
+			if err != nil {
+				return nil, err
+			}
+			query = query.Where(
+				fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND currencies.id %[2]s ?) OR %[1]s IS NULL)", column, compare),
+				value, value, after.ID)
 		}
 	}
 
+	// id делает порядок однозначным, чтобы страницы не пересекались
+	var models []CurrencyModel
+	result := query.
+		Order(column + " " + direction + " NULLS LAST").
+		Order("currencies.id " + direction).
+		Limit(limit).
+		Find(&models)
+	if result.Error != nil {
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	currencies := make([]*domain.Currency, len(models))
+	for i, model := range models {
+		currencies[i] = model.toDomain()
+	}
+
 	return currencies, nil
 }
 
+// currencySortValue переводит значение из позиции в тип колонки сортировки
+func currencySortValue(field domain.CurrencySortField, value string) (interface{}, error) {
+	switch field {
+	case domain.SortByCreatedAt:
+		createdAt, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, domain.ErrInvalidPageToken
+		}
+		return createdAt, nil
+	case domain.SortByMarketCap:
+		marketCap, err := domain.ParseDecimal(value)
+		if err != nil {
+			return nil, domain.ErrInvalidPageToken
+		}
+		return marketCap, nil
+	default:
+		return value, nil
+	}
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func (r *postgresRepository) UpdateMarketCaps(ctx context.Context, marketCaps map[string]domain.Decimal) error {
+	if len(marketCaps) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for symbol, marketCap := range marketCaps {
+			err := tx.Exec(`
+				INSERT INTO currency_market_caps (symbol, market_cap, updated_at) VALUES (?, ?, NOW())
+				ON CONFLICT (symbol) DO UPDATE SET market_cap = EXCLUDED.market_cap, updated_at = EXCLUDED.updated_at`,
+				symbol, marketCap).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.ErrDatabaseConnection
+	}
+	return nil
+}
+
 func (r *postgresRepository) Delete(ctx context.Context, symbol string) error {
 	result := r.db.WithContext(ctx).Where("symbol = ?", symbol).Delete(&CurrencyModel{})
 
@@ -119,14 +308,47 @@ func (r *postgresRepository) Delete(ctx context.Context, symbol string) error {
 	return nil
 }
 
-func (r *postgresRepository) Update(ctx context.Context, currency *domain.Currency) error {
-	model := &CurrencyModel{
-		Symbol:    currency.Symbol,
-		Name:      currency.Name,
-		UpdatedAt: time.Now(),
+func (r *postgresRepository) GetRemovedBySymbol(ctx context.Context, symbol string) (*domain.Currency, error) {
+	var model CurrencyModel
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("UPPER(symbol) = UPPER(?) AND deleted_at IS NOT NULL", symbol).
+		First(&model)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, domain.ErrCurrencyNotFound
+		}
+		return nil, domain.ErrDatabaseConnection
 	}
 
-	result := r.db.WithContext(ctx).Where("symbol = ?", currency.Symbol).Updates(model)
+	return model.toDomain(), nil
+}
+
+// ListRemoved возвращает удалённые валюты; ненулевой removedBefore оставляет только удалённые раньше него
+func (r *postgresRepository) ListRemoved(ctx context.Context, removedBefore time.Time) ([]*domain.Currency, error) {
+	query := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL")
+	if !removedBefore.IsZero() {
+		query = query.Where("deleted_at < ?", removedBefore)
+	}
+
+	var models []CurrencyModel
+	if err := query.Order("deleted_at DESC").Find(&models).Error; err != nil {
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	currencies := make([]*domain.Currency, len(models))
+	for i, model := range models {
+		currencies[i] = model.toDomain()
+	}
+
+	return currencies, nil
+}
+
+func (r *postgresRepository) Restore(ctx context.Context, symbol string) error {
+	result := r.db.WithContext(ctx).Unscoped().
+		Model(&CurrencyModel{}).
+		Where("symbol = ? AND deleted_at IS NOT NULL", symbol).
+		Updates(map[string]interface{}{"deleted_at": nil, "updated_at": time.Now()})
 
 	if result.Error != nil {
 		return domain.ErrDatabaseConnection
@@ -139,26 +361,151 @@ func (r *postgresRepository) Update(ctx context.Context, currency *domain.Curren
 	return nil
 }
 
-func (r *postgresRepository) SavePrice(ctx context.Context, price *domain.CurrencyPrice) error {
-	model := &CurrencyPriceModel{
+func (r *postgresRepository) Purge(ctx context.Context, symbol string, withPrices bool) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().
+			Where("symbol = ? AND deleted_at IS NOT NULL", symbol).
+			Delete(&CurrencyModel{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrCurrencyNotFound
+		}
+		if err := tx.Exec("DELETE FROM currency_market_caps WHERE symbol = ?", symbol).Error; err != nil {
+			return err
+		}
+
+		if !withPrices {
+			return nil
+		}
+		for _, table := range priceTables {
+			if err := tx.Exec("DELETE FROM "+table.name+" WHERE symbol = ?", symbol).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err == domain.ErrCurrencyNotFound {
+		return err
+	}
+	if err != nil {
+		return domain.ErrDatabaseConnection
+	}
+	return nil
+}
+
+func (r *postgresRepository) Update(ctx context.Context, currency *domain.Currency) error {
+	model := currencyToModel(currency)
+	model.UpdatedAt = time.Now()
+
+	// Select явно перечисляет колонки, чтобы нулевые значения (decimals 0, пустые теги) тоже сохранялись
+	result := r.db.WithContext(ctx).
+		Model(&CurrencyModel{}).
+		Where("symbol = ?", currency.Symbol).
+		Select("name", "quotes", "decimals", "tags", "provider_coin_id", "description", "tracking", "updated_at").
+		Updates(model)
+
+	if result.Error != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrCurrencyNotFound
+	}
+
+	return nil
+}
+
+func priceToModel(price *domain.CurrencyPrice) *CurrencyPriceModel {
+	source := price.Source
+	if source == "" {
+		source = domain.SourceManual
+	}
+
+	return &CurrencyPriceModel{
 		Symbol:    price.Symbol,
+		Quote:     domain.NormalizeQuote(price.Quote),
 		Price:     price.Price,
 		Timestamp: price.Timestamp,
+		Source:    source,
 	}
+}
 
-	result := r.db.WithContext(ctx).Create(model)
+// upsertPrice - повторная запись той же точки от того же источника обновляет цену, а не создаёт дубликат
+var upsertPrice = clause.OnConflict{
+	Columns:   priceConflictColumns,
+	DoUpdates: clause.AssignmentColumns([]string{"price"}),
+}
+
+func (r *postgresRepository) SavePrice(ctx context.Context, price *domain.CurrencyPrice) error {
+	if price.IsSynthetic {
+		return domain.ErrSyntheticPrice
+	}
+
+	model := priceToModel(price)
+	result := r.db.WithContext(ctx).Clauses(upsertPrice).Create(model)
 	if result.Error != nil {
 		return domain.ErrDatabaseConnection
 	}
 
 	price.ID = model.ID
+	price.Source = model.Source
 	return nil
 }
 
-func (r *postgresRepository) GetLatestPrice(ctx context.Context, symbol string) (*domain.CurrencyPrice, error) {
+// SavePrices сохраняет цены многострочными INSERT в одной транзакции.
+// Повторы одной точки внутри пакета схлопываются: остаётся последняя цена.
+func (r *postgresRepository) SavePrices(ctx context.Context, prices []*domain.CurrencyPrice) error {
+	for _, price := range prices {
+		if price.IsSynthetic {
+			return domain.ErrSyntheticPrice
+		}
+	}
+
+	models := make([]*CurrencyPriceModel, 0, len(prices))
+	owners := make([][]*domain.CurrencyPrice, 0, len(prices))
+	positions := make(map[string]int, len(prices))
+	for _, price := range prices {
+		model := priceToModel(price)
+		key := fmt.Sprintf("%s|%s|%d|%s", model.Symbol, model.Quote, model.Timestamp.UnixMicro(), model.Source)
+
+		// Одна строка не может обновиться дважды в одном INSERT ... ON CONFLICT
+		if i, ok := positions[key]; ok {
+			models[i].Price = model.Price
+			owners[i] = append(owners[i], price)
+			continue
+		}
+		positions[key] = len(models)
+		models = append(models, model)
+		owners = append(owners, []*domain.CurrencyPrice{price})
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(upsertPrice).CreateInBatches(models, priceBatchSize).Error
+	})
+	if err != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	for i, model := range models {
+		for _, price := range owners[i] {
+			price.ID = model.ID
+			price.Source = model.Source
+		}
+	}
+	return nil
+}
+
+func (r *postgresRepository) GetLatestPrice(ctx context.Context, symbol, quote string) (*domain.CurrencyPrice, error) {
 	var model CurrencyPriceModel
 	result := r.db.WithContext(ctx).
-		Where("symbol = ?", symbol).
+		Where("symbol = ? AND quote = ?", symbol, quote).
 		Order("timestamp DESC").
 		First(&model)
 
@@ -169,42 +516,147 @@ func (r *postgresRepository) GetLatestPrice(ctx context.Context, symbol string)
 		return nil, domain.ErrDatabaseConnection
 	}
 
-	return &domain.CurrencyPrice{
-		ID:        model.ID,
-		Symbol:    model.Symbol,
-		Price:     model.Price,
-		Timestamp: model.Timestamp,
-	}, nil
+	return model.toDomain(), nil
 }
 
-func (r *postgresRepository) GetPriceByTimestamp(ctx context.Context, symbol string, timestamp time.Time) (*domain.CurrencyPrice, error) {
-	var model CurrencyPriceModel
-	result := r.db.WithContext(ctx).
-		Where("symbol = ? AND timestamp <= ?", symbol, timestamp).
-		Order("timestamp DESC").
-		First(&model)
+func (r *postgresRepository) GetLatestPrices(ctx context.Context, symbols []string, quote string) (map[string]*domain.CurrencyPrice, error) {
+	var models []CurrencyPriceModel
+	result := r.db.WithContext(ctx).Raw(`
+		SELECT DISTINCT ON (symbol) * FROM currency_prices
+		WHERE symbol IN ? AND quote = ?
+		ORDER BY symbol, timestamp DESC`,
+		symbols, quote,
+	).Scan(&models)
 
 	if result.Error != nil {
-		if result.Error == gorm.ErrRecordNotFound {
-			return nil, domain.ErrCurrencyNotFound
-		}
 		return nil, domain.ErrDatabaseConnection
 	}
 
-	return &domain.CurrencyPrice{
-		ID:        model.ID,
-		Symbol:    model.Symbol,
-		Price:     model.Price,
-		Timestamp: model.Timestamp,
-	}, nil
+	prices := make(map[string]*domain.CurrencyPrice, len(models))
+	for _, model := range models {
+		prices[model.Symbol] = model.toDomain()
+	}
+
+	return prices, nil
+}
+
+// pricesAroundBatchSQL находит соседние цены для каждой точки (symbol, ts) из массивов-параметров.
+// ordinality нумерует точки с единицы, чтобы сопоставить строки результата с запросом.
+const pricesAroundBatchSQL = `
+	WITH points AS (
+		SELECT symbol, ts, idx
+		FROM unnest(CAST(? AS text[]), CAST(? AS timestamptz[])) WITH ORDINALITY AS t(symbol, ts, idx)
+	)
+	SELECT points.idx, 'before' AS side, p.* FROM points
+	CROSS JOIN LATERAL (
+		SELECT * FROM ` + priceHistoryView + ` h
+		WHERE h.symbol = points.symbol AND h.quote = ? AND h.timestamp <= points.ts
+		ORDER BY h.timestamp DESC LIMIT 1
+	) p
+	UNION ALL
+	SELECT points.idx, 'after' AS side, p.* FROM points
+	CROSS JOIN LATERAL (
+		SELECT * FROM ` + priceHistoryView + ` h
+		WHERE h.symbol = points.symbol AND h.quote = ? AND h.timestamp >= points.ts
+		ORDER BY h.timestamp ASC LIMIT 1
+	) p`
+
+func (r *postgresRepository) GetPricesAroundBatch(ctx context.Context, points []domain.PricePoint, quote string) ([]*domain.CurrencyPrice, []*domain.CurrencyPrice, error) {
+	before := make([]*domain.CurrencyPrice, len(points))
+	after := make([]*domain.CurrencyPrice, len(points))
+	if len(points) == 0 {
+		return before, after, nil
+	}
+
+	symbols := make(stringArray, len(points))
+	timestamps := make(stringArray, len(points))
+	for i, point := range points {
+		symbols[i] = point.Symbol
+		timestamps[i] = point.Timestamp.UTC().Format(time.RFC3339Nano)
+	}
+
+	var rows []struct {
+		CurrencyPriceModel
+		Idx  int
+		Side string
+	}
+	result := r.db.WithContext(ctx).Raw(pricesAroundBatchSQL, symbols, timestamps, quote, quote).Scan(&rows)
+	if result.Error != nil {
+		return nil, nil, domain.ErrDatabaseConnection
+	}
+
+	for _, row := range rows {
+		if row.Idx < 1 || row.Idx > len(points) {
+			continue
+		}
+		if row.Side == "before" {
+			before[row.Idx-1] = row.CurrencyPriceModel.toDomain()
+		} else {
+			after[row.Idx-1] = row.CurrencyPriceModel.toDomain()
+		}
+	}
+
+	return before, after, nil
+}
+
+func (r *postgresRepository) GetPricesAround(ctx context.Context, symbol, quote string, timestamp time.Time) (*domain.CurrencyPrice, *domain.CurrencyPrice, error) {
+	var rows []struct {
+		CurrencyPriceModel
+		Side string
+	}
+
+	// Обе соседние цены одним запросом; каждая половина использует индекс (symbol, quote, timestamp)
+	result := r.db.WithContext(ctx).Raw(`
+		(SELECT *, 'before' AS side FROM `+priceHistoryView+`
+			WHERE symbol = ? AND quote = ? AND timestamp <= ?
+			ORDER BY timestamp DESC LIMIT 1)
+		UNION ALL
+		(SELECT *, 'after' AS side FROM `+priceHistoryView+`
+			WHERE symbol = ? AND quote = ? AND timestamp >= ?
+			ORDER BY timestamp ASC LIMIT 1)`,
+		symbol, quote, timestamp, symbol, quote, timestamp,
+	).Scan(&rows)
+
+	if result.Error != nil {
+		return nil, nil, domain.ErrDatabaseConnection
+	}
+
+	var before, after *domain.CurrencyPrice
+	for _, row := range rows {
+		if row.Side == "before" {
+			before = row.CurrencyPriceModel.toDomain()
+		} else {
+			after = row.CurrencyPriceModel.toDomain()
+		}
+	}
+
+	return before, after, nil
 }
 
-func (r *postgresRepository) GetPriceHistory(ctx context.Context, symbol string, startTime, endTime time.Time, limit int) ([]*domain.CurrencyPrice, error) {
+func (r *postgresRepository) GetPriceHistory(ctx context.Context, symbol, quote string, startTime, endTime time.Time, ascending bool, after *domain.PricePosition, limit int) ([]*domain.CurrencyPrice, error) {
 	var models []CurrencyPriceModel
 
+	// Старые периоды хранятся в агрегатах, представление отдаёт их вместе с сырыми ценами
 	query := r.db.WithContext(ctx).
-		Where("symbol = ? AND timestamp BETWEEN ? AND ?", symbol, startTime, endTime).
-		Order("timestamp DESC")
+		Table(priceHistoryView).
+		Where("symbol = ? AND quote = ?", symbol, quote)
+
+	if !startTime.IsZero() {
+		query = query.Where("timestamp >= ?", startTime)
+	}
+	if !endTime.IsZero() {
+		query = query.Where("timestamp <= ?", endTime)
+	}
+
+	// Keyset-пагинация: source делает порядок однозначным при совпадении времени
+	direction, compare := "DESC", "<"
+	if ascending {
+		direction, compare = "ASC", ">"
+	}
+	if after != nil {
+		query = query.Where("(timestamp, source) "+compare+" (?, ?)", after.Timestamp, after.Source)
+	}
+	query = query.Order("timestamp " + direction).Order("source " + direction)
 
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -218,13 +670,173 @@ func (r *postgresRepository) GetPriceHistory(ctx context.Context, symbol string,
 
 	prices := make([]*domain.CurrencyPrice, len(models))
 	for i, model := range models {
-		prices[i] = &domain.CurrencyPrice{
-			ID:        model.ID,
-			Symbol:    model.Symbol,
-			Price:     model.Price,
-			Timestamp: model.Timestamp,
-		}
+		prices[i] = model.toDomain()
 	}
 
 	return prices, nil
 }
+
+type candleRow struct {
+	OpenTime time.Time
+	Open     domain.Decimal
+	High     domain.Decimal
+	Low      domain.Decimal
+	Close    domain.Decimal
+	Count    int64
+}
+
+func (r *postgresRepository) GetCandles(ctx context.Context, symbol, quote string, interval time.Duration, startTime, endTime time.Time) ([]*domain.Candle, error) {
+	// Агрегат целиком попадает в одну свечу, только если интервал кратен его шагу
+	resolution, err := r.storedResolution(ctx, symbol, quote, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	if resolution > 0 && interval%resolution != 0 {
+		return nil, domain.ErrCandleIntervalTooFine
+	}
+
+	var rows []candleRow
+
+	// Сырые цены и агрегаты приводятся к общему виду и сливаются: open берётся у самого раннего куска,
+	// close - у самого позднего, count суммируется. date_bin выравнивает начало по границам интервала от эпохи.
+	// Берутся только агрегаты, целиком лежащие в периоде, поэтому все куски не раньше startTime,
+	// и свечи начинаются не раньше выровненного начала периода.
+	hourFrom, hourTo := rollupWindow(time.Hour, startTime, endTime)
+	dayFrom, dayTo := rollupWindow(24*time.Hour, startTime, endTime)
+	result := r.db.WithContext(ctx).Raw(`
+		WITH pieces AS (
+			SELECT timestamp AS bucket, timestamp AS first_time, timestamp AS last_time,
+				price AS open, price AS high, price AS low, price AS close, 1::BIGINT AS count, id AS seq
+			FROM currency_prices
+			WHERE symbol = @symbol AND quote = @quote AND timestamp BETWEEN @start AND @end
+			UNION ALL
+			SELECT bucket, first_time, last_time, open, high, low, close, count, 0
+			FROM `+rollupHourlyTable+`
+			WHERE symbol = @symbol AND quote = @quote AND bucket BETWEEN @hourFrom AND @hourTo
+			UNION ALL
+			SELECT bucket, first_time, last_time, open, high, low, close, count, 0
+			FROM `+rollupDailyTable+`
+			WHERE symbol = @symbol AND quote = @quote AND bucket BETWEEN @dayFrom AND @dayTo
+		)
+		SELECT
+			date_bin(CAST(@interval AS interval), bucket, TIMESTAMPTZ '1970-01-01 00:00:00+00') AS open_time,
+			(array_agg(open ORDER BY first_time ASC, seq ASC))[1] AS open,
+			MAX(high) AS high,
+			MIN(low) AS low,
+			(array_agg(close ORDER BY last_time DESC, seq DESC))[1] AS close,
+			SUM(count)::BIGINT AS count
+		FROM pieces
+		GROUP BY open_time
+		ORDER BY open_time ASC`,
+		map[string]interface{}{
+			"symbol":   symbol,
+			"quote":    quote,
+			"start":    startTime,
+			"end":      endTime,
+			"hourFrom": hourFrom,
+			"hourTo":   hourTo,
+			"dayFrom":  dayFrom,
+			"dayTo":    dayTo,
+			"interval": fmt.Sprintf("%d seconds", int64(interval.Seconds())),
+		},
+	).Scan(&rows)
+
+	if result.Error != nil {
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	candles := make([]*domain.Candle, len(rows))
+	for i, row := range rows {
+		candles[i] = &domain.Candle{
+			Symbol:   symbol,
+			Quote:    quote,
+			OpenTime: row.OpenTime,
+			Open:     row.Open,
+			High:     row.High,
+			Low:      row.Low,
+			Close:    row.Close,
+			Count:    row.Count,
+		}
+	}
+
+	return candles, nil
+}
+
+// rollupTables - таблицы агрегатов и их шаг, от крупного к мелкому
+var rollupTables = []struct {
+	name string
+	step time.Duration
+}{
+	{rollupDailyTable, 24 * time.Hour},
+	{rollupHourlyTable, time.Hour},
+}
+
+// rollupWindow возвращает границы начала агрегатов с шагом step, целиком лежащих в [startTime, endTime].
+// Агрегат, начатый до startTime или закончившийся после endTime, содержит цены вне периода:
+// его open, high и low исказили бы крайние свечи, а date_bin дал бы свечу раньше запрошенного начала.
+func rollupWindow(step time.Duration, startTime, endTime time.Time) (from, to time.Time) {
+	// Границы агрегатов кратны часу и суткам в UTC, как и при отсчёте Truncate от нулевого времени
+	from = startTime.UTC().Truncate(step)
+	if from.Before(startTime) {
+		from = from.Add(step)
+	}
+	return from, endTime.Add(-step)
+}
+
+// storedResolution возвращает самый крупный шаг агрегатов, попадающих в свечи за период, или 0, если за период есть только сырые цены
+func (r *postgresRepository) storedResolution(ctx context.Context, symbol, quote string, startTime, endTime time.Time) (time.Duration, error) {
+	for _, table := range rollupTables {
+		from, to := rollupWindow(table.step, startTime, endTime)
+		if to.Before(from) {
+			continue
+		}
+
+		var exists bool
+		result := r.db.WithContext(ctx).Raw(
+			`SELECT EXISTS (SELECT 1 FROM `+table.name+` WHERE symbol = ? AND quote = ? AND bucket BETWEEN ? AND ?)`,
+			symbol, quote, from, to,
+		).Scan(&exists)
+
+		if result.Error != nil {
+			return 0, domain.ErrDatabaseConnection
+		}
+		if exists {
+			return table.step, nil
+		}
+	}
+	return 0, nil
+}
+
+func (r *postgresRepository) GetPriceCoverage(ctx context.Context, symbol, quote string, startTime, endTime time.Time) (*domain.PriceCoverage, error) {
+	coverage := &domain.PriceCoverage{}
+	result := r.db.WithContext(ctx).
+		Model(&CurrencyPriceModel{}).
+		Where("symbol = ? AND quote = ? AND timestamp BETWEEN ? AND ?", symbol, quote, startTime, endTime).
+		Pluck("timestamp", &coverage.Timestamps)
+
+	if result.Error != nil {
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	// Сжатие удаляет исходные строки, поэтому свёрнутые интервалы не пересекаются ни друг с другом, ни с сырыми ценами
+	for _, table := range rollupTables {
+		var buckets []time.Time
+		result := r.db.WithContext(ctx).
+			Table(table.name).
+			Where("symbol = ? AND quote = ? AND bucket > ? AND bucket <= ?", symbol, quote, startTime.Add(-table.step), endTime).
+			Pluck("bucket", &buckets)
+
+		if result.Error != nil {
+			return nil, domain.ErrDatabaseConnection
+		}
+		for _, bucket := range buckets {
+			coverage.Compacted = append(coverage.Compacted, domain.PricePeriod{From: bucket, To: bucket.Add(table.step)})
+		}
+	}
+
+	sort.Slice(coverage.Compacted, func(i, j int) bool {
+		return coverage.Compacted[i].From.Before(coverage.Compacted[j].From)
+	})
+
+	return coverage, nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRollupWindow(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		step       time.Duration
+		start, end time.Time
+		from, to   time.Time
+	}{
+		{
+			name:  "aligned start",
+			step:  time.Hour,
+			start: day.Add(10 * time.Hour),
+			end:   day.Add(14 * time.Hour),
+			from:  day.Add(10 * time.Hour),
+			to:    day.Add(13 * time.Hour),
+		},
+		{
+			// Агрегат 10:00-11:00 содержит цены до начала периода и не берётся
+			name:  "start in the middle of an hour",
+			step:  time.Hour,
+			start: day.Add(10*time.Hour + 30*time.Minute),
+			end:   day.Add(14*time.Hour + 15*time.Minute),
+			from:  day.Add(11 * time.Hour),
+			to:    day.Add(13*time.Hour + 15*time.Minute),
+		},
+		{
+			name:  "start in the middle of a day",
+			step:  24 * time.Hour,
+			start: day.Add(6 * time.Hour),
+			end:   day.AddDate(0, 0, 5),
+			from:  day.AddDate(0, 0, 1),
+			to:    day.AddDate(0, 0, 4),
+		},
+		{
+			// Время в другом часовом поясе выравнивается по суткам UTC
+			name:  "non-UTC start",
+			step:  24 * time.Hour,
+			start: day.In(time.FixedZone("UTC+3", 3*60*60)),
+			end:   day.AddDate(0, 0, 2),
+			from:  day,
+			to:    day.AddDate(0, 0, 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := rollupWindow(tt.step, tt.start, tt.end)
+			if !from.Equal(tt.from) || !to.Equal(tt.to) {
+				t.Errorf("rollupWindow = [%v, %v], want [%v, %v]", from, to, tt.from, tt.to)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+const (
+	pricePartitionPrefix  = "currency_prices_"
+	pricePartitionLayout  = "2006_01"
+	pricePartitionDefault = "currency_prices_default"
+)
+
+type priceMaintenanceRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceMaintenanceRepository(db *gorm.DB) ports.PriceMaintenanceRepository {
+	return &priceMaintenanceRepository{db: db}
+}
+
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// EnsurePricePartitions создаёт недостающие помесячные секции для периода [from, to]
+func (r *priceMaintenanceRepository) EnsurePricePartitions(ctx context.Context, from, to time.Time) error {
+	for month := monthStart(from); !month.After(to); month = month.AddDate(0, 1, 0) {
+		if err := r.ensurePricePartition(ctx, month); err != nil {
+			return fmt.Errorf("%w: partition %s: %v", domain.ErrDatabaseConnection, month.Format(pricePartitionLayout), err)
+		}
+	}
+	return nil
+}
+
+// ensurePricePartition создаёт секцию за месяц. Цены этого месяца, уже попавшие в секцию по умолчанию,
+// переносятся в новую секцию до её подключения: PostgreSQL не подключит секцию, пока в секции
+// по умолчанию есть строки из её диапазона, а оставшиеся там цены никогда не удалились бы вместе с секцией.
+func (r *priceMaintenanceRepository) ensurePricePartition(ctx context.Context, month time.Time) error {
+	name := pricePartitionPrefix + month.Format(pricePartitionLayout)
+	from, to := month.Format(time.RFC3339), month.AddDate(0, 1, 0).Format(time.RFC3339)
+
+	// Обычно секция уже есть - проверяем без блокировки
+	if exists, err := partitionExists(r.db.WithContext(ctx), name); err != nil || exists {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Блокировка секции по умолчанию не даёт записать в неё цены месяца между переносом и подключением
+		// и заодно упорядочивает параллельные вызовы (планировщик и загрузка истории)
+		if err := tx.Exec(`LOCK TABLE ` + pricePartitionDefault + ` IN ACCESS EXCLUSIVE MODE`).Error; err != nil {
+			return err
+		}
+		if exists, err := partitionExists(tx, name); err != nil || exists {
+			return err
+		}
+
+		statements := []string{
+			fmt.Sprintf(`CREATE TABLE %s (LIKE currency_prices INCLUDING DEFAULTS INCLUDING CONSTRAINTS)`, name),
+			fmt.Sprintf(`WITH moved AS (
+				DELETE FROM %s WHERE timestamp >= '%s' AND timestamp < '%s' RETURNING *
+			) INSERT INTO %s SELECT * FROM moved`, pricePartitionDefault, from, to, name),
+			fmt.Sprintf(`ALTER TABLE currency_prices ATTACH PARTITION %s FOR VALUES FROM ('%s') TO ('%s')`, name, from, to),
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func partitionExists(db *gorm.DB, name string) (bool, error) {
+	var exists bool
+	err := db.Raw(`SELECT to_regclass(?) IS NOT NULL`, name).Scan(&exists).Error
+	return exists, err
+}
+
+// PartitionDefaultPrices создаёт секции за все месяцы, цены которых лежат в секции по умолчанию
+// (например, история, загруженная до появления секций), и переносит эти цены в них
+func (r *priceMaintenanceRepository) PartitionDefaultPrices(ctx context.Context) error {
+	var bounds struct {
+		Oldest *time.Time
+		Newest *time.Time
+	}
+	result := r.db.WithContext(ctx).Raw(
+		`SELECT MIN(timestamp) AS oldest, MAX(timestamp) AS newest FROM ` + pricePartitionDefault,
+	).Scan(&bounds)
+
+	if result.Error != nil {
+		return domain.ErrDatabaseConnection
+	}
+	if bounds.Oldest == nil || bounds.Newest == nil {
+		return nil
+	}
+
+	return r.EnsurePricePartitions(ctx, *bounds.Oldest, *bounds.Newest)
+}
+
+// ListPricePartitions возвращает помесячные секции; секция по умолчанию не включается
+func (r *priceMaintenanceRepository) ListPricePartitions(ctx context.Context) ([]domain.PricePartition, error) {
+	var names []string
+	result := r.db.WithContext(ctx).Raw(`
+		SELECT child.relname
+		FROM pg_inherits
+		JOIN pg_class parent ON parent.oid = pg_inherits.inhparent
+		JOIN pg_class child ON child.oid = pg_inherits.inhrelid
+		WHERE parent.relname = 'currency_prices'
+		ORDER BY child.relname`).Scan(&names)
+
+	if result.Error != nil {
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	partitions := make([]domain.PricePartition, 0, len(names))
+	for _, name := range names {
+		partition, ok := parsePricePartition(name)
+		if ok {
+			partitions = append(partitions, partition)
+		}
+	}
+	return partitions, nil
+}
+
+func parsePricePartition(name string) (domain.PricePartition, bool) {
+	if len(name) != len(pricePartitionPrefix)+len(pricePartitionLayout) || name[:len(pricePartitionPrefix)] != pricePartitionPrefix {
+		return domain.PricePartition{}, false
+	}
+
+	from, err := time.Parse(pricePartitionLayout, name[len(pricePartitionPrefix):])
+	if err != nil {
+		return domain.PricePartition{}, false
+	}
+
+	return domain.PricePartition{Name: name, From: from, To: from.AddDate(0, 1, 0)}, true
+}
+
+func (r *priceMaintenanceRepository) DropPricePartition(ctx context.Context, partition domain.PricePartition) error {
+	// Имя проверяется, чтобы в DROP TABLE не попало ничего, кроме секции цен
+	if _, ok := parsePricePartition(partition.Name); !ok {
+		return fmt.Errorf("not a price partition: %q", partition.Name)
+	}
+
+	if err := r.db.WithContext(ctx).Exec(`DROP TABLE IF EXISTS ` + partition.Name).Error; err != nil {
+		return domain.ErrDatabaseConnection
+	}
+	return nil
+}
+
+// priceTables - таблицы с ценами и колонка времени в каждой из них
+var priceTables = []struct{ name, timeColumn string }{
+	{"currency_prices", "timestamp"},
+	{rollupHourlyTable, "last_time"},
+	{rollupDailyTable, "last_time"},
+}
+
+func (r *priceMaintenanceRepository) DeleteSymbolPricesBefore(ctx context.Context, symbol string, before time.Time) (int64, error) {
+	return r.deleteBefore(ctx, before, "symbol = ?", symbol)
+}
+
+func (r *priceMaintenanceRepository) DeletePricesBeforeExcept(ctx context.Context, before time.Time, exceptSymbols []string) (int64, error) {
+	if len(exceptSymbols) == 0 {
+		return r.deleteBefore(ctx, before, "TRUE")
+	}
+	return r.deleteBefore(ctx, before, "symbol NOT IN ?", exceptSymbols)
+}
+
+// deleteBefore удаляет устаревшие цены из сырых данных и агрегатов в одной транзакции
+func (r *priceMaintenanceRepository) deleteBefore(ctx context.Context, before time.Time, condition string, args ...interface{}) (int64, error) {
+	var deleted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range priceTables {
+			query := fmt.Sprintf("DELETE FROM %s WHERE %s < ? AND %s", table.name, table.timeColumn, condition)
+			result := tx.Exec(query, append([]interface{}{before}, args...)...)
+			if result.Error != nil {
+				return result.Error
+			}
+			deleted += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, domain.ErrDatabaseConnection
+	}
+	return deleted, nil
+}
+
+const (
+	rollupHourlyTable = "price_rollups_hourly"
+	rollupDailyTable  = "price_rollups_daily"
+)
+
+// compactRawSQL переносит сырые цены старше границы в агрегаты одним запросом:
+// DELETE ... RETURNING и INSERT выполняются атомарно
+const compactRawSQL = `
+WITH moved AS (
+	DELETE FROM currency_prices WHERE timestamp < ?
+	RETURNING symbol, quote, price, timestamp
+)
+INSERT INTO %[1]s AS r (symbol, quote, bucket, open, high, low, close, count, first_time, last_time, updated_at)
+SELECT symbol, quote,
+	date_trunc('%[2]s', timestamp AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS bucket,
+	(array_agg(price ORDER BY timestamp ASC))[1],
+	MAX(price),
+	MIN(price),
+	(array_agg(price ORDER BY timestamp DESC))[1],
+	COUNT(*),
+	MIN(timestamp),
+	MAX(timestamp),
+	NOW()
+FROM moved
+GROUP BY symbol, quote, bucket
+` + mergeRollupSQL
+
+// compactRollupSQL сворачивает часовые агрегаты в дневные
+const compactRollupSQL = `
+WITH moved AS (
+	DELETE FROM ` + rollupHourlyTable + ` WHERE bucket < ?
+	RETURNING symbol, quote, bucket, open, high, low, close, count, first_time, last_time
+)
+INSERT INTO %[1]s AS r (symbol, quote, bucket, open, high, low, close, count, first_time, last_time, updated_at)
+SELECT symbol, quote,
+	date_trunc('%[2]s', bucket AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS day,
+	(array_agg(open ORDER BY first_time ASC))[1],
+	MAX(high),
+	MIN(low),
+	(array_agg(close ORDER BY last_time DESC))[1],
+	SUM(count),
+	MIN(first_time),
+	MAX(last_time),
+	NOW()
+FROM moved
+GROUP BY symbol, quote, day
+` + mergeRollupSQL
+
+// mergeRollupSQL объединяет новый агрегат с уже существующим за тот же интервал
+// (например, когда загрузка истории добавила цены в уже свёрнутый период)
+const mergeRollupSQL = `
+ON CONFLICT (symbol, quote, bucket) DO UPDATE SET
+	open = CASE WHEN EXCLUDED.first_time < r.first_time THEN EXCLUDED.open ELSE r.open END,
+	close = CASE WHEN EXCLUDED.last_time >= r.last_time THEN EXCLUDED.close ELSE r.close END,
+	high = GREATEST(r.high, EXCLUDED.high),
+	low = LEAST(r.low, EXCLUDED.low),
+	count = r.count + EXCLUDED.count,
+	first_time = LEAST(r.first_time, EXCLUDED.first_time),
+	last_time = GREATEST(r.last_time, EXCLUDED.last_time),
+	updated_at = NOW()`
+
+func (r *priceMaintenanceRepository) CompactToHourly(ctx context.Context, before time.Time) (int64, error) {
+	return r.compact(ctx, fmt.Sprintf(compactRawSQL, rollupHourlyTable, "hour"), before)
+}
+
+func (r *priceMaintenanceRepository) CompactToDaily(ctx context.Context, before time.Time, fromRaw bool) (int64, error) {
+	query := fmt.Sprintf(compactRollupSQL, rollupDailyTable, "day")
+	if fromRaw {
+		query = fmt.Sprintf(compactRawSQL, rollupDailyTable, "day")
+	}
+	return r.compact(ctx, query, before)
+}
+
+func (r *priceMaintenanceRepository) compact(ctx context.Context, query string, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Exec(query, before)
+	if result.Error != nil {
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseConnection, result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// RegisteredProvider - провайдер цен с именем и приоритетом (меньше - раньше)
+type RegisteredProvider struct {
+	Name     string
+	Priority int
+	Provider ports.ExternalPriceProvider
+}
+
+// providerCooldowns - паузы провайдеров после rate limit, общие для реестров с одними провайдерами
+type providerCooldowns struct {
+	cooldown time.Duration
+
+	mu           sync.Mutex
+	limitedUntil map[string]time.Time
+}
+
+func newProviderCooldowns(cooldown time.Duration) *providerCooldowns {
+	return &providerCooldowns{
+		cooldown:     cooldown,
+		limitedUntil: make(map[string]time.Time),
+	}
+}
+
+// limited сообщает, находится ли провайдер на паузе
+func (c *providerCooldowns) limited(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	until, limited := c.limitedUntil[name]
+	return limited && time.Now().Before(until)
+}
+
+// record ставит провайдер на паузу, если ошибка - rate limit
+func (c *providerCooldowns) record(name string, err error) {
+	if !errors.Is(err, domain.ErrRateLimited) || c.cooldown <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	c.limitedUntil[name] = time.Now().Add(c.cooldown)
+	c.mu.Unlock()
+}
+
+// rateLimitedProvider не обращается к провайдеру, пока тот на паузе после rate limit,
+// и ставит его на паузу по ответу 429. Нужен там, где провайдеры опрашиваются в обход реестра.
+type rateLimitedProvider struct {
+	name      string
+	provider  ports.ExternalPriceProvider
+	cooldowns *providerCooldowns
+}
+
+func (p *rateLimitedProvider) GetCurrentPrice(ctx context.Context, symbol, quote string) (*domain.CurrencyPrice, error) {
+	if p.cooldowns.limited(p.name) {
+		return nil, domain.ErrRateLimited
+	}
+
+	price, err := p.provider.GetCurrentPrice(ctx, symbol, quote)
+	p.cooldowns.record(p.name, err)
+	return price, err
+}
+
+func (p *rateLimitedProvider) GetHistoricalPrices(ctx context.Context, symbol, quote string, startTime, endTime time.Time) ([]*domain.CurrencyPrice, error) {
+	if p.cooldowns.limited(p.name) {
+		return nil, domain.ErrRateLimited
+	}
+
+	prices, err := p.provider.GetHistoricalPrices(ctx, symbol, quote, startTime, endTime)
+	p.cooldowns.record(p.name, err)
+	return prices, err
+}
+
+// providerRegistry опрашивает провайдеров по приоритету и переключается на следующего при ошибке
+type providerRegistry struct {
+	providers []RegisteredProvider
+	cooldowns *providerCooldowns
+}
+
+// NewProviderRegistry создаёт реестр провайдеров. Провайдер, упёршийся в rate limit,
+// пропускается в течение cooldown.
+func NewProviderRegistry(cooldown time.Duration, providers ...RegisteredProvider) ports.ExternalPriceProvider {
+	return newProviderRegistry(newProviderCooldowns(cooldown), providers...)
+}
+
+func newProviderRegistry(cooldowns *providerCooldowns, providers ...RegisteredProvider) *providerRegistry {
+	sorted := append([]RegisteredProvider(nil), providers...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority < sorted[j].Priority
+	})
+
+	return &providerRegistry{
+		providers: sorted,
+		cooldowns: cooldowns,
+	}
+}
+
+func (r *providerRegistry) GetCurrentPrice(ctx context.Context, symbol, quote string) (*domain.CurrencyPrice, error) {
+	available, outcome := r.available()
+	for _, provider := range available {
+		price, err := provider.Provider.GetCurrentPrice(ctx, symbol, quote)
+		if err != nil {
+			outcome.fail(err)
+			r.handleFailure(provider.Name, symbol, err)
+			continue
+		}
+
+		if price.Source == "" {
+			price.Source = provider.Name
+		}
+		log.Printf("✅ Цена %s/%s от %s: %s", price.Symbol, price.Quote, price.Source, price.Price)
+		return price, nil
+	}
+
+	return nil, outcome.err()
+}
+
+func (r *providerRegistry) GetHistoricalPrices(ctx context.Context, symbol, quote string, startTime, endTime time.Time) ([]*domain.CurrencyPrice, error) {
+	available, outcome := r.available()
+	for _, provider := range available {
+		prices, err := provider.Provider.GetHistoricalPrices(ctx, symbol, quote, startTime, endTime)
+		if err != nil {
+			outcome.fail(err)
+			r.handleFailure(provider.Name, symbol, err)
+			continue
+		}
+		if len(prices) == 0 {
+			outcome.noData()
+			log.Printf("⚠️  %s: нет исторических данных для %s", provider.Name, symbol)
+			continue
+		}
+
+		for _, price := range prices {
+			if price.Source == "" {
+				price.Source = provider.Name
+			}
+		}
+		log.Printf("✅ Получено %d исторических точек для %s от %s", len(prices), symbol, provider.Name)
+		return prices, nil
+	}
+
+	return nil, outcome.err()
+}
+
+// providerOutcome копит причины, по которым провайдеры не вернули цену
+type providerOutcome struct {
+	unknown bool
+	limited bool
+	empty   bool
+}
+
+func (o *providerOutcome) fail(err error) {
+	o.unknown = o.unknown && isUnknownSymbol(err)
+	o.limited = o.limited || errors.Is(err, domain.ErrRateLimited)
+}
+
+// noData отмечает провайдера, который знает тикер, но не вернул цен за период
+func (o *providerOutcome) noData() {
+	o.unknown = false
+	o.empty = true
+}
+
+// err возвращает ошибку после перебора всех провайдеров: если ни один провайдер не знает тикер,
+// это не сбой внешнего API, а неизвестная валюта; если кто-то упёрся в rate limit - стоит повторить позже;
+// пустой ответ провайдера, знающего тикер, отличается от неизвестной валюты, чтобы история не терялась
+func (o *providerOutcome) err() error {
+	switch {
+	case o.unknown:
+		return domain.ErrCurrencyNotFound
+	case o.limited:
+		return domain.ErrRateLimited
+	case o.empty:
+		return domain.ErrNoHistoricalPrices
+	default:
+		return domain.ErrExternalAPIUnavailable
+	}
+}
+
+func isUnknownSymbol(err error) bool {
+	return errors.Is(err, domain.ErrCurrencyNotFound) || errors.Is(err, domain.ErrAmbiguousSymbol)
+}
+
+// available возвращает провайдеров, не находящихся на паузе после rate limit. Пропущенный
+// провайдер мог бы знать тикер, поэтому тогда результат уже не может быть "валюта неизвестна".
+func (r *providerRegistry) available() ([]RegisteredProvider, *providerOutcome) {
+	available := make([]RegisteredProvider, 0, len(r.providers))
+	outcome := &providerOutcome{}
+	for _, provider := range r.providers {
+		if r.cooldowns.limited(provider.Name) {
+			outcome.limited = true
+			continue
+		}
+		available = append(available, provider)
+	}
+	outcome.unknown = len(available) > 0 && !outcome.limited
+	return available, outcome
+}
+
+func (r *providerRegistry) handleFailure(name, symbol string, err error) {
+	log.Printf("⚠️  Провайдер %s не вернул цену %s: %v, переключаемся на следующий", name, symbol, err)
+	r.cooldowns.record(name, err)
+}
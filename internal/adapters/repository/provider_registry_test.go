@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+)
+
+// stubProvider отвечает заранее заданной ценой или ошибкой и считает вызовы
+type stubProvider struct {
+	price   *domain.CurrencyPrice
+	history []*domain.CurrencyPrice
+	err     error
+	calls   int
+}
+
+func (p *stubProvider) GetCurrentPrice(ctx context.Context, symbol, quote string) (*domain.CurrencyPrice, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	price := *p.price
+	return &price, nil
+}
+
+func (p *stubProvider) GetHistoricalPrices(ctx context.Context, symbol, quote string, startTime, endTime time.Time) ([]*domain.CurrencyPrice, error) {
+	p.calls++
+	return p.history, p.err
+}
+
+func stubPrice(value int64) *domain.CurrencyPrice {
+	return &domain.CurrencyPrice{Symbol: "BTC", Quote: "USD", Price: domain.NewDecimal(value, 0), Timestamp: time.Now()}
+}
+
+func TestProviderRegistryFailoverOrder(t *testing.T) {
+	first := &stubProvider{err: domain.ErrExternalAPIUnavailable}
+	second := &stubProvider{price: stubPrice(100)}
+	third := &stubProvider{price: stubPrice(200)}
+
+	// Порядок регистрации не важен, опрос идёт по приоритету
+	registry := NewProviderRegistry(time.Minute,
+		RegisteredProvider{Name: "third", Priority: 3, Provider: third},
+		RegisteredProvider{Name: "first", Priority: 1, Provider: first},
+		RegisteredProvider{Name: "second", Priority: 2, Provider: second},
+	)
+
+	price, err := registry.GetCurrentPrice(context.Background(), "BTC", "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price.Source != "second" || price.Price.Cmp(domain.NewDecimal(100, 0)) != 0 {
+		t.Errorf("got %s from %s, want 100 from second", price.Price, price.Source)
+	}
+	if first.calls != 1 || second.calls != 1 || third.calls != 0 {
+		t.Errorf("calls = %d/%d/%d, want 1/1/0", first.calls, second.calls, third.calls)
+	}
+}
+
+func TestProviderRegistryCooldown(t *testing.T) {
+	limited := &stubProvider{err: domain.ErrRateLimited}
+	backup := &stubProvider{price: stubPrice(100)}
+	registry := NewProviderRegistry(time.Minute,
+		RegisteredProvider{Name: "limited", Priority: 1, Provider: limited},
+		RegisteredProvider{Name: "backup", Priority: 2, Provider: backup},
+	)
+
+	for i := 0; i < 3; i++ {
+		price, err := registry.GetCurrentPrice(context.Background(), "BTC", "USD")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if price.Source != "backup" {
+			t.Errorf("source = %s, want backup", price.Source)
+		}
+	}
+	// После 429 провайдер пропускается до конца паузы
+	if limited.calls != 1 {
+		t.Errorf("rate limited provider called %d times, want 1", limited.calls)
+	}
+
+	// Остальные провайдеры недоступны - наружу уходит rate limit, а не "валюта не найдена"
+	backup.err = domain.ErrCurrencyNotFound
+	if _, err := registry.GetCurrentPrice(context.Background(), "BTC", "USD"); !errors.Is(err, domain.ErrRateLimited) {
+		t.Errorf("err = %v, want %v", err, domain.ErrRateLimited)
+	}
+}
+
+func TestProviderRegistryZeroCooldown(t *testing.T) {
+	limited := &stubProvider{err: domain.ErrRateLimited}
+	registry := NewProviderRegistry(0, RegisteredProvider{Name: "limited", Provider: limited})
+
+	for i := 0; i < 2; i++ {
+		if _, err := registry.GetCurrentPrice(context.Background(), "BTC", "USD"); !errors.Is(err, domain.ErrRateLimited) {
+			t.Errorf("err = %v, want %v", err, domain.ErrRateLimited)
+		}
+	}
+	if limited.calls != 2 {
+		t.Errorf("calls = %d, want 2: without cooldown the provider is not paused", limited.calls)
+	}
+}
+
+func TestProviderRegistryExhausted(t *testing.T) {
+	tests := []struct {
+		name string
+		errs []error
+		want error
+	}{
+		{"all unknown", []error{domain.ErrCurrencyNotFound, domain.ErrAmbiguousSymbol}, domain.ErrCurrencyNotFound},
+		{"unknown and unavailable", []error{domain.ErrCurrencyNotFound, domain.ErrExternalAPIUnavailable}, domain.ErrExternalAPIUnavailable},
+		{"unknown and rate limited", []error{domain.ErrCurrencyNotFound, domain.ErrRateLimited}, domain.ErrRateLimited},
+		{"no providers", nil, domain.ErrExternalAPIUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providers := make([]RegisteredProvider, len(tt.errs))
+			for i, err := range tt.errs {
+				providers[i] = RegisteredProvider{Name: err.Error(), Priority: i, Provider: &stubProvider{err: err}}
+			}
+			registry := NewProviderRegistry(time.Minute, providers...)
+
+			if _, err := registry.GetCurrentPrice(context.Background(), "BTC", "USD"); !errors.Is(err, tt.want) {
+				t.Errorf("GetCurrentPrice: err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderRegistryHistorySkipsEmpty(t *testing.T) {
+	empty := &stubProvider{}
+	full := &stubProvider{history: []*domain.CurrencyPrice{stubPrice(1), stubPrice(2)}}
+	registry := NewProviderRegistry(time.Minute,
+		RegisteredProvider{Name: "empty", Priority: 1, Provider: empty},
+		RegisteredProvider{Name: "full", Priority: 2, Provider: full},
+	)
+
+	prices, err := registry.GetHistoricalPrices(context.Background(), "BTC", "USD", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 2 || prices[0].Source != "full" {
+		t.Errorf("got %d prices from %q, want 2 from full", len(prices), prices[0].Source)
+	}
+
+	// Пустой ответ провайдера, знающего тикер, не означает, что валюта неизвестна
+	full.history = nil
+	if _, err := registry.GetHistoricalPrices(context.Background(), "BTC", "USD", time.Time{}, time.Time{}); !errors.Is(err, domain.ErrNoHistoricalPrices) {
+		t.Errorf("err = %v, want %v", err, domain.ErrNoHistoricalPrices)
+	}
+	full.err = domain.ErrCurrencyNotFound
+	if _, err := registry.GetHistoricalPrices(context.Background(), "BTC", "USD", time.Time{}, time.Time{}); !errors.Is(err, domain.ErrNoHistoricalPrices) {
+		t.Errorf("err = %v, want %v", err, domain.ErrNoHistoricalPrices)
+	}
+
+	// Валюта неизвестна, только если её не знает ни один провайдер
+	empty.err = domain.ErrCurrencyNotFound
+	if _, err := registry.GetHistoricalPrices(context.Background(), "BTC", "USD", time.Time{}, time.Time{}); !errors.Is(err, domain.ErrCurrencyNotFound) {
+		t.Errorf("err = %v, want %v", err, domain.ErrCurrencyNotFound)
+	}
+
+	consensus := NewConsensusPriceProvider(domain.NewDecimal(2, 2), 1,
+		RegisteredProvider{Name: "empty", Priority: 1, Provider: &stubProvider{}},
+		RegisteredProvider{Name: "unknown", Priority: 2, Provider: &stubProvider{err: domain.ErrCurrencyNotFound}},
+	)
+	if _, err := consensus.GetHistoricalPrices(context.Background(), "BTC", "USD", time.Time{}, time.Time{}); !errors.Is(err, domain.ErrNoHistoricalPrices) {
+		t.Errorf("consensus err = %v, want %v", err, domain.ErrNoHistoricalPrices)
+	}
+}
+
+func TestConsensusSharesCooldowns(t *testing.T) {
+	cooldowns := newProviderCooldowns(time.Minute)
+	limited := &stubProvider{err: domain.ErrRateLimited}
+	unknown := &stubProvider{err: domain.ErrCurrencyNotFound}
+	provider := NewConsensusPriceProvider(domain.NewDecimal(2, 2), 1,
+		RegisteredProvider{Name: "limited", Priority: 1, Provider: &rateLimitedProvider{name: "limited", provider: limited, cooldowns: cooldowns}},
+		RegisteredProvider{Name: "unknown", Priority: 2, Provider: unknown},
+	)
+
+	for i := 0; i < 2; i++ {
+		// Провайдер на паузе мог бы знать тикер, поэтому это не "валюта не найдена"
+		if _, err := provider.GetCurrentPrice(context.Background(), "BTC", "USD"); !errors.Is(err, domain.ErrInsufficientSources) {
+			t.Errorf("err = %v, want %v", err, domain.ErrInsufficientSources)
+		}
+	}
+	if limited.calls != 1 {
+		t.Errorf("rate limited provider called %d times, want 1", limited.calls)
+	}
+
+	limited.err = domain.ErrCurrencyNotFound
+	cooldowns = newProviderCooldowns(time.Minute)
+	provider = NewConsensusPriceProvider(domain.NewDecimal(2, 2), 1,
+		RegisteredProvider{Name: "limited", Priority: 1, Provider: &rateLimitedProvider{name: "limited", provider: limited, cooldowns: cooldowns}},
+		RegisteredProvider{Name: "unknown", Priority: 2, Provider: unknown},
+	)
+	if _, err := provider.GetHistoricalPrices(context.Background(), "BTC", "USD", time.Time{}, time.Time{}); !errors.Is(err, domain.ErrCurrencyNotFound) {
+		t.Errorf("history: err = %v, want %v", err, domain.ErrCurrencyNotFound)
+	}
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// stringArray - отображение PostgreSQL TEXT[] на []string
+type stringArray []string
+
+func (a stringArray) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+
+	elements := make([]string, len(a))
+	for i, element := range a {
+		escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(element)
+		elements[i] = `"` + escaped + `"`
+	}
+
+	return "{" + strings.Join(elements, ",") + "}", nil
+}
+
+func (a *stringArray) Scan(src interface{}) error {
+	var literal string
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		literal = v
+	case []byte:
+		literal = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into stringArray", src)
+	}
+
+	elements, err := parseArrayLiteral(literal)
+	if err != nil {
+		return err
+	}
+
+	*a = elements
+	return nil
+}
+
+// parseArrayLiteral разбирает одномерный литерал массива вида {a,"b c",d}
+func parseArrayLiteral(literal string) ([]string, error) {
+	if len(literal) < 2 || literal[0] != '{' || literal[len(literal)-1] != '}' {
+		return nil, fmt.Errorf("invalid array literal: %q", literal)
+	}
+
+	body := literal[1 : len(literal)-1]
+	elements := []string{}
+	if body == "" {
+		return elements, nil
+	}
+
+	var current strings.Builder
+	quoted, escaped := false, false
+	for _, r := range body {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			elements = append(elements, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	elements = append(elements, current.String())
+
+	return elements, nil
+}
@@ -0,0 +1,357 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+type WebhookEndpointModel struct {
+	ID                  int64       `gorm:"primaryKey;autoIncrement"`
+	URL                 string      `gorm:"not null"`
+	Events              stringArray `gorm:"type:text[];not null"`
+	Secret              string      `gorm:"not null;size:100"`
+	Description         string      `gorm:"not null"`
+	Enabled             bool        `gorm:"not null"`
+	ConsecutiveFailures int         `gorm:"not null"`
+	DisabledAt          *time.Time
+	CreatedAt           time.Time `gorm:"autoCreateTime"`
+	UpdatedAt           time.Time `gorm:"autoUpdateTime"`
+}
+
+func (WebhookEndpointModel) TableName() string {
+	return "webhook_endpoints"
+}
+
+func (m WebhookEndpointModel) toDomain() *domain.WebhookEndpoint {
+	events := make([]domain.WebhookEvent, len(m.Events))
+	for i, event := range m.Events {
+		events[i] = domain.WebhookEvent(event)
+	}
+
+	return &domain.WebhookEndpoint{
+		ID:                  m.ID,
+		URL:                 m.URL,
+		Events:              events,
+		Secret:              m.Secret,
+		Description:         m.Description,
+		Enabled:             m.Enabled,
+		ConsecutiveFailures: m.ConsecutiveFailures,
+		DisabledAt:          m.DisabledAt,
+		CreatedAt:           m.CreatedAt,
+		UpdatedAt:           m.UpdatedAt,
+	}
+}
+
+func webhookEndpointToModel(endpoint *domain.WebhookEndpoint) *WebhookEndpointModel {
+	events := make(stringArray, len(endpoint.Events))
+	for i, event := range endpoint.Events {
+		events[i] = string(event)
+	}
+
+	return &WebhookEndpointModel{
+		ID:                  endpoint.ID,
+		URL:                 endpoint.URL,
+		Events:              events,
+		Secret:              endpoint.Secret,
+		Description:         endpoint.Description,
+		Enabled:             endpoint.Enabled,
+		ConsecutiveFailures: endpoint.ConsecutiveFailures,
+		DisabledAt:          endpoint.DisabledAt,
+		CreatedAt:           endpoint.CreatedAt,
+		UpdatedAt:           endpoint.UpdatedAt,
+	}
+}
+
+type WebhookDeliveryModel struct {
+	ID             int64     `gorm:"primaryKey;autoIncrement"`
+	EndpointID     int64     `gorm:"not null"`
+	EventID        string    `gorm:"not null;size:64"`
+	Event          string    `gorm:"not null;size:50"`
+	Payload        string    `gorm:"type:jsonb;not null"`
+	Status         string    `gorm:"not null;size:20"`
+	Attempts       int       `gorm:"not null"`
+	LastStatusCode int       `gorm:"not null"`
+	LastError      string    `gorm:"not null"`
+	NextAttemptAt  time.Time `gorm:"not null"`
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+func (WebhookDeliveryModel) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (m WebhookDeliveryModel) toDomain() *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:             m.ID,
+		EndpointID:     m.EndpointID,
+		EventID:        m.EventID,
+		Event:          domain.WebhookEvent(m.Event),
+		Payload:        json.RawMessage(m.Payload),
+		Status:         m.Status,
+		Attempts:       m.Attempts,
+		LastStatusCode: m.LastStatusCode,
+		LastError:      m.LastError,
+		NextAttemptAt:  m.NextAttemptAt,
+		DeliveredAt:    m.DeliveredAt,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+func webhookDeliveryToModel(delivery *domain.WebhookDelivery) *WebhookDeliveryModel {
+	return &WebhookDeliveryModel{
+		ID:             delivery.ID,
+		EndpointID:     delivery.EndpointID,
+		EventID:        delivery.EventID,
+		Event:          string(delivery.Event),
+		Payload:        string(delivery.Payload),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) ports.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) CreateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	model := webhookEndpointToModel(endpoint)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	endpoint.ID = model.ID
+	endpoint.CreatedAt = model.CreatedAt
+	endpoint.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *webhookRepository) GetEndpoint(ctx context.Context, id int64) (*domain.WebhookEndpoint, error) {
+	var model WebhookEndpointModel
+	result := r.db.WithContext(ctx).First(&model, id)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, domain.ErrWebhookEndpointNotFound
+		}
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	return model.toDomain(), nil
+}
+
+func (r *webhookRepository) ListEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error) {
+	return r.findEndpoints(r.db.WithContext(ctx).Order("id"))
+}
+
+func (r *webhookRepository) ListEndpointsForEvent(ctx context.Context, event domain.WebhookEvent) ([]*domain.WebhookEndpoint, error) {
+	query := r.db.WithContext(ctx).
+		Where("enabled AND ? = ANY(events)", string(event)).
+		Order("id")
+	return r.findEndpoints(query)
+}
+
+func (r *webhookRepository) findEndpoints(query *gorm.DB) ([]*domain.WebhookEndpoint, error) {
+	var models []WebhookEndpointModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	endpoints := make([]*domain.WebhookEndpoint, len(models))
+	for i, model := range models {
+		endpoints[i] = model.toDomain()
+	}
+
+	return endpoints, nil
+}
+
+func (r *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	model := webhookEndpointToModel(endpoint)
+	model.UpdatedAt = time.Now()
+
+	// Select явно перечисляет колонки, чтобы выключение и сброс счётчика ошибок тоже сохранялись
+	result := r.db.WithContext(ctx).
+		Model(&WebhookEndpointModel{}).
+		Where("id = ?", endpoint.ID).
+		Select("url", "events", "description", "enabled", "consecutive_failures", "disabled_at", "updated_at").
+		Updates(model)
+
+	if result.Error != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrWebhookEndpointNotFound
+	}
+
+	endpoint.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *webhookRepository) DeleteEndpoint(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).Delete(&WebhookEndpointModel{}, id)
+
+	if result.Error != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrWebhookEndpointNotFound
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) RecordEndpointResult(ctx context.Context, id int64, success bool, disableAfter int) (bool, error) {
+	if success {
+		err := r.db.WithContext(ctx).
+			Model(&WebhookEndpointModel{}).
+			Where("id = ? AND consecutive_failures <> 0", id).
+			UpdateColumn("consecutive_failures", 0).Error
+		if err != nil {
+			return false, domain.ErrDatabaseConnection
+		}
+		return false, nil
+	}
+
+	// В SET справа видны значения строки до изменения, поэтому порог сравнивается с увеличенным счётчиком
+	var rows []struct{ Enabled bool }
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE webhook_endpoints SET
+			consecutive_failures = consecutive_failures + 1,
+			enabled = (? <= 0 OR consecutive_failures + 1 < ?),
+			disabled_at = CASE WHEN ? > 0 AND consecutive_failures + 1 >= ? THEN NOW() ELSE disabled_at END
+		WHERE id = ? AND enabled
+		RETURNING enabled`,
+		disableAfter, disableAfter, disableAfter, disableAfter, id).
+		Scan(&rows).Error
+	if err != nil {
+		return false, domain.ErrDatabaseConnection
+	}
+
+	return len(rows) == 1 && !rows[0].Enabled, nil
+}
+
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	models := make([]*WebhookDeliveryModel, len(deliveries))
+	for i, delivery := range deliveries {
+		models[i] = webhookDeliveryToModel(delivery)
+	}
+
+	if err := r.db.WithContext(ctx).CreateInBatches(models, 500).Error; err != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	for i, model := range models {
+		deliveries[i].ID = model.ID
+		deliveries[i].CreatedAt = model.CreatedAt
+		deliveries[i].UpdatedAt = model.UpdatedAt
+	}
+	return nil
+}
+
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error) {
+	// SKIP LOCKED позволяет нескольким экземплярам сервиса разбирать очередь, не мешая друг другу,
+	// а сдвиг next_attempt_at возвращает доставку в очередь, если обработчик упал посреди попытки
+	var models []WebhookDeliveryModel
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT d.id FROM webhook_deliveries d
+			JOIN webhook_endpoints e ON e.id = d.endpoint_id
+			WHERE d.status = ? AND d.next_attempt_at <= ? AND e.enabled
+			ORDER BY d.next_attempt_at
+			LIMIT ?
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease), domain.DeliveryPending, now, limit).
+		Scan(&models).Error
+	if err != nil {
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	deliveries := make([]*domain.WebhookDelivery, len(models))
+	for i, model := range models {
+		deliveries[i] = model.toDomain()
+	}
+
+	return deliveries, nil
+}
+
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	model := webhookDeliveryToModel(delivery)
+	model.UpdatedAt = time.Now()
+
+	result := r.db.WithContext(ctx).
+		Model(&WebhookDeliveryModel{}).
+		Where("id = ?", delivery.ID).
+		Select("status", "attempts", "last_status_code", "last_error", "next_attempt_at", "delivered_at", "updated_at").
+		Updates(model)
+
+	if result.Error != nil {
+		return domain.ErrDatabaseConnection
+	}
+
+	delivery.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, endpointID int64, status string, beforeID int64, limit int) ([]*domain.WebhookDelivery, error) {
+	query := r.db.WithContext(ctx).
+		Where("endpoint_id = ?", endpointID).
+		Order("id DESC").
+		Limit(limit)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+
+	var models []WebhookDeliveryModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, domain.ErrDatabaseConnection
+	}
+
+	deliveries := make([]*domain.WebhookDelivery, len(models))
+	for i, model := range models {
+		deliveries[i] = model.toDomain()
+	}
+
+	return deliveries, nil
+}
+
+func (r *webhookRepository) DeleteFinishedDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("status <> ? AND updated_at < ?", domain.DeliveryPending, before).
+		Delete(&WebhookDeliveryModel{})
+
+	if result.Error != nil {
+		return 0, domain.ErrDatabaseConnection
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// webhookErrorBodyLimit - сколько байт ответа с ошибкой сохраняется в журнале доставок
+const webhookErrorBodyLimit = 256
+
+type webhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender(timeout time.Duration) ports.WebhookSender {
+	return &webhookSender{
+		client: &http.Client{
+			Timeout: timeout,
+			// Редирект сменил бы адрес, на который получатель подписан, поэтому ответ 3xx считается ошибкой
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send отправляет тело доставки POST-запросом. Подпись передаётся в заголовке
+// X-Webhook-Signature как "sha256=<hex>" от строки "<X-Webhook-Timestamp>.<тело>".
+func (s *webhookSender) Send(ctx context.Context, endpoint *domain.WebhookEndpoint, delivery *domain.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "CryptoService/1.0")
+	req.Header.Set("X-Webhook-Event", string(delivery.Event))
+	req.Header.Set("X-Webhook-Id", delivery.EventID)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Webhook-Signature", "sha256="+domain.SignWebhook(endpoint.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBodyLimit))
+		io.Copy(io.Discard, resp.Body)
+		if message := strings.TrimSpace(string(body)); message != "" {
+			return resp.StatusCode, fmt.Errorf("HTTP %d: %s", resp.StatusCode, message)
+		}
+		return resp.StatusCode, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
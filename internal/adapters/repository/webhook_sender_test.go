@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+)
+
+func testDelivery() *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:      42,
+		EventID: "evt_1",
+		Event:   domain.EventPriceUpdated,
+		Payload: []byte(`{"id":"evt_1","type":"price.updated"}`),
+	}
+}
+
+func TestWebhookSenderSignsRequest(t *testing.T) {
+	delivery := testDelivery()
+	received := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() { received <- struct{}{} }()
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != string(delivery.Payload) {
+			t.Errorf("body = %s, want %s", body, delivery.Payload)
+		}
+
+		// Получатель проверяет подпись так же, как описано в документации
+		timestamp, err := strconv.ParseInt(r.Header.Get("X-Webhook-Timestamp"), 10, 64)
+		if err != nil {
+			t.Errorf("invalid X-Webhook-Timestamp: %v", err)
+		}
+		if time.Since(time.Unix(timestamp, 0)) > time.Minute {
+			t.Errorf("X-Webhook-Timestamp %d is not current", timestamp)
+		}
+		if got, want := r.Header.Get("X-Webhook-Signature"), "sha256="+domain.SignWebhook("whsec_test", timestamp, body); got != want {
+			t.Errorf("X-Webhook-Signature = %q, want %q", got, want)
+		}
+
+		headers := map[string]string{
+			"Content-Type":       "application/json",
+			"X-Webhook-Event":    "price.updated",
+			"X-Webhook-Id":       "evt_1",
+			"X-Webhook-Delivery": "42",
+		}
+		for name, want := range headers {
+			if got := r.Header.Get(name); got != want {
+				t.Errorf("%s = %q, want %q", name, got, want)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	endpoint := &domain.WebhookEndpoint{ID: 1, URL: server.URL, Secret: "whsec_test"}
+	status, err := NewWebhookSender(time.Second).Send(context.Background(), endpoint, delivery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", status, http.StatusNoContent)
+	}
+	<-received
+}
+
+func TestWebhookSenderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		status  int
+		message string
+	}{
+		{
+			name: "error with body",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "invalid signature", http.StatusUnauthorized)
+			},
+			status:  http.StatusUnauthorized,
+			message: "HTTP 401: invalid signature",
+		},
+		{
+			name: "long body is truncated",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(strings.Repeat("x", 10*webhookErrorBodyLimit)))
+			},
+			status:  http.StatusInternalServerError,
+			message: "HTTP 500: " + strings.Repeat("x", webhookErrorBodyLimit),
+		},
+		{
+			name: "redirect is not followed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "http://127.0.0.1:0/elsewhere", http.StatusFound)
+			},
+			status:  http.StatusFound,
+			message: "HTTP 302",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			endpoint := &domain.WebhookEndpoint{ID: 1, URL: server.URL, Secret: "whsec_test"}
+			status, err := NewWebhookSender(time.Second).Send(context.Background(), endpoint, testDelivery())
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if status != tt.status {
+				t.Errorf("status = %d, want %d", status, tt.status)
+			}
+			if !strings.HasPrefix(err.Error(), tt.message) || len(err.Error()) > len(tt.message) {
+				t.Errorf("error = %q, want %q", err, tt.message)
+			}
+		})
+	}
+}
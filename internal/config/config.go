@@ -3,13 +3,25 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
-	Database DatabaseConfig
-	Server   ServerConfig
-	GRPC     GRPCConfig
-	APIToken string
+	Database  DatabaseConfig
+	Server    ServerConfig
+	GRPC      GRPCConfig
+	Collector CollectorConfig
+	Catalog   CatalogConfig
+	Backfill  BackfillConfig
+	Retention RetentionConfig
+	Rollup    RollupConfig
+	Purge     PurgeConfig
+	Alerts    AlertsConfig
+	Webhooks  WebhooksConfig
+	Quotes    QuoteConfig
+	Providers ProvidersConfig
+	APIToken  string
 }
 
 type DatabaseConfig struct {
@@ -32,10 +44,114 @@ type GRPCConfig struct {
 	Host string
 }
 
+type QuoteConfig struct {
+	// Defaults - котировки для валют, добавленных без явного списка
+	Defaults []string
+}
+
+type ProviderConfig struct {
+	Name     string
+	Priority int
+	BaseURL  string
+	APIKey   string
+}
+
+type ProvidersConfig struct {
+	// Mode - failover (первый ответивший по приоритету) или consensus (медиана всех провайдеров)
+	Mode string
+	// Providers - провайдеры цен в порядке приоритета
+	Providers         []ProviderConfig
+	Timeout           time.Duration
+	RateLimitCooldown time.Duration
+	// FallbackEnabled - отдавать предустановленные цены, если все провайдеры недоступны
+	FallbackEnabled bool
+	Consensus       ConsensusConfig
+}
+
+type ConsensusConfig struct {
+	// MaxDeviation - допустимое относительное отклонение от медианы, например "0.02"
+	MaxDeviation string
+	MinSources   int
+}
+
+type CollectorConfig struct {
+	Enabled  bool
+	Interval time.Duration
+	Jitter   time.Duration
+}
+
+type CatalogConfig struct {
+	// RefreshInterval - период обновления каталога монет, 0 отключает обновление
+	RefreshInterval time.Duration
+}
+
+type BackfillConfig struct {
+	// ChunkSize - период, запрашиваемый у провайдера за один раз
+	ChunkSize  time.Duration
+	ChunkDelay time.Duration
+	RetryDelay time.Duration
+	MaxRetries int
+}
+
+type RetentionConfig struct {
+	// Default - срок хранения цен, 0 - без ограничений
+	Default time.Duration
+	// PerSymbol - сроки хранения отдельных валют, перекрывающие Default
+	PerSymbol           map[string]time.Duration
+	MaintenanceInterval time.Duration
+	// PartitionsAhead - на сколько месяцев вперёд создавать секции цен
+	PartitionsAhead int
+}
+
+type RollupConfig struct {
+	// HourlyAfter и DailyAfter - возраст цен, после которого они сворачиваются в агрегаты, 0 - не сворачивать
+	HourlyAfter time.Duration
+	DailyAfter  time.Duration
+	Interval    time.Duration
+}
+
+type PurgeConfig struct {
+	// After - сколько удалённая валюта хранится до окончательной очистки, 0 - не очищать
+	After time.Duration
+	// WithPrices - удалять ли вместе с валютой её историю цен
+	WithPrices bool
+	Interval   time.Duration
+}
+
+type AlertsConfig struct {
+	// DefaultCooldown - интервал между срабатываниями правила, если у него не задан свой
+	DefaultCooldown time.Duration
+	// MaxPriceAge - цены старше этого возраста правила не проверяют
+	MaxPriceAge time.Duration
+}
+
+type WebhooksConfig struct {
+	Timeout     time.Duration
+	MaxAttempts int
+	// RetryBase и RetryMax - первая пауза между попытками доставки и её предел
+	RetryBase time.Duration
+	RetryMax  time.Duration
+	// DisableAfter - число ошибок доставки подряд до отключения получателя, 0 - не отключать
+	DisableAfter int
+	PollInterval time.Duration
+	Workers      int
+	// MaxPriceAge - цены старше этого возраста не рассылаются
+	MaxPriceAge time.Duration
+	// LogRetention - сколько хранятся завершённые доставки в журнале, 0 - не очищать
+	LogRetention       time.Duration
+	LogCleanupInterval time.Duration
+}
+
 func Load() (*Config, error) {
 	dbPort, _ := strconv.Atoi(getEnv("DB_PORT", "5432"))
 	serverPort, _ := strconv.Atoi(getEnv("SERVER_PORT", "8080"))
 	grpcPort, _ := strconv.Atoi(getEnv("GRPC_PORT", "9090"))
+	consensusMinSources, _ := strconv.Atoi(getEnv("CONSENSUS_MIN_SOURCES", "2"))
+	backfillMaxRetries, _ := strconv.Atoi(getEnv("BACKFILL_MAX_RETRIES", "5"))
+	partitionsAhead, _ := strconv.Atoi(getEnv("PARTITION_MONTHS_AHEAD", "3"))
+	webhookMaxAttempts, _ := strconv.Atoi(getEnv("WEBHOOK_MAX_ATTEMPTS", "8"))
+	webhookDisableAfter, _ := strconv.Atoi(getEnv("WEBHOOK_DISABLE_AFTER", "20"))
+	webhookWorkers, _ := strconv.Atoi(getEnv("WEBHOOK_WORKERS", "4"))
 
 	return &Config{
 		Database: DatabaseConfig{
@@ -55,13 +171,148 @@ func Load() (*Config, error) {
 			Port: grpcPort,
 			Host: getEnv("GRPC_HOST", "0.0.0.0"),
 		},
+		Collector: CollectorConfig{
+			Enabled:  getBoolEnv("COLLECTOR_ENABLED", true),
+			Interval: getDurationEnv("COLLECTOR_INTERVAL", time.Minute),
+			Jitter:   getDurationEnv("COLLECTOR_JITTER", 10*time.Second),
+		},
+		Catalog: CatalogConfig{
+			RefreshInterval: getDurationEnv("CATALOG_REFRESH_INTERVAL", 24*time.Hour),
+		},
+		Backfill: BackfillConfig{
+			ChunkSize:  getDurationEnv("BACKFILL_CHUNK_SIZE", 30*24*time.Hour),
+			ChunkDelay: getDurationEnv("BACKFILL_CHUNK_DELAY", 2*time.Second),
+			RetryDelay: getDurationEnv("BACKFILL_RETRY_DELAY", time.Minute),
+			MaxRetries: backfillMaxRetries,
+		},
+		Retention: RetentionConfig{
+			Default:             getDurationEnv("PRICE_RETENTION_DEFAULT", 0),
+			PerSymbol:           loadRetention(getEnv("PRICE_RETENTION", "")),
+			MaintenanceInterval: getDurationEnv("PARTITION_MAINTENANCE_INTERVAL", time.Hour),
+			PartitionsAhead:     partitionsAhead,
+		},
+		Rollup: RollupConfig{
+			HourlyAfter: getDurationEnv("ROLLUP_HOURLY_AFTER", 30*24*time.Hour),
+			DailyAfter:  getDurationEnv("ROLLUP_DAILY_AFTER", 365*24*time.Hour),
+			Interval:    getDurationEnv("ROLLUP_INTERVAL", time.Hour),
+		},
+		Purge: PurgeConfig{
+			After:      getDurationEnv("CURRENCY_PURGE_AFTER", 30*24*time.Hour),
+			WithPrices: getBoolEnv("CURRENCY_PURGE_PRICES", true),
+			Interval:   getDurationEnv("CURRENCY_PURGE_INTERVAL", time.Hour),
+		},
+		Alerts: AlertsConfig{
+			DefaultCooldown: getDurationEnv("ALERT_DEFAULT_COOLDOWN", 15*time.Minute),
+			MaxPriceAge:     getDurationEnv("ALERT_MAX_PRICE_AGE", 10*time.Minute),
+		},
+		Webhooks: WebhooksConfig{
+			Timeout:            getDurationEnv("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts:        webhookMaxAttempts,
+			RetryBase:          getDurationEnv("WEBHOOK_RETRY_BASE", 10*time.Second),
+			RetryMax:           getDurationEnv("WEBHOOK_RETRY_MAX", time.Hour),
+			DisableAfter:       webhookDisableAfter,
+			PollInterval:       getDurationEnv("WEBHOOK_POLL_INTERVAL", 5*time.Second),
+			Workers:            webhookWorkers,
+			MaxPriceAge:        getDurationEnv("WEBHOOK_MAX_PRICE_AGE", 10*time.Minute),
+			LogRetention:       getDurationEnv("WEBHOOK_LOG_RETENTION", 7*24*time.Hour),
+			LogCleanupInterval: getDurationEnv("WEBHOOK_LOG_CLEANUP_INTERVAL", time.Hour),
+		},
+		Quotes: QuoteConfig{
+			Defaults: getListEnv("DEFAULT_QUOTES", []string{"USD"}),
+		},
+		Providers: ProvidersConfig{
+			Mode:              strings.ToLower(getEnv("PRICE_MODE", "failover")),
+			Providers:         loadProviders(getEnv("PRICE_PROVIDERS", "coingecko:1")),
+			Timeout:           getDurationEnv("EXTERNAL_API_TIMEOUT", 30*time.Second),
+			RateLimitCooldown: getDurationEnv("PROVIDER_RATE_LIMIT_COOLDOWN", time.Minute),
+			FallbackEnabled:   getBoolEnv("PRICE_FALLBACK_ENABLED", true),
+			Consensus: ConsensusConfig{
+				MaxDeviation: getEnv("CONSENSUS_MAX_DEVIATION", "0.02"),
+				MinSources:   consensusMinSources,
+			},
+		},
 		APIToken: getEnv("API_TOKEN", ""),
 	}, nil
 }
 
+// loadProviders разбирает список вида "coingecko:1,binance:2". Адрес и ключ API каждого
+// провайдера задаются переменными <NAME>_BASE_URL и <NAME>_API_KEY.
+func loadProviders(spec string) []ProviderConfig {
+	var providers []ProviderConfig
+	for i, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, priorityStr, _ := strings.Cut(item, ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+		priority, err := strconv.Atoi(strings.TrimSpace(priorityStr))
+		if err != nil {
+			priority = i + 1
+		}
+
+		envPrefix := strings.ToUpper(name)
+		providers = append(providers, ProviderConfig{
+			Name:     name,
+			Priority: priority,
+			BaseURL:  getEnv(envPrefix+"_BASE_URL", ""),
+			APIKey:   getEnv(envPrefix+"_API_KEY", ""),
+		})
+	}
+	return providers
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// loadRetention разбирает список вида "BTC:0,DOGE:2160h"
+func loadRetention(spec string) map[string]time.Duration {
+	retention := make(map[string]time.Duration)
+	for _, item := range strings.Split(spec, ",") {
+		symbol, durationStr, ok := strings.Cut(strings.TrimSpace(item), ":")
+		if !ok {
+			continue
+		}
+
+		duration, err := time.ParseDuration(strings.TrimSpace(durationStr))
+		if err != nil {
+			continue
+		}
+		retention[strings.ToUpper(strings.TrimSpace(symbol))] = duration
+	}
+	return retention
+}
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getListEnv(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,222 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// alertPercentPrecision - число знаков после запятой в изменении цены в процентах
+const alertPercentPrecision = 6
+
+// AlertCondition - условие срабатывания правила оповещения
+type AlertCondition string
+
+const (
+	// AlertAbove - цена достигла порога или выше него
+	AlertAbove AlertCondition = "above"
+	// AlertBelow - цена опустилась до порога или ниже него
+	AlertBelow AlertCondition = "below"
+	// AlertPercentChange - цена изменилась в любую сторону не меньше чем на Threshold процентов за Window
+	AlertPercentChange AlertCondition = "percent_change"
+)
+
+// AlertRule - правило оповещения о цене валюты в одной котировке
+type AlertRule struct {
+	ID        int64          `json:"id"`
+	Symbol    string         `json:"symbol"`
+	Quote     string         `json:"quote"`
+	Condition AlertCondition `json:"condition"`
+	// Threshold - уровень цены для above/below или изменение в процентах для percent_change
+	Threshold Decimal `json:"threshold"`
+	// Window - период, за который считается изменение percent_change
+	Window time.Duration `json:"window"`
+	// Cooldown - минимальный интервал между срабатываниями, 0 - значение по умолчанию
+	Cooldown time.Duration `json:"cooldown"`
+	Enabled  bool          `json:"enabled"`
+	// Armed - правило уровня ждёт пересечения порога. После срабатывания сбрасывается
+	// и взводится снова, когда цена вернётся по другую сторону порога.
+	Armed           bool       `json:"armed"`
+	Description     string     `json:"description"`
+	LastTriggeredAt *time.Time `json:"last_triggered_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// AlertRuleUpdate - частичное изменение правила, nil-поля остаются без изменений.
+// Символ и котировка не меняются: для другой пары заводится новое правило.
+type AlertRuleUpdate struct {
+	Condition   *AlertCondition
+	Threshold   *Decimal
+	Window      *time.Duration
+	Cooldown    *time.Duration
+	Enabled     *bool
+	Description *string
+}
+
+// Rearms сообщает, что изменение задаёт новое условие или порог и правило нужно взвести заново через Arm
+func (u AlertRuleUpdate) Rearms() bool {
+	return u.Condition != nil || u.Threshold != nil
+}
+
+// Apply переносит заданные поля изменения в правило. Взведение не меняется: оно зависит
+// от текущей цены, см. Rearms и Arm.
+func (u AlertRuleUpdate) Apply(r *AlertRule) {
+	if u.Condition != nil {
+		r.Condition = *u.Condition
+	}
+	if u.Threshold != nil {
+		r.Threshold = *u.Threshold
+	}
+	if u.Window != nil {
+		r.Window = *u.Window
+	}
+	if u.Cooldown != nil {
+		r.Cooldown = *u.Cooldown
+	}
+	if u.Enabled != nil {
+		r.Enabled = *u.Enabled
+	}
+	if u.Description != nil {
+		r.Description = *u.Description
+	}
+}
+
+// AlertTrigger - запись о срабатывании правила
+type AlertTrigger struct {
+	ID        int64          `json:"id"`
+	RuleID    int64          `json:"rule_id"`
+	Symbol    string         `json:"symbol"`
+	Quote     string         `json:"quote"`
+	Condition AlertCondition `json:"condition"`
+	Threshold Decimal        `json:"threshold"`
+	// Price и PriceTime - цена, на которой сработало правило
+	Price     Decimal   `json:"price"`
+	PriceTime time.Time `json:"price_time"`
+	// ReferencePrice и ChangePercent заполняются только для percent_change
+	ReferencePrice Decimal   `json:"reference_price"`
+	ChangePercent  Decimal   `json:"change_percent"`
+	TriggeredAt    time.Time `json:"triggered_at"`
+}
+
+// AlertTriggerQuery - запрос страницы истории срабатываний, от новых к старым
+type AlertTriggerQuery struct {
+	// RuleID и Symbol - необязательные фильтры
+	RuleID    int64
+	Symbol    string
+	PageSize  int
+	PageToken string
+}
+
+type AlertTriggerPage struct {
+	Triggers []*AlertTrigger
+	// NextPageToken пуст на последней странице
+	NextPageToken string
+}
+
+// Fingerprint описывает фильтры запроса, к которым привязан токен страницы
+func (q AlertTriggerQuery) Fingerprint() string {
+	return fmt.Sprintf("%d|%s", q.RuleID, q.Symbol)
+}
+
+func (r *AlertRule) Validate() error {
+	if r.Symbol == "" {
+		return ErrInvalidCurrencySymbol
+	}
+
+	if !isValidQuote(r.Quote) {
+		return ErrInvalidQuote
+	}
+
+	switch r.Condition {
+	case AlertAbove, AlertBelow:
+		if r.Window != 0 {
+			return ErrInvalidAlertWindow
+		}
+	case AlertPercentChange:
+		if r.Window < time.Second {
+			return ErrInvalidAlertWindow
+		}
+	default:
+		return ErrInvalidAlertCondition
+	}
+
+	if r.Threshold.Sign() <= 0 {
+		return ErrInvalidAlertThreshold
+	}
+
+	if r.Cooldown < 0 {
+		return ErrInvalidAlertCooldown
+	}
+
+	return nil
+}
+
+// CoolingDown сообщает, не истёк ли к моменту at интервал после последнего срабатывания
+func (r *AlertRule) CoolingDown(at time.Time, cooldown time.Duration) bool {
+	return r.LastTriggeredAt != nil && at.Before(r.LastTriggeredAt.Add(cooldown))
+}
+
+// IsLevel сообщает, срабатывает ли правило при пересечении уровня цены
+func (r *AlertRule) IsLevel() bool {
+	return r.Condition == AlertAbove || r.Condition == AlertBelow
+}
+
+// levelReached сообщает, находится ли цена по сторону порога, заданную условием уровня
+func (r *AlertRule) levelReached(price *CurrencyPrice) bool {
+	switch r.Condition {
+	case AlertAbove:
+		return price.Price.Cmp(r.Threshold) >= 0
+	case AlertBelow:
+		return price.Price.Cmp(r.Threshold) <= 0
+	default:
+		return false
+	}
+}
+
+// Arm взводит новое или изменённое правило. Если последняя известная цена уже за порогом,
+// правило остаётся невзведённым, иначе оно сработало бы на следующей цене без пересечения порога.
+// Без известной цены правило взводится.
+func (r *AlertRule) Arm(latest *CurrencyPrice) {
+	r.Armed = latest == nil || !r.levelReached(latest)
+}
+
+// ShouldRearm сообщает, что сработавшее правило уровня нужно взвести: цена вернулась за порог
+func (r *AlertRule) ShouldRearm(price *CurrencyPrice) bool {
+	return r.IsLevel() && !r.Armed && !r.levelReached(price)
+}
+
+// Evaluate проверяет условие правила для новой цены. reference - цена на начало окна,
+// нужна только для percent_change. Правило уровня срабатывает, только если оно взведено,
+// то есть цена пересекла порог, а не осталась за ним. Возвращает nil, если условие не выполнено.
+func (r *AlertRule) Evaluate(price, reference *CurrencyPrice) *AlertTrigger {
+	trigger := &AlertTrigger{
+		RuleID:    r.ID,
+		Symbol:    r.Symbol,
+		Quote:     r.Quote,
+		Condition: r.Condition,
+		Threshold: r.Threshold,
+		Price:     price.Price,
+		PriceTime: price.Timestamp,
+	}
+
+	switch r.Condition {
+	case AlertAbove, AlertBelow:
+		if !r.Armed || !r.levelReached(price) {
+			return nil
+		}
+	case AlertPercentChange:
+		if reference == nil || !reference.IsValidPrice() {
+			return nil
+		}
+		change, err := price.Price.Sub(reference.Price).Mul(NewDecimal(100, 0)).Div(reference.Price, alertPercentPrecision)
+		if err != nil || change.Abs().Cmp(r.Threshold) < 0 {
+			return nil
+		}
+		trigger.ReferencePrice = reference.Price
+		trigger.ChangePercent = change.Trim()
+	default:
+		return nil
+	}
+
+	return trigger
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAlertRuleLevelCrossing(t *testing.T) {
+	rule := &AlertRule{ID: 1, Symbol: "BTC", Quote: "USD", Condition: AlertAbove, Threshold: NewDecimal(100, 0), Armed: true}
+
+	steps := []struct {
+		price       int64
+		wantTrigger bool
+		wantRearm   bool
+	}{
+		{price: 90},
+		{price: 101, wantTrigger: true},
+		// Цена остаётся выше порога - повторного срабатывания нет
+		{price: 110},
+		{price: 100},
+		// Возврат под порог взводит правило, следующее пересечение снова срабатывает
+		{price: 99, wantRearm: true},
+		{price: 95},
+		{price: 100, wantTrigger: true},
+	}
+
+	for i, step := range steps {
+		price := &CurrencyPrice{Symbol: "BTC", Quote: "USD", Price: NewDecimal(step.price, 0), Timestamp: time.Now()}
+
+		if got := rule.ShouldRearm(price); got != step.wantRearm {
+			t.Fatalf("step %d (%d): ShouldRearm = %v, want %v", i, step.price, got, step.wantRearm)
+		}
+		if step.wantRearm {
+			rule.Armed = true
+		}
+
+		trigger := rule.Evaluate(price, nil)
+		if (trigger != nil) != step.wantTrigger {
+			t.Fatalf("step %d (%d): triggered = %v, want %v", i, step.price, trigger != nil, step.wantTrigger)
+		}
+		if trigger != nil {
+			rule.Armed = false
+		}
+	}
+}
+
+func TestAlertRuleBelowAndPercentChange(t *testing.T) {
+	below := &AlertRule{Condition: AlertBelow, Threshold: NewDecimal(50, 0), Armed: true}
+	if below.Evaluate(&CurrencyPrice{Price: NewDecimal(50, 0)}, nil) == nil {
+		t.Error("below: price at threshold should trigger")
+	}
+	below.Armed = false
+	if below.Evaluate(&CurrencyPrice{Price: NewDecimal(40, 0)}, nil) != nil {
+		t.Error("below: disarmed rule should not trigger")
+	}
+	if !below.ShouldRearm(&CurrencyPrice{Price: NewDecimal(51, 0)}) {
+		t.Error("below: price back above threshold should rearm")
+	}
+
+	change := &AlertRule{Condition: AlertPercentChange, Threshold: NewDecimal(5, 0), Window: time.Hour}
+	reference := &CurrencyPrice{Price: NewDecimal(100, 0)}
+	trigger := change.Evaluate(&CurrencyPrice{Price: NewDecimal(94, 0)}, reference)
+	if trigger == nil || trigger.ChangePercent.String() != "-6" {
+		t.Fatalf("percent_change: trigger = %+v, want change -6", trigger)
+	}
+	if change.ShouldRearm(&CurrencyPrice{Price: NewDecimal(100, 0)}) {
+		t.Error("percent_change rules are never rearmed")
+	}
+}
+
+func TestAlertRuleUpdateRearms(t *testing.T) {
+	rule := &AlertRule{Condition: AlertAbove, Threshold: NewDecimal(100, 0)}
+	description := "note"
+	if (AlertRuleUpdate{Description: &description}).Rearms() {
+		t.Error("description change should not rearm the rule")
+	}
+
+	threshold := NewDecimal(120, 0)
+	update := AlertRuleUpdate{Threshold: &threshold}
+	if !update.Rearms() {
+		t.Error("threshold change should rearm the rule")
+	}
+	update.Apply(rule)
+	if rule.Armed {
+		t.Error("Apply should leave arming to Arm")
+	}
+}
+
+func TestAlertRuleArm(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition AlertCondition
+		latest    *CurrencyPrice
+		wantArmed bool
+	}{
+		{name: "no price yet", condition: AlertAbove, wantArmed: true},
+		{name: "above, price below threshold", condition: AlertAbove, latest: &CurrencyPrice{Price: NewDecimal(90, 0)}, wantArmed: true},
+		// Цена уже за порогом: правило ждёт возврата цены, а не срабатывает сразу
+		{name: "above, price past threshold", condition: AlertAbove, latest: &CurrencyPrice{Price: NewDecimal(110, 0)}},
+		{name: "below, price past threshold", condition: AlertBelow, latest: &CurrencyPrice{Price: NewDecimal(90, 0)}},
+		{name: "percent change", condition: AlertPercentChange, latest: &CurrencyPrice{Price: NewDecimal(110, 0)}, wantArmed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &AlertRule{Condition: tt.condition, Threshold: NewDecimal(100, 0)}
+			rule.Arm(tt.latest)
+			if rule.Armed != tt.wantArmed {
+				t.Errorf("Armed = %v, want %v", rule.Armed, tt.wantArmed)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// Статусы задачи загрузки истории
+const (
+	BackfillPending   = "pending"
+	BackfillRunning   = "running"
+	BackfillCompleted = "completed"
+	BackfillFailed    = "failed"
+)
+
+// BackfillJob - задача загрузки истории цен за период. Период обрабатывается
+// частями, Cursor указывает на начало ещё не загруженной части.
+type BackfillJob struct {
+	ID            int64     `json:"id"`
+	Symbol        string    `json:"symbol"`
+	Quote         string    `json:"quote"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	Cursor        time.Time `json:"cursor"`
+	Status        string    `json:"status"`
+	FetchedCount  int64     `json:"fetched_count"`
+	InsertedCount int64     `json:"inserted_count"`
+	Error         string    `json:"error,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// IsFinished сообщает, завершена ли задача (успешно или с ошибкой)
+func (j *BackfillJob) IsFinished() bool {
+	return j.Status == BackfillCompleted || j.Status == BackfillFailed
+}
+
+// Progress возвращает долю обработанного периода от 0 до 1
+func (j *BackfillJob) Progress() float64 {
+	if j.Status == BackfillCompleted {
+		return 1
+	}
+
+	total := j.EndTime.Sub(j.StartTime)
+	if total <= 0 {
+		return 0
+	}
+
+	done := j.Cursor.Sub(j.StartTime)
+	return min(max(float64(done)/float64(total), 0), 1)
+}
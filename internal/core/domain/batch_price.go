@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// MaxBatchPriceItems - наибольшее число пар (символ, момент) в одном пакетном запросе цен
+const MaxBatchPriceItems = 10000
+
+// BatchPriceQuery - пакетный запрос цен: каждый символ на каждый момент времени.
+// Без моментов запрашивается последняя цена каждого символа.
+type BatchPriceQuery struct {
+	Symbols    []string
+	Timestamps []time.Time
+	Quote      string
+	Lookup     PriceLookup
+}
+
+// Size возвращает число элементов в ответе на запрос
+func (q BatchPriceQuery) Size() int {
+	return len(q.Symbols) * max(len(q.Timestamps), 1)
+}
+
+// PricePoint - символ и момент времени, на который нужна цена
+type PricePoint struct {
+	Symbol    string
+	Timestamp time.Time
+}
+
+// BatchPriceResult - результат для одного элемента пакета: цена или ошибка
+type BatchPriceResult struct {
+	Symbol string
+	// Timestamp - запрошенный момент, нулевой для последней цены
+	Timestamp time.Time
+	Result    *PriceLookupResult
+	Err       error
+}
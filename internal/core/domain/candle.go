@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// Candle - OHLC-свеча по ценам за интервал
+type Candle struct {
+	Symbol   string    `json:"symbol"`
+	Quote    string    `json:"quote"`
+	OpenTime time.Time `json:"open_time"`
+	Open     Decimal   `json:"open"`
+	High     Decimal   `json:"high"`
+	Low      Decimal   `json:"low"`
+	Close    Decimal   `json:"close"`
+	Count    int64     `json:"count"`
+}
+
+var candleIntervals = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"30m": 30 * time.Minute,
+	"1h":  time.Hour,
+	"4h":  4 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+// ParseCandleInterval переводит интервал вида "5m" или "1h" в длительность
+func ParseCandleInterval(interval string) (time.Duration, error) {
+	duration, ok := candleIntervals[interval]
+	if !ok {
+		return 0, ErrInvalidCandleInterval
+	}
+	return duration, nil
+}
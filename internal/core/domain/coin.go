@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Coin - запись каталога монет внешнего провайдера
+type Coin struct {
+	ID     string `json:"id"`
+	Symbol string `json:"symbol"`
+	Name   string `json:"name"`
+}
+
+// CoinOverride - ручная привязка тикера к идентификатору монеты для неоднозначных тикеров
+type CoinOverride struct {
+	Symbol    string    `json:"symbol"`
+	CoinID    string    `json:"coin_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
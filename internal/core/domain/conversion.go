@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// PivotQuote - котировка, через которую пересчитываются курсы между отслеживаемыми валютами
+const PivotQuote = "USD"
+
+// ConversionPrecision - число знаков после запятой в курсе и результате конвертации
+const ConversionPrecision = 18
+
+// ConversionQuery - запрос конвертации суммы From в To. Нулевой Timestamp означает последние цены.
+type ConversionQuery struct {
+	From      string
+	To        string
+	Amount    Decimal
+	Timestamp time.Time
+	Lookup    PriceLookup
+}
+
+// Conversion - результат конвертации и цены обеих валют в PivotQuote, по которым он рассчитан.
+// Для самой PivotQuote нога отсутствует (nil), её цена равна единице.
+type Conversion struct {
+	From            string
+	To              string
+	Amount          Decimal
+	Rate            Decimal
+	ConvertedAmount Decimal
+	FromLeg         *PriceLookupResult
+	ToLeg           *PriceLookupResult
+}
+
+// IsPivot сообщает, является ли символ котировкой пересчёта
+func IsPivot(symbol string) bool {
+	return strings.EqualFold(symbol, PivotQuote)
+}
@@ -1,24 +1,106 @@
 package domain
 
 import (
+	"strings"
 	"time"
 )
 
+// DefaultQuote - валюта котировки по умолчанию
+const DefaultQuote = "USD"
+
+// DefaultDecimals - число знаков после запятой при отображении цены по умолчанию
+const DefaultDecimals = 2
+
+// MaxDecimals - максимальная точность отображения цены
+const MaxDecimals = 18
+
+// maxTagLength - максимальная длина тега валюты
+const maxTagLength = 32
+
+// TrackingState - состояние сбора цен валюты
+type TrackingState string
+
+const (
+	TrackingActive TrackingState = "active"
+	// TrackingPaused - валюта остаётся в списке, но сборщик не запрашивает её цены
+	TrackingPaused TrackingState = "paused"
+)
+
+// Источники цен помимо имён внешних провайдеров
+const (
+	// SourceFallback - предустановленная (синтетическая) цена на случай недоступности провайдеров
+	SourceFallback = "fallback"
+	// SourceManual - цена, сохранённая без указания провайдера
+	SourceManual = "manual"
+	// SourceRollupHourly и SourceRollupDaily - цены закрытия часовых и дневных агрегатов старой истории
+	SourceRollupHourly = "rollup_1h"
+	SourceRollupDaily  = "rollup_1d"
+)
+
 
 type Currency struct {
-	ID        int64     `json:"id"`
-	Symbol    string    `json:"symbol"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID       int64    `json:"id"`
+	Symbol   string   `json:"symbol"`
+	Name     string   `json:"name"`
+	Quotes   []string `json:"quotes"`
+	Decimals int      `json:"decimals"`
+	Tags     []string `json:"tags"`
+	// ProviderCoinID - идентификатор монеты у провайдера, определённый при добавлении или изменении
+	ProviderCoinID string        `json:"provider_coin_id"`
+	Description    string        `json:"description"`
+	Tracking       TrackingState `json:"tracking"`
+	// MarketCap - последняя известная капитализация в USD, ноль - неизвестна
+	MarketCap Decimal    `json:"market_cap"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// CurrencyUpdate - частичное изменение валюты, nil-поля остаются без изменений.
+// Символ не меняется: по нему валюта связана с историей цен.
+type CurrencyUpdate struct {
+	Name        *string
+	Quotes      []string
+	Decimals    *int
+	Tags        []string
+	Description *string
+	Tracking    *TrackingState
+}
+
+// Apply переносит заданные поля изменения в валюту
+func (u CurrencyUpdate) Apply(c *Currency) {
+	if u.Name != nil {
+		c.Name = *u.Name
+	}
+	if u.Quotes != nil {
+		c.Quotes = NormalizeQuotes(u.Quotes)
+	}
+	if u.Decimals != nil {
+		c.Decimals = *u.Decimals
+	}
+	if u.Tags != nil {
+		c.Tags = NormalizeTags(u.Tags)
+	}
+	if u.Description != nil {
+		c.Description = *u.Description
+	}
+	if u.Tracking != nil {
+		c.Tracking = *u.Tracking
+	}
 }
 
 
 type CurrencyPrice struct {
-	ID        int64     `json:"id"`
-	Symbol    string    `json:"symbol"`
-	Price     float64   `json:"price"`
-	Timestamp time.Time `json:"timestamp"`
+	ID          int64     `json:"id"`
+	Symbol      string    `json:"symbol"`
+	Quote       string    `json:"quote"`
+	Price       Decimal   `json:"price"`
+	Timestamp   time.Time `json:"timestamp"`
+	Source      string    `json:"source"`
+	Sources     []string  `json:"sources,omitempty"`
+	IsSynthetic bool      `json:"is_synthetic"`
+	// MarketCap - капитализация в валюте котировки, если провайдер её сообщил; в истории не хранится
+	MarketCap Decimal `json:"market_cap"`
 }
 
 
@@ -31,10 +113,101 @@ func (c *Currency) Validate() error {
 		return ErrInvalidCurrencyName
 	}
 
+	if len(c.Quotes) == 0 {
+		return ErrInvalidQuote
+	}
+
+	for _, quote := range c.Quotes {
+		if !isValidQuote(quote) {
+			return ErrInvalidQuote
+		}
+	}
+
+	if c.Decimals < 0 || c.Decimals > MaxDecimals {
+		return ErrInvalidDecimals
+	}
+
+	for _, tag := range c.Tags {
+		if tag == "" || len(tag) > maxTagLength {
+			return ErrInvalidTag
+		}
+	}
+
+	if c.Tracking != TrackingActive && c.Tracking != TrackingPaused {
+		return ErrInvalidTrackingState
+	}
+
 	return nil
 }
 
+// IsPaused сообщает, приостановлен ли сбор цен валюты
+func (c *Currency) IsPaused() bool {
+	return c.Tracking == TrackingPaused
+}
+
 
 func (cp *CurrencyPrice) IsValidPrice() bool {
-	return cp.Price > 0
+	return cp.Price.Sign() > 0
+}
+
+
+// TracksQuote сообщает, отслеживается ли цена валюты в указанной котировке
+func (c *Currency) TracksQuote(quote string) bool {
+	for _, q := range c.Quotes {
+		if strings.EqualFold(q, quote) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeQuote приводит котировку к верхнему регистру, пустая означает DefaultQuote
+func NormalizeQuote(quote string) string {
+	quote = strings.ToUpper(strings.TrimSpace(quote))
+	if quote == "" {
+		return DefaultQuote
+	}
+	return quote
+}
+
+// NormalizeQuotes нормализует список котировок и убирает дубликаты
+func NormalizeQuotes(quotes []string) []string {
+	seen := make(map[string]struct{}, len(quotes))
+	normalized := make([]string, 0, len(quotes))
+	for _, quote := range quotes {
+		quote = NormalizeQuote(quote)
+		if _, ok := seen[quote]; ok {
+			continue
+		}
+		seen[quote] = struct{}{}
+		normalized = append(normalized, quote)
+	}
+	return normalized
+}
+
+// NormalizeTags приводит теги к нижнему регистру и убирает дубликаты
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]struct{}, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+func isValidQuote(quote string) bool {
+	if len(quote) < 3 || len(quote) > 10 {
+		return false
+	}
+	for _, r := range quote {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CurrencySortField - поле сортировки списка валют
+type CurrencySortField string
+
+const (
+	SortBySymbol    CurrencySortField = "symbol"
+	SortByCreatedAt CurrencySortField = "created_at"
+	// SortByMarketCap сортирует по капитализации в USD, валюты без капитализации всегда в конце
+	SortByMarketCap CurrencySortField = "market_cap"
+)
+
+// CurrencyFilter - фильтр списка валют, пустые поля не ограничивают выборку
+type CurrencyFilter struct {
+	Tag        string
+	NamePrefix string
+	Tracking   TrackingState
+}
+
+type CurrencyOrder struct {
+	Field      CurrencySortField
+	Descending bool
+}
+
+// CurrencyPosition - позиция валюты в отсортированном списке: значение поля сортировки и id.
+// Пустое Value означает отсутствие значения (капитализация неизвестна) - такие валюты идут в конце.
+type CurrencyPosition struct {
+	Value string `json:"v,omitempty"`
+	ID    int64  `json:"id"`
+}
+
+// Position возвращает позицию валюты при сортировке по field
+func (c *Currency) Position(field CurrencySortField) CurrencyPosition {
+	position := CurrencyPosition{ID: c.ID}
+	switch field {
+	case SortBySymbol:
+		position.Value = c.Symbol
+	case SortByCreatedAt:
+		position.Value = c.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case SortByMarketCap:
+		if !c.MarketCap.IsZero() {
+			position.Value = c.MarketCap.String()
+		}
+	}
+	return position
+}
+
+// CurrencyListQuery - запрос страницы списка валют
+type CurrencyListQuery struct {
+	Filter    CurrencyFilter
+	Order     CurrencyOrder
+	PageSize  int
+	PageToken string
+}
+
+type CurrencyPage struct {
+	Currencies []*Currency
+	// NextPageToken пуст на последней странице
+	NextPageToken string
+}
+
+// Fingerprint описывает фильтр и сортировку запроса. Токен страницы привязан к нему,
+// чтобы его нельзя было использовать с другими параметрами.
+func (q CurrencyListQuery) Fingerprint() string {
+	return fmt.Sprintf("%s|%s|%s|%s|%t",
+		strings.ToLower(q.Filter.Tag), strings.ToLower(q.Filter.NamePrefix), q.Filter.Tracking,
+		q.Order.Field, q.Order.Descending)
+}
+
+// ParseCurrencyOrder разбирает order_by в стиле AIP-132: "symbol", "created_at desc", "market_cap desc".
+// Пустая строка означает сортировку по символу.
+func ParseCurrencyOrder(orderBy string) (CurrencyOrder, error) {
+	fields := strings.Fields(strings.ToLower(orderBy))
+	if len(fields) == 0 {
+		return CurrencyOrder{Field: SortBySymbol}, nil
+	}
+	if len(fields) > 2 {
+		return CurrencyOrder{}, ErrInvalidOrderBy
+	}
+
+	order := CurrencyOrder{Field: CurrencySortField(fields[0])}
+	switch order.Field {
+	case SortBySymbol, SortByCreatedAt, SortByMarketCap:
+	default:
+		return CurrencyOrder{}, ErrInvalidOrderBy
+	}
+
+	if len(fields) == 2 {
+		switch fields[1] {
+		case "asc":
+		case "desc":
+			order.Descending = true
+		default:
+			return CurrencyOrder{}, ErrInvalidOrderBy
+		}
+	}
+
+	return order, nil
+}
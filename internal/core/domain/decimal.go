@@ -0,0 +1,300 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal - десятичное число произвольной точности, равное value * 10^-scale.
+// Нулевое значение Decimal соответствует нулю.
+type Decimal struct {
+	value *big.Int
+	scale int32
+}
+
+// Пределы разбираемых чисел: без них строка вроде "1e2147483647" заставила бы
+// построить число из миллиардов цифр
+const (
+	maxDecimalExponent = 64
+	maxDecimalScale    = 36
+)
+
+var (
+	bigTen      = big.NewInt(10)
+	nanosFactor = big.NewInt(1_000_000_000)
+)
+
+// NewDecimal создаёт число value * 10^-scale
+func NewDecimal(value int64, scale int32) Decimal {
+	return Decimal{value: big.NewInt(value), scale: scale}
+}
+
+// NewDecimalFromFloat переводит float64 в Decimal по его кратчайшему десятичному представлению
+func NewDecimalFromFloat(f float64) Decimal {
+	d, err := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return Decimal{}
+	}
+	return d
+}
+
+// ParseDecimal разбирает строку вида "-123.456" или "1.5e-7". Порядок ограничен
+// maxDecimalExponent, итоговое число знаков после запятой - maxDecimalScale.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Decimal{}, fmt.Errorf("%w: empty decimal", ErrInvalidPrice)
+	}
+
+	mantissa, exponent := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil || exp > maxDecimalExponent || exp < -maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidPrice, s)
+		}
+		mantissa, exponent = s[:i], exp
+	}
+
+	intPart, fracPart := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		intPart, fracPart = mantissa[:i], mantissa[i+1:]
+	}
+
+	digits := intPart + fracPart
+	if digits == "" || digits == "-" || digits == "+" {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidPrice, s)
+	}
+
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok || strings.ContainsAny(fracPart, "+-") {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidPrice, s)
+	}
+
+	scale := int64(len(fracPart)) - exponent
+	if scale > maxDecimalScale {
+		return Decimal{}, fmt.Errorf("%w: too many fractional digits in %q", ErrInvalidPrice, s)
+	}
+	if scale < 0 {
+		value.Mul(value, pow10(int32(-scale)))
+		scale = 0
+	}
+
+	return Decimal{value: value, scale: int32(scale)}, nil
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) bigValue() *big.Int {
+	if d.value == nil {
+		return new(big.Int)
+	}
+	return d.value
+}
+
+// rescale приводит число к большему или равному масштабу без потери точности
+func (d Decimal) rescale(scale int32) *big.Int {
+	value := new(big.Int).Set(d.bigValue())
+	if scale > d.scale {
+		value.Mul(value, pow10(scale-d.scale))
+	}
+	return value
+}
+
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := max(a.scale, b.scale)
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+	return Decimal{value: a.Add(a, b), scale: scale}
+}
+
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+	return Decimal{value: a.Sub(a, b), scale: scale}
+}
+
+func (d Decimal) Mul(other Decimal) Decimal {
+	value := new(big.Int).Mul(d.bigValue(), other.bigValue())
+	return Decimal{value: value, scale: d.scale + other.scale}
+}
+
+// Div делит число с округлением до precision знаков после запятой (половина - от нуля).
+// Деление на ноль возвращает ErrInvalidPrice.
+func (d Decimal) Div(other Decimal, precision int32) (Decimal, error) {
+	if other.IsZero() {
+		return Decimal{}, fmt.Errorf("%w: division by zero", ErrInvalidPrice)
+	}
+
+	numerator := new(big.Int).Set(d.bigValue())
+	denominator := new(big.Int).Set(other.bigValue())
+
+	shift := precision - d.scale + other.scale
+	if shift >= 0 {
+		numerator.Mul(numerator, pow10(shift))
+	} else {
+		denominator.Mul(denominator, pow10(-shift))
+	}
+
+	return Decimal{value: quoRound(numerator, denominator), scale: precision}, nil
+}
+
+// quoRound делит с округлением половины от нуля
+func quoRound(numerator, denominator *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	doubled := new(big.Int).Abs(remainder)
+	doubled.Lsh(doubled, 1)
+	if doubled.Cmp(new(big.Int).Abs(denominator)) >= 0 {
+		if numerator.Sign()*denominator.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return quotient
+}
+
+// Round округляет число до scale знаков после запятой (половина - от нуля)
+func (d Decimal) Round(scale int32) Decimal {
+	if scale >= d.scale {
+		return Decimal{value: d.rescale(scale), scale: scale}
+	}
+	return Decimal{value: quoRound(d.bigValue(), pow10(d.scale-scale)), scale: scale}
+}
+
+// Trim убирает незначащие нули в конце дробной части
+func (d Decimal) Trim() Decimal {
+	value, scale := new(big.Int).Set(d.bigValue()), d.scale
+	remainder := new(big.Int)
+	for scale > 0 {
+		quotient, _ := new(big.Int).QuoRem(value, bigTen, remainder)
+		if remainder.Sign() != 0 {
+			break
+		}
+		value, scale = quotient, scale-1
+	}
+	return Decimal{value: value, scale: scale}
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: new(big.Int).Neg(d.bigValue()), scale: d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{value: new(big.Int).Abs(d.bigValue()), scale: d.scale}
+}
+
+// Cmp возвращает -1, 0 или 1, если d меньше, равно или больше other
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, _ := align(d, other)
+	return a.Cmp(b)
+}
+
+func (d Decimal) Sign() int {
+	return d.bigValue().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.bigValue()).String()
+
+	if d.scale > 0 {
+		if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		point := len(digits) - int(d.scale)
+		digits = digits[:point] + "." + digits[point:]
+	} else if d.scale < 0 && digits != "0" {
+		digits += strings.Repeat("0", int(-d.scale))
+	}
+
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Float64 возвращает ближайшее значение float64 (с возможной потерей точности)
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// UnitsNanos возвращает целую часть и дробную часть в миллиардных долях.
+// Дробная часть усекается до 9 знаков, знаки units и nanos совпадают.
+func (d Decimal) UnitsNanos() (int64, int32) {
+	var scaled *big.Int
+	if d.scale <= 9 {
+		scaled = d.rescale(9)
+	} else {
+		scaled = new(big.Int).Quo(d.bigValue(), pow10(d.scale-9))
+	}
+
+	units, nanos := new(big.Int).QuoRem(scaled, nanosFactor, new(big.Int))
+	return units.Int64(), int32(nanos.Int64())
+}
+
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func (d *Decimal) Scan(src interface{}) error {
+	var parsed Decimal
+	var err error
+
+	switch v := src.(type) {
+	case nil:
+		*d = Decimal{}
+		return nil
+	case string:
+		parsed, err = ParseDecimal(v)
+	case []byte:
+		parsed, err = ParseDecimal(string(v))
+	case float64:
+		parsed = NewDecimalFromFloat(v)
+	case int64:
+		parsed = NewDecimal(v, 0)
+	default:
+		return fmt.Errorf("cannot scan %T into Decimal", src)
+	}
+
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON сериализует число строкой, чтобы не терять точность в JSON-клиентах
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	str := strings.Trim(string(data), `"`)
+	if str == "null" {
+		*d = Decimal{}
+		return nil
+	}
+
+	parsed, err := ParseDecimal(str)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
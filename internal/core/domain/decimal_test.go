@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "123.456", want: "123.456"},
+		{in: "-0.5", want: "-0.5"},
+		{in: "+7", want: "7"},
+		{in: "  42  ", want: "42"},
+		{in: "1.5e-7", want: "0.00000015"},
+		{in: "1.5E3", want: "1500"},
+		{in: "2e0", want: "2"},
+		{in: ".25", want: "0.25"},
+		{in: "1e64", want: "1" + strings.Repeat("0", 64)},
+		{in: "1e-35", want: "0." + strings.Repeat("0", 34) + "1"},
+		{in: "0." + strings.Repeat("0", 35) + "1", want: "0." + strings.Repeat("0", 35) + "1"},
+		{in: "", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "1.2.3", wantErr: true},
+		{in: "1.-5", wantErr: true},
+		{in: "1e", wantErr: true},
+		{in: "1e65", wantErr: true},
+		{in: "1e-65", wantErr: true},
+		{in: "1e2147483647", wantErr: true},
+		{in: "1e-2000000000", wantErr: true},
+		{in: "1e-9999999999", wantErr: true},
+		{in: "1e-37", wantErr: true},
+		{in: "0." + strings.Repeat("0", 36) + "1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDecimal(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidPrice) {
+				t.Errorf("ParseDecimal(%q) error = %v, want ErrInvalidPrice", tt.in, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseDecimal(%q) unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParseDecimal(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalDiv(t *testing.T) {
+	tests := []struct {
+		a, b      string
+		precision int32
+		want      string
+	}{
+		{a: "1", b: "3", precision: 4, want: "0.3333"},
+		{a: "2", b: "3", precision: 4, want: "0.6667"},
+		{a: "-2", b: "3", precision: 4, want: "-0.6667"},
+		{a: "2", b: "-3", precision: 4, want: "-0.6667"},
+		{a: "-2", b: "-3", precision: 4, want: "0.6667"},
+		// Половина округляется от нуля
+		{a: "1", b: "8", precision: 2, want: "0.13"},
+		{a: "-1", b: "8", precision: 2, want: "-0.13"},
+		{a: "1", b: "16", precision: 3, want: "0.063"},
+		{a: "10", b: "4", precision: 0, want: "3"},
+		{a: "-10", b: "4", precision: 0, want: "-3"},
+		{a: "9", b: "4", precision: 0, want: "2"},
+		// Масштаб делимого больше требуемой точности
+		{a: "1.23456789", b: "1", precision: 4, want: "1.2346"},
+		{a: "100", b: "0.001", precision: 2, want: "100000.00"},
+		{a: "0", b: "7", precision: 3, want: "0.000"},
+	}
+
+	for _, tt := range tests {
+		a, b := mustDecimal(t, tt.a), mustDecimal(t, tt.b)
+		got, err := a.Div(b, tt.precision)
+		if err != nil {
+			t.Errorf("%s / %s: unexpected error: %v", tt.a, tt.b, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("%s / %s (precision %d) = %s, want %s", tt.a, tt.b, tt.precision, got, tt.want)
+		}
+	}
+
+	if _, err := mustDecimal(t, "1").Div(Decimal{}, 2); !errors.Is(err, ErrInvalidPrice) {
+		t.Errorf("division by zero error = %v, want ErrInvalidPrice", err)
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	tests := []struct {
+		in    string
+		scale int32
+		want  string
+	}{
+		{in: "1.005", scale: 2, want: "1.01"},
+		{in: "-1.005", scale: 2, want: "-1.01"},
+		{in: "1.004", scale: 2, want: "1.00"},
+		{in: "2.5", scale: 0, want: "3"},
+		{in: "-2.5", scale: 0, want: "-3"},
+		{in: "1.5", scale: 3, want: "1.500"},
+	}
+
+	for _, tt := range tests {
+		if got := mustDecimal(t, tt.in).Round(tt.scale); got.String() != tt.want {
+			t.Errorf("Round(%s, %d) = %s, want %s", tt.in, tt.scale, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalUnitsNanos(t *testing.T) {
+	tests := []struct {
+		in        string
+		wantUnits int64
+		wantNanos int32
+	}{
+		{in: "0", wantUnits: 0, wantNanos: 0},
+		{in: "117416", wantUnits: 117416, wantNanos: 0},
+		{in: "1.5", wantUnits: 1, wantNanos: 500_000_000},
+		{in: "-1.5", wantUnits: -1, wantNanos: -500_000_000},
+		{in: "-0.25", wantUnits: 0, wantNanos: -250_000_000},
+		{in: "0.000000001", wantUnits: 0, wantNanos: 1},
+		// Знаки после девятого усекаются, а не округляются
+		{in: "0.0000000019", wantUnits: 0, wantNanos: 1},
+		{in: "-0.0000000019", wantUnits: 0, wantNanos: -1},
+		{in: "123.456789012345", wantUnits: 123, wantNanos: 456_789_012},
+		{in: "1e3", wantUnits: 1000, wantNanos: 0},
+	}
+
+	for _, tt := range tests {
+		units, nanos := mustDecimal(t, tt.in).UnitsNanos()
+		if units != tt.wantUnits || nanos != tt.wantNanos {
+			t.Errorf("UnitsNanos(%s) = (%d, %d), want (%d, %d)", tt.in, units, nanos, tt.wantUnits, tt.wantNanos)
+		}
+	}
+}
+
+func mustDecimal(t *testing.T, s string) Decimal {
+	t.Helper()
+	d, err := ParseDecimal(s)
+	if err != nil {
+		t.Fatalf("ParseDecimal(%q): %v", s, err)
+	}
+	return d
+}
@@ -9,18 +9,111 @@ var (
 	// ErrCurrencyAlreadyExists возвращается при попытке добавить существующую криптовалюту
 	ErrCurrencyAlreadyExists = errors.New("currency already exists")
 
+	// ErrCurrencyRemoved возвращается при попытке добавить удалённую, но ещё не очищенную криптовалюту
+	ErrCurrencyRemoved = errors.New("currency was removed, restore it instead")
+
 	// ErrInvalidCurrencySymbol возвращается при некорректном символе
 	ErrInvalidCurrencySymbol = errors.New("invalid currency symbol")
 
 	// ErrInvalidCurrencyName возвращается при некорректном названии
 	ErrInvalidCurrencyName = errors.New("invalid currency name")
 
+	// ErrInvalidQuote возвращается при некорректной валюте котировки
+	ErrInvalidQuote = errors.New("invalid quote currency")
+
+	// ErrQuoteNotTracked возвращается, если цена валюты в этой котировке не отслеживается
+	ErrQuoteNotTracked = errors.New("quote currency is not tracked for this currency")
+
+	// ErrAmbiguousSymbol возвращается, когда тикеру соответствует несколько монет каталога
+	ErrAmbiguousSymbol = errors.New("ambiguous symbol, coin ID override required")
+
+	// ErrCoinNotInCatalog возвращается, когда идентификатора монеты нет в каталоге провайдера
+	ErrCoinNotInCatalog = errors.New("coin ID not found in catalog")
+
+	// ErrInvalidDecimals возвращается при точности отображения вне диапазона 0..18
+	ErrInvalidDecimals = errors.New("invalid display decimals")
+
+	// ErrInvalidTag возвращается при пустом или слишком длинном теге
+	ErrInvalidTag = errors.New("invalid currency tag")
+
+	// ErrInvalidTrackingState возвращается при неизвестном состоянии отслеживания
+	ErrInvalidTrackingState = errors.New("invalid tracking state")
+
 	// ErrInvalidPrice возвращается при некорректной цене
 	ErrInvalidPrice = errors.New("invalid price")
 
+	// ErrSyntheticPrice возвращается при попытке сохранить синтетическую цену
+	ErrSyntheticPrice = errors.New("synthetic prices are not persisted")
+
+	// ErrPriceNotFound возвращается, когда на запрошенный момент нет цены в пределах допустимой давности
+	ErrPriceNotFound = errors.New("no price found for the requested time")
+
+	// ErrInvalidLookupMode возвращается при неизвестном способе поиска цены на момент времени
+	ErrInvalidLookupMode = errors.New("invalid price lookup mode")
+
+	// ErrBatchTooLarge возвращается, когда пакетный запрос цен пуст или превышает MaxBatchPriceItems
+	ErrBatchTooLarge = errors.New("batch must contain between 1 and 10000 items")
+
+	// ErrInvalidAmount возвращается при отрицательной или некорректной сумме конвертации
+	ErrInvalidAmount = errors.New("invalid amount")
+
+	// ErrAlertRuleNotFound возвращается, когда правило оповещения не найдено
+	ErrAlertRuleNotFound = errors.New("alert rule not found")
+
+	// ErrInvalidAlertCondition возвращается при неизвестном условии правила оповещения
+	ErrInvalidAlertCondition = errors.New("invalid alert condition")
+
+	// ErrInvalidAlertThreshold возвращается при неположительном пороге правила оповещения
+	ErrInvalidAlertThreshold = errors.New("alert threshold must be positive")
+
+	// ErrInvalidAlertWindow возвращается, если окно задано не для percent_change или не задано для него
+	ErrInvalidAlertWindow = errors.New("window of at least 1s is required for percent_change and only allowed for it")
+
+	// ErrInvalidAlertCooldown возвращается при отрицательном интервале между срабатываниями
+	ErrInvalidAlertCooldown = errors.New("invalid alert cooldown")
+
+	// ErrWebhookEndpointNotFound возвращается, когда получатель webhook не найден
+	ErrWebhookEndpointNotFound = errors.New("webhook endpoint not found")
+
+	// ErrInvalidWebhookURL возвращается при адресе получателя не http(s)
+	ErrInvalidWebhookURL = errors.New("invalid webhook URL")
+
+	// ErrInvalidWebhookEvent возвращается при пустом списке событий или неизвестном событии
+	ErrInvalidWebhookEvent = errors.New("invalid webhook event")
+
+	// ErrInvalidDeliveryStatus возвращается при неизвестном статусе доставки в фильтре журнала
+	ErrInvalidDeliveryStatus = errors.New("invalid delivery status")
+
+	// ErrInvalidCandleInterval возвращается при неподдерживаемом интервале свечей
+	ErrInvalidCandleInterval = errors.New("invalid candle interval")
+
+	// ErrCandleIntervalTooFine возвращается, когда период свёрнут в агрегаты крупнее интервала свечей
+	ErrCandleIntervalTooFine = errors.New("candle interval is finer than stored price resolution")
+
+	// ErrInvalidTimeRange возвращается, когда начало периода позже его конца
+	ErrInvalidTimeRange = errors.New("invalid time range")
+
+	// ErrInvalidPageToken возвращается при повреждённом токене страницы или токене от другого запроса
+	ErrInvalidPageToken = errors.New("invalid page token")
+
+	// ErrInvalidOrderBy возвращается при неподдерживаемой сортировке
+	ErrInvalidOrderBy = errors.New("invalid order_by")
+
+	// ErrBackfillJobNotFound возвращается, когда задача загрузки истории не найдена
+	ErrBackfillJobNotFound = errors.New("backfill job not found")
+
 	// ErrDatabaseConnection возвращается при ошибке подключения к БД
 	ErrDatabaseConnection = errors.New("database connection error")
 
 	// ErrExternalAPIUnavailable возвращается при недоступности внешнего API
 	ErrExternalAPIUnavailable = errors.New("external API unavailable")
+
+	// ErrInsufficientSources возвращается, когда для консенсусной цены не хватило согласных источников
+	ErrInsufficientSources = errors.New("not enough agreeing price sources")
+
+	// ErrRateLimited возвращается, когда внешний API ограничил частоту запросов
+	ErrRateLimited = errors.New("external API rate limit exceeded")
+
+	// ErrNoHistoricalPrices возвращается, когда провайдеры знают тикер, но не вернули ни одной цены за период
+	ErrNoHistoricalPrices = errors.New("no historical prices for the requested period")
 )
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// Размер страницы по умолчанию и максимальный для постраничных списков (AIP-158)
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 1000
+)
+
+// PageSize приводит запрошенный размер страницы к допустимому: 0 - размер по умолчанию,
+// слишком большие значения ограничиваются максимумом
+func PageSize(requested int) int {
+	if requested <= 0 {
+		return DefaultPageSize
+	}
+	return min(requested, MaxPageSize)
+}
+
+// EncodePageToken упаковывает курсор в непрозрачный для клиента токен
+func EncodePageToken(cursor any) string {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageToken распаковывает токен, выданный EncodePageToken
+func DecodePageToken(token string, cursor any) error {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ErrInvalidPageToken
+	}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return ErrInvalidPageToken
+	}
+	return nil
+}
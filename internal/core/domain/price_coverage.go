@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// PricePeriod - полуинтервал времени [From, To)
+type PricePeriod struct {
+	From time.Time
+	To   time.Time
+}
+
+// PriceCoverage - цены, уже сохранённые за период: метки времени сырых цен
+// и интервалы, свёрнутые в часовые и дневные агрегаты
+type PriceCoverage struct {
+	Timestamps []time.Time
+	// Compacted - непересекающиеся интервалы агрегатов, отсортированные по началу
+	Compacted []PricePeriod
+}
+
+// IsCompacted сообщает, попадает ли t в свёрнутый интервал. Цены такого интервала уже учтены
+// в агрегате, и новая сырая цена при следующем сжатии была бы посчитана в нём повторно.
+func (c *PriceCoverage) IsCompacted(t time.Time) bool {
+	i := sort.Search(len(c.Compacted), func(i int) bool { return c.Compacted[i].To.After(t) })
+	return i < len(c.Compacted) && !t.Before(c.Compacted[i].From)
+}
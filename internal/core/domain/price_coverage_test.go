@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPriceCoverageIsCompacted(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	coverage := &PriceCoverage{Compacted: []PricePeriod{
+		{From: day, To: day.Add(24 * time.Hour)},
+		{From: day.Add(48 * time.Hour), To: day.Add(49 * time.Hour)},
+	}}
+
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{day.Add(-time.Second), false},
+		{day, true},
+		{day.Add(23 * time.Hour), true},
+		{day.Add(24 * time.Hour), false},
+		{day.Add(48*time.Hour + 30*time.Minute), true},
+		{day.Add(49 * time.Hour), false},
+	}
+	for _, tt := range tests {
+		if got := coverage.IsCompacted(tt.at); got != tt.want {
+			t.Errorf("IsCompacted(%v) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+
+	if (&PriceCoverage{}).IsCompacted(day) {
+		t.Error("empty coverage must not report compacted periods")
+	}
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// PriceHistoryQuery - запрос страницы истории цен. Нулевые границы периода не ограничивают выборку.
+type PriceHistoryQuery struct {
+	Symbol    string
+	Quote     string
+	StartTime time.Time
+	EndTime   time.Time
+	// Ascending - от старых цен к новым, по умолчанию от новых к старым
+	Ascending bool
+	PageSize  int
+	PageToken string
+}
+
+type PriceHistoryPage struct {
+	Prices []*CurrencyPrice
+	// NextPageToken пуст на последней странице
+	NextPageToken string
+}
+
+// PricePosition - позиция цены в упорядоченной истории. Пара (timestamp, source)
+// уникальна для символа и котировки, поэтому подходит как ключ постраничного обхода.
+type PricePosition struct {
+	Timestamp time.Time `json:"t"`
+	Source    string    `json:"s"`
+}
+
+// Fingerprint описывает параметры запроса, к которым привязан токен страницы
+func (q PriceHistoryQuery) Fingerprint() string {
+	return fmt.Sprintf("%s|%s|%d|%d|%t",
+		q.Symbol, q.Quote, q.StartTime.UnixNano(), q.EndTime.UnixNano(), q.Ascending)
+}
@@ -0,0 +1,110 @@
+package domain
+
+import "time"
+
+// SourceInterpolated - цена, линейно интерполированная между двумя соседними ценами
+const SourceInterpolated = "interpolated"
+
+// LookupMode - способ выбора цены на момент времени
+type LookupMode string
+
+const (
+	// LookupPrevious - последняя цена не позже запрошенного момента
+	LookupPrevious LookupMode = "previous"
+	// LookupNext - первая цена не раньше запрошенного момента
+	LookupNext LookupMode = "next"
+	// LookupNearest - ближайшая по времени цена с любой стороны
+	LookupNearest LookupMode = "nearest"
+	// LookupInterpolate - линейная интерполяция между предыдущей и следующей ценами
+	LookupInterpolate LookupMode = "interpolate"
+)
+
+// PriceLookup - параметры поиска цены на момент времени
+type PriceLookup struct {
+	Mode LookupMode
+	// MaxStaleness - наибольшее допустимое расстояние до использованной цены, 0 - без ограничений
+	MaxStaleness time.Duration
+}
+
+// PriceLookupResult - найденная цена и её удалённость от запрошенного момента
+type PriceLookupResult struct {
+	Price         *CurrencyPrice
+	RequestedTime time.Time
+	// Distance - расстояние от запрошенного момента до использованной цены,
+	// для интерполяции - до дальней из двух соседних цен
+	Distance time.Duration
+}
+
+func (l PriceLookup) Validate() error {
+	switch l.Mode {
+	case "", LookupPrevious, LookupNext, LookupNearest, LookupInterpolate:
+		return nil
+	default:
+		return ErrInvalidLookupMode
+	}
+}
+
+// Resolve выбирает цену на момент at по соседним ценам: before - последняя не позже at,
+// after - первая не раньше at (любая может быть nil). Возвращает ErrPriceNotFound,
+// если подходящей цены нет или она дальше MaxStaleness.
+func (l PriceLookup) Resolve(before, after *CurrencyPrice, at time.Time) (*PriceLookupResult, error) {
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+
+	var price *CurrencyPrice
+	var distance time.Duration
+
+	switch l.Mode {
+	case LookupPrevious, "":
+		price = before
+	case LookupNext:
+		price = after
+	case LookupNearest:
+		price = before
+		if after != nil && (before == nil || after.Timestamp.Sub(at) < at.Sub(before.Timestamp)) {
+			price = after
+		}
+	case LookupInterpolate:
+		if before == nil || after == nil {
+			return nil, ErrPriceNotFound
+		}
+		price = interpolate(before, after, at)
+		distance = max(at.Sub(before.Timestamp), after.Timestamp.Sub(at))
+	}
+
+	if price == nil {
+		return nil, ErrPriceNotFound
+	}
+	if l.Mode != LookupInterpolate {
+		distance = price.Timestamp.Sub(at).Abs()
+	}
+	if l.MaxStaleness > 0 && distance > l.MaxStaleness {
+		return nil, ErrPriceNotFound
+	}
+
+	return &PriceLookupResult{Price: price, RequestedTime: at, Distance: distance}, nil
+}
+
+// interpolate линейно интерполирует цену на момент at с точностью исходных цен
+func interpolate(before, after *CurrencyPrice, at time.Time) *CurrencyPrice {
+	if !after.Timestamp.After(before.Timestamp) {
+		return before
+	}
+
+	elapsed := NewDecimal(at.Sub(before.Timestamp).Microseconds(), 0)
+	span := NewDecimal(after.Timestamp.Sub(before.Timestamp).Microseconds(), 0)
+	delta, err := after.Price.Sub(before.Price).Mul(elapsed).Div(span, max(before.Price.scale, after.Price.scale))
+	if err != nil {
+		return before
+	}
+
+	return &CurrencyPrice{
+		Symbol:      before.Symbol,
+		Quote:       before.Quote,
+		Price:       before.Price.Add(delta),
+		Timestamp:   at,
+		Source:      SourceInterpolated,
+		IsSynthetic: true,
+	}
+}
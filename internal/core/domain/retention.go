@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// RetentionPolicy - сроки хранения цен. Нулевой срок означает хранение без ограничений.
+type RetentionPolicy struct {
+	Default   time.Duration
+	PerSymbol map[string]time.Duration
+}
+
+// For возвращает срок хранения цен валюты
+func (p RetentionPolicy) For(symbol string) time.Duration {
+	if retention, ok := p.PerSymbol[strings.ToUpper(symbol)]; ok {
+		return retention
+	}
+	return p.Default
+}
+
+// Longest возвращает наибольший срок хранения среди всех валют.
+// false означает, что хотя бы одна валюта хранится без ограничений.
+func (p RetentionPolicy) Longest() (time.Duration, bool) {
+	if p.Default <= 0 {
+		return 0, false
+	}
+
+	longest := p.Default
+	for _, retention := range p.PerSymbol {
+		if retention <= 0 {
+			return 0, false
+		}
+		longest = max(longest, retention)
+	}
+	return longest, true
+}
+
+// PricePartition - помесячная секция таблицы цен, покрывающая период [From, To)
+type PricePartition struct {
+	Name string
+	From time.Time
+	To   time.Time
+}
@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// WebhookEvent - тип события, на которое подписывается получатель
+type WebhookEvent string
+
+const (
+	EventPriceUpdated    WebhookEvent = "price.updated"
+	EventCurrencyAdded   WebhookEvent = "currency.added"
+	EventCurrencyRemoved WebhookEvent = "currency.removed"
+)
+
+// Статусы доставки события получателю
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookEndpoint - адрес получателя событий. Secret используется для подписи каждой доставки.
+type WebhookEndpoint struct {
+	ID          int64          `json:"id"`
+	URL         string         `json:"url"`
+	Events      []WebhookEvent `json:"events"`
+	Secret      string         `json:"-"`
+	Description string         `json:"description"`
+	Enabled     bool           `json:"enabled"`
+	// ConsecutiveFailures - неудачные попытки доставки подряд; при достижении порога получатель отключается
+	ConsecutiveFailures int `json:"consecutive_failures"`
+	// DisabledAt - время автоматического отключения из-за ошибок доставки
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// WebhookEndpointUpdate - частичное изменение получателя, nil-поля остаются без изменений
+type WebhookEndpointUpdate struct {
+	URL         *string
+	Events      []WebhookEvent
+	Description *string
+	Enabled     *bool
+}
+
+// Apply переносит заданные поля изменения в получателя. Повторное включение
+// сбрасывает счётчик ошибок и отметку об автоматическом отключении.
+func (u WebhookEndpointUpdate) Apply(e *WebhookEndpoint) {
+	if u.URL != nil {
+		e.URL = *u.URL
+	}
+	if u.Events != nil {
+		e.Events = u.Events
+	}
+	if u.Description != nil {
+		e.Description = *u.Description
+	}
+	if u.Enabled != nil {
+		if *u.Enabled && !e.Enabled {
+			e.ConsecutiveFailures = 0
+			e.DisabledAt = nil
+		}
+		e.Enabled = *u.Enabled
+	}
+}
+
+// WebhookDelivery - доставка одного события одному получателю и её попытки
+type WebhookDelivery struct {
+	ID         int64        `json:"id"`
+	EndpointID int64        `json:"endpoint_id"`
+	EventID    string       `json:"event_id"`
+	Event      WebhookEvent `json:"event"`
+	// Payload - тело запроса, одинаковое для всех попыток
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	LastStatusCode int             `json:"last_status_code"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// WebhookPayload - конверт события, который получает webhook
+type WebhookPayload struct {
+	ID        string       `json:"id"`
+	Type      WebhookEvent `json:"type"`
+	CreatedAt time.Time    `json:"created_at"`
+	Data      any          `json:"data"`
+}
+
+// WebhookDeliveryQuery - запрос страницы журнала доставок получателя, от новых к старым
+type WebhookDeliveryQuery struct {
+	EndpointID int64
+	// Status - необязательный фильтр по статусу
+	Status    string
+	PageSize  int
+	PageToken string
+}
+
+type WebhookDeliveryPage struct {
+	Deliveries []*WebhookDelivery
+	// NextPageToken пуст на последней странице
+	NextPageToken string
+}
+
+// Fingerprint описывает фильтры запроса, к которым привязан токен страницы
+func (q WebhookDeliveryQuery) Fingerprint() string {
+	return fmt.Sprintf("%d|%s", q.EndpointID, q.Status)
+}
+
+func (e *WebhookEndpoint) Validate() error {
+	parsed, err := url.Parse(e.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+
+	if len(e.Events) == 0 {
+		return ErrInvalidWebhookEvent
+	}
+	for _, event := range e.Events {
+		if !event.IsValid() {
+			return ErrInvalidWebhookEvent
+		}
+	}
+
+	return nil
+}
+
+// Subscribes сообщает, получает ли адрес события этого типа
+func (e *WebhookEndpoint) Subscribes(event WebhookEvent) bool {
+	for _, subscribed := range e.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (e WebhookEvent) IsValid() bool {
+	switch e {
+	case EventPriceUpdated, EventCurrencyAdded, EventCurrencyRemoved:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsFinished сообщает, завершена ли доставка (успешно или исчерпав попытки)
+func (d *WebhookDelivery) IsFinished() bool {
+	return d.Status == DeliverySucceeded || d.Status == DeliveryFailed
+}
+
+// WebhookRetryDelay возвращает паузу перед следующей попыткой после attempts неудачных:
+// base, 2*base, 4*base, ... но не больше maxDelay
+func WebhookRetryDelay(attempts int, base, maxDelay time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// SignWebhook возвращает HMAC-SHA256 строки "<timestamp>.<body>" в hex. Метка времени входит
+// в подпись, чтобы получатель мог отбрасывать повторно отправленные старые запросы.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWebhookRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts  int
+		base, max time.Duration
+		want      time.Duration
+	}{
+		{1, time.Second, time.Minute, time.Second},
+		{2, time.Second, time.Minute, 2 * time.Second},
+		{3, time.Second, time.Minute, 4 * time.Second},
+		{6, time.Second, time.Minute, 32 * time.Second},
+		{7, time.Second, time.Minute, time.Minute},
+		{1000, time.Second, time.Minute, time.Minute},
+		{1, 2 * time.Minute, time.Minute, time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := WebhookRetryDelay(tt.attempts, tt.base, tt.max); got != tt.want {
+			t.Errorf("WebhookRetryDelay(%d, %v, %v) = %v, want %v", tt.attempts, tt.base, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	body := []byte(`{"id":"evt_1"}`)
+	// HMAC-SHA256("whsec_test", "1700000000.{"id":"evt_1"}")
+	const want = "c89214b5b5da833daed6f0b8c5bb6bd58cea9022bd80ccc78230f3942d632925"
+
+	if got := SignWebhook("whsec_test", 1700000000, body); got != want {
+		t.Errorf("SignWebhook = %s, want %s", got, want)
+	}
+	// Подпись зависит от секрета и метки времени
+	if SignWebhook("whsec_other", 1700000000, body) == want || SignWebhook("whsec_test", 1700000001, body) == want {
+		t.Error("signature must change with secret and timestamp")
+	}
+}
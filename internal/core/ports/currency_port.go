@@ -9,9 +9,69 @@ import (
 
 
 type CurrencyService interface {
-	AddCurrency(ctx context.Context, symbol, name string) (*domain.Currency, error)
+	AddCurrency(ctx context.Context, currency *domain.Currency) (*domain.Currency, error)
 	RemoveCurrency(ctx context.Context, symbol string) error
-	GetCurrencyPrice(ctx context.Context, symbol string, timestamp time.Time) (*domain.CurrencyPrice, error)
-	ListCurrencies(ctx context.Context) ([]*domain.Currency, error)
-	GetPriceHistory(ctx context.Context, symbol string, startTime, endTime time.Time, limit int) ([]*domain.CurrencyPrice, error)
+	UpdateCurrency(ctx context.Context, symbol string, update domain.CurrencyUpdate) (*domain.Currency, error)
+	RestoreCurrency(ctx context.Context, symbol string) (*domain.Currency, error)
+	ListRemovedCurrencies(ctx context.Context) ([]*domain.Currency, error)
+	// GetCurrencyPrice возвращает последнюю цену, а при ненулевом timestamp - цену на этот момент по правилам lookup
+	GetCurrencyPrice(ctx context.Context, symbol, quote string, timestamp time.Time, lookup domain.PriceLookup) (*domain.PriceLookupResult, error)
+	// BatchGetPrices ищет цены для многих символов и моментов; ошибки отдельных элементов
+	// возвращаются в их результатах, ошибка метода означает отказ всего пакета
+	BatchGetPrices(ctx context.Context, query domain.BatchPriceQuery) ([]*domain.BatchPriceResult, error)
+	// Convert пересчитывает сумму между валютами через их цены в domain.PivotQuote
+	Convert(ctx context.Context, query domain.ConversionQuery) (*domain.Conversion, error)
+	ListCurrencies(ctx context.Context, query domain.CurrencyListQuery) (*domain.CurrencyPage, error)
+	GetPriceHistory(ctx context.Context, query domain.PriceHistoryQuery) (*domain.PriceHistoryPage, error)
+	GetCandles(ctx context.Context, symbol, quote, interval string, startTime, endTime time.Time) ([]*domain.Candle, error)
+	StreamPrices(ctx context.Context, symbols []string) (<-chan *domain.CurrencyPrice, func(), error)
+}
+
+// BackfillService загружает историю цен у внешних провайдеров в фоне
+type BackfillService interface {
+	StartBackfill(ctx context.Context, symbol, quote string, startTime, endTime time.Time) (*domain.BackfillJob, error)
+	GetBackfillJob(ctx context.Context, id int64) (*domain.BackfillJob, error)
+	ResumeBackfills(ctx context.Context) error
+	Stop()
+}
+
+// AlertService управляет правилами оповещений и проверяет их на каждой новой сохранённой цене
+type AlertService interface {
+	CreateRule(ctx context.Context, rule *domain.AlertRule) (*domain.AlertRule, error)
+	GetRule(ctx context.Context, id int64) (*domain.AlertRule, error)
+	ListRules(ctx context.Context, symbol string) ([]*domain.AlertRule, error)
+	UpdateRule(ctx context.Context, id int64, update domain.AlertRuleUpdate) (*domain.AlertRule, error)
+	DeleteRule(ctx context.Context, id int64) error
+	ListTriggers(ctx context.Context, query domain.AlertTriggerQuery) (*domain.AlertTriggerPage, error)
+	// Start подписывается на сохраняемые цены и запускает проверку правил
+	Start()
+	Stop()
+}
+
+// EventPublisher рассылает события сервиса внешним подписчикам
+type EventPublisher interface {
+	Publish(ctx context.Context, event domain.WebhookEvent, data any) error
+}
+
+// WebhookService управляет получателями webhook и доставляет им события с повторами
+type WebhookService interface {
+	EventPublisher
+	// CreateEndpoint возвращает получателя вместе с секретом подписи; позже секрет не отдаётся
+	CreateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) (*domain.WebhookEndpoint, error)
+	GetEndpoint(ctx context.Context, id int64) (*domain.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, id int64, update domain.WebhookEndpointUpdate) (*domain.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, query domain.WebhookDeliveryQuery) (*domain.WebhookDeliveryPage, error)
+	// Start подписывается на сохраняемые цены и запускает доставку
+	Start()
+	Stop()
+}
+
+type CoinCatalogService interface {
+	CoinResolver
+	RefreshCatalog(ctx context.Context) error
+	SetOverride(ctx context.Context, symbol, coinID string) (*domain.CoinOverride, error)
+	DeleteOverride(ctx context.Context, symbol string) error
+	ListOverrides(ctx context.Context) ([]*domain.CoinOverride, error)
 }
@@ -13,16 +13,145 @@ type CurrencyRepository interface {
 	List(ctx context.Context) ([]*domain.Currency, error)
 	Delete(ctx context.Context, symbol string) error
 	Update(ctx context.Context, currency *domain.Currency) error
+	// ListPage возвращает до limit валют, идущих после позиции after (nil - с начала), с учётом фильтра и сортировки
+	ListPage(ctx context.Context, filter domain.CurrencyFilter, order domain.CurrencyOrder, after *domain.CurrencyPosition, limit int) ([]*domain.Currency, error)
+	// UpdateMarketCaps сохраняет последнюю капитализацию валют в USD
+	UpdateMarketCaps(ctx context.Context, marketCaps map[string]domain.Decimal) error
+	GetBySymbols(ctx context.Context, symbols []string) ([]*domain.Currency, error)
+	GetRemovedBySymbol(ctx context.Context, symbol string) (*domain.Currency, error)
+	ListRemoved(ctx context.Context, removedBefore time.Time) ([]*domain.Currency, error)
+	Restore(ctx context.Context, symbol string) error
+	// Purge окончательно удаляет удалённую валюту, а при withPrices - и всю её историю цен
+	Purge(ctx context.Context, symbol string, withPrices bool) error
 }
 
 type PriceRepository interface {
 	SavePrice(ctx context.Context, price *domain.CurrencyPrice) error
-	GetLatestPrice(ctx context.Context, symbol string) (*domain.CurrencyPrice, error)
-	GetPriceByTimestamp(ctx context.Context, symbol string, timestamp time.Time) (*domain.CurrencyPrice, error)
-	GetPriceHistory(ctx context.Context, symbol string, startTime, endTime time.Time, limit int) ([]*domain.CurrencyPrice, error)
+	SavePrices(ctx context.Context, prices []*domain.CurrencyPrice) error
+	GetLatestPrice(ctx context.Context, symbol, quote string) (*domain.CurrencyPrice, error)
+	// GetPricesAround возвращает последнюю цену не позже timestamp и первую не раньше него, любая может быть nil
+	GetPricesAround(ctx context.Context, symbol, quote string, timestamp time.Time) (before, after *domain.CurrencyPrice, err error)
+	// GetLatestPrices возвращает последние цены символов; символы без цен в результат не попадают
+	GetLatestPrices(ctx context.Context, symbols []string, quote string) (map[string]*domain.CurrencyPrice, error)
+	// GetPricesAroundBatch - GetPricesAround для многих точек сразу, результаты выровнены по индексам points
+	GetPricesAroundBatch(ctx context.Context, points []domain.PricePoint, quote string) (before, after []*domain.CurrencyPrice, err error)
+	// GetPriceHistory возвращает до limit цен за период, начиная после позиции after (nil - с начала)
+	GetPriceHistory(ctx context.Context, symbol, quote string, startTime, endTime time.Time, ascending bool, after *domain.PricePosition, limit int) ([]*domain.CurrencyPrice, error)
+	// GetCandles строит свечи по сырым ценам и агрегатам; интервал должен быть кратен шагу агрегатов за период
+	GetCandles(ctx context.Context, symbol, quote string, interval time.Duration, startTime, endTime time.Time) ([]*domain.Candle, error)
+	// GetPriceCoverage возвращает метки сырых цен и свёрнутые в агрегаты интервалы за период
+	GetPriceCoverage(ctx context.Context, symbol, quote string, startTime, endTime time.Time) (*domain.PriceCoverage, error)
+}
+
+// PriceMaintenanceRepository управляет секциями таблицы цен и удалением устаревших цен
+type PriceMaintenanceRepository interface {
+	// EnsurePricePartitions создаёт недостающие помесячные секции, перенося в них цены из секции по умолчанию
+	EnsurePricePartitions(ctx context.Context, from, to time.Time) error
+	// PartitionDefaultPrices создаёт секции для всех цен, оставшихся в секции по умолчанию
+	PartitionDefaultPrices(ctx context.Context) error
+	ListPricePartitions(ctx context.Context) ([]domain.PricePartition, error)
+	DropPricePartition(ctx context.Context, partition domain.PricePartition) error
+	DeleteSymbolPricesBefore(ctx context.Context, symbol string, before time.Time) (int64, error)
+	DeletePricesBeforeExcept(ctx context.Context, before time.Time, exceptSymbols []string) (int64, error)
+	// CompactToHourly сворачивает сырые цены старше before в часовые агрегаты
+	CompactToHourly(ctx context.Context, before time.Time) (int64, error)
+	// CompactToDaily сворачивает часовые агрегаты (или сырые цены, если fromRaw) старше before в дневные
+	CompactToDaily(ctx context.Context, before time.Time, fromRaw bool) (int64, error)
+}
+
+type BackfillRepository interface {
+	CreateJob(ctx context.Context, job *domain.BackfillJob) error
+	GetJob(ctx context.Context, id int64) (*domain.BackfillJob, error)
+	UpdateJob(ctx context.Context, job *domain.BackfillJob) error
+	ListUnfinishedJobs(ctx context.Context) ([]*domain.BackfillJob, error)
+}
+
+// AlertRepository хранит правила оповещений и историю их срабатываний
+type AlertRepository interface {
+	CreateRule(ctx context.Context, rule *domain.AlertRule) error
+	GetRule(ctx context.Context, id int64) (*domain.AlertRule, error)
+	// ListRules возвращает правила валюты, а при пустом symbol - все правила
+	ListRules(ctx context.Context, symbol string) ([]*domain.AlertRule, error)
+	// ListEnabledRules возвращает включённые правила пары символ-котировка
+	ListEnabledRules(ctx context.Context, symbol, quote string) ([]*domain.AlertRule, error)
+	UpdateRule(ctx context.Context, rule *domain.AlertRule) error
+	DeleteRule(ctx context.Context, id int64) error
+	// RecordTrigger сохраняет срабатывание, только если предыдущее срабатывание правила было
+	// не позже notAfter. false означает, что правило ещё не вышло из периода охлаждения.
+	RecordTrigger(ctx context.Context, trigger *domain.AlertTrigger, notAfter time.Time) (bool, error)
+	// RearmRule снова взводит правило уровня после возврата цены за порог
+	RearmRule(ctx context.Context, id int64) error
+	// ListTriggers возвращает до limit срабатываний от новых к старым с id меньше beforeID (0 - с самого нового)
+	ListTriggers(ctx context.Context, ruleID int64, symbol string, beforeID int64, limit int) ([]*domain.AlertTrigger, error)
+}
+
+// AlertNotifier доставляет сработавшие оповещения во внешний канал
+type AlertNotifier interface {
+	Notify(ctx context.Context, rule *domain.AlertRule, trigger *domain.AlertTrigger) error
+}
+
+// WebhookRepository хранит получателей webhook и журнал доставок
+type WebhookRepository interface {
+	CreateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error
+	GetEndpoint(ctx context.Context, id int64) (*domain.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error)
+	// ListEndpointsForEvent возвращает включённых получателей, подписанных на событие
+	ListEndpointsForEvent(ctx context.Context, event domain.WebhookEvent) ([]*domain.WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error
+	DeleteEndpoint(ctx context.Context, id int64) error
+	// RecordEndpointResult сбрасывает счётчик ошибок получателя после успешной доставки или
+	// увеличивает его после неудачной. true означает, что получатель только что отключён,
+	// достигнув disableAfter ошибок подряд.
+	RecordEndpointResult(ctx context.Context, id int64, success bool, disableAfter int) (bool, error)
+
+	CreateDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error
+	// ClaimDueDeliveries забирает до limit ожидающих доставок включённых получателей, время попытки
+	// которых наступило, и откладывает их на lease, чтобы их не взял другой обработчик
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	// ListDeliveries возвращает до limit доставок получателя от новых к старым с id меньше beforeID (0 - с самой новой)
+	ListDeliveries(ctx context.Context, endpointID int64, status string, beforeID int64, limit int) ([]*domain.WebhookDelivery, error)
+	// DeleteFinishedDeliveries удаляет завершённые доставки, последний раз обновлённые раньше before
+	DeleteFinishedDeliveries(ctx context.Context, before time.Time) (int64, error)
+}
+
+// WebhookSender отправляет доставку получателю и возвращает HTTP-статус ответа
+type WebhookSender interface {
+	Send(ctx context.Context, endpoint *domain.WebhookEndpoint, delivery *domain.WebhookDelivery) (int, error)
+}
+
+// PriceSubscriber позволяет получать цены в момент их сохранения.
+// Пустой список символов означает подписку на все валюты.
+type PriceSubscriber interface {
+	Subscribe(symbols []string) (<-chan *domain.CurrencyPrice, func())
+}
+
+type CoinCatalogRepository interface {
+	ReplaceCatalog(ctx context.Context, coins []domain.Coin) error
+	FindBySymbol(ctx context.Context, symbol string) ([]domain.Coin, error)
+	CoinExists(ctx context.Context, coinID string) (bool, error)
+	CatalogSize(ctx context.Context) (int64, error)
+	GetOverride(ctx context.Context, symbol string) (*domain.CoinOverride, error)
+	ListOverrides(ctx context.Context) ([]*domain.CoinOverride, error)
+	SaveOverride(ctx context.Context, override *domain.CoinOverride) error
+	CreateOverrideIfMissing(ctx context.Context, override *domain.CoinOverride) error
+	DeleteOverride(ctx context.Context, symbol string) error
+	// OverridesSeeded сообщает, добавлялись ли уже привязки по умолчанию
+	OverridesSeeded(ctx context.Context) (bool, error)
+	MarkOverridesSeeded(ctx context.Context) error
+}
+
+// CoinListProvider загружает полный список монет внешнего провайдера
+type CoinListProvider interface {
+	ListCoins(ctx context.Context) ([]domain.Coin, error)
+}
+
+// CoinResolver переводит тикер в идентификатор монеты провайдера
+type CoinResolver interface {
+	ResolveCoinID(ctx context.Context, symbol string) (string, error)
 }
 
 type ExternalPriceProvider interface {
-	GetCurrentPrice(ctx context.Context, symbol string) (*domain.CurrencyPrice, error)
-	GetHistoricalPrices(ctx context.Context, symbol string, startTime, endTime time.Time) ([]*domain.CurrencyPrice, error)
+	GetCurrentPrice(ctx context.Context, symbol, quote string) (*domain.CurrencyPrice, error)
+	GetHistoricalPrices(ctx context.Context, symbol, quote string, startTime, endTime time.Time) ([]*domain.CurrencyPrice, error)
 }
@@ -0,0 +1,332 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// AlertOptions - параметры проверки правил оповещений
+type AlertOptions struct {
+	// DefaultCooldown - интервал между срабатываниями правил, у которых он не задан
+	DefaultCooldown time.Duration
+	// MaxPriceAge - более старые цены (например, история от провайдера) правила не проверяют, 0 - без ограничения
+	MaxPriceAge time.Duration
+}
+
+type alertTriggerCursor struct {
+	BeforeID int64  `json:"b"`
+	Query    string `json:"q"`
+}
+
+type alertService struct {
+	alertRepo       ports.AlertRepository
+	currencyRepo    ports.CurrencyRepository
+	priceRepo       ports.PriceRepository
+	priceSubscriber ports.PriceSubscriber
+	notifier        ports.AlertNotifier
+	opts            AlertOptions
+
+	ctx         context.Context
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+	unsubscribe func()
+
+	// pending - последняя ещё не проверенная цена каждой пары символ-котировка.
+	// Более ранние цены пары вытесняются: для правил важна только свежая цена.
+	mu      sync.Mutex
+	pending map[string]*domain.CurrencyPrice
+	wake    chan struct{}
+}
+
+func NewAlertService(
+	alertRepo ports.AlertRepository,
+	currencyRepo ports.CurrencyRepository,
+	priceRepo ports.PriceRepository,
+	priceSubscriber ports.PriceSubscriber,
+	notifier ports.AlertNotifier,
+	opts AlertOptions,
+) ports.AlertService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &alertService{
+		alertRepo:       alertRepo,
+		currencyRepo:    currencyRepo,
+		priceRepo:       priceRepo,
+		priceSubscriber: priceSubscriber,
+		notifier:        notifier,
+		opts:            opts,
+		ctx:             ctx,
+		cancel:          cancel,
+		pending:         make(map[string]*domain.CurrencyPrice),
+		wake:            make(chan struct{}, 1),
+	}
+}
+
+func (s *alertService) CreateRule(ctx context.Context, rule *domain.AlertRule) (*domain.AlertRule, error) {
+	rule = &domain.AlertRule{
+		Symbol:      strings.ToUpper(rule.Symbol),
+		Quote:       domain.NormalizeQuote(rule.Quote),
+		Condition:   rule.Condition,
+		Threshold:   rule.Threshold,
+		Window:      rule.Window,
+		Cooldown:    rule.Cooldown,
+		Enabled:     rule.Enabled,
+		Description: rule.Description,
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	currency, err := s.currencyRepo.GetBySymbol(ctx, rule.Symbol)
+	if err != nil {
+		return nil, domain.ErrCurrencyNotFound
+	}
+	if !currency.TracksQuote(rule.Quote) {
+		return nil, domain.ErrQuoteNotTracked
+	}
+
+	latest, err := s.latestPrice(ctx, rule.Symbol, rule.Quote)
+	if err != nil {
+		return nil, err
+	}
+	rule.Arm(latest)
+
+	if err := s.alertRepo.CreateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔔 Добавлено правило оповещения #%d: %s/%s %s %s", rule.ID, rule.Symbol, rule.Quote, rule.Condition, rule.Threshold)
+	return rule, nil
+}
+
+func (s *alertService) GetRule(ctx context.Context, id int64) (*domain.AlertRule, error) {
+	return s.alertRepo.GetRule(ctx, id)
+}
+
+func (s *alertService) ListRules(ctx context.Context, symbol string) ([]*domain.AlertRule, error) {
+	return s.alertRepo.ListRules(ctx, strings.ToUpper(symbol))
+}
+
+func (s *alertService) UpdateRule(ctx context.Context, id int64, update domain.AlertRuleUpdate) (*domain.AlertRule, error) {
+	rule, err := s.alertRepo.GetRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	update.Apply(rule)
+	// Окно имеет смысл только для percent_change: при смене условия на уровень оно сбрасывается
+	if rule.Condition != domain.AlertPercentChange && update.Window == nil {
+		rule.Window = 0
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	if update.Rearms() {
+		latest, err := s.latestPrice(ctx, rule.Symbol, rule.Quote)
+		if err != nil {
+			return nil, err
+		}
+		rule.Arm(latest)
+	}
+
+	if err := s.alertRepo.UpdateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *alertService) DeleteRule(ctx context.Context, id int64) error {
+	return s.alertRepo.DeleteRule(ctx, id)
+}
+
+func (s *alertService) ListTriggers(ctx context.Context, query domain.AlertTriggerQuery) (*domain.AlertTriggerPage, error) {
+	query.Symbol = strings.ToUpper(query.Symbol)
+
+	var beforeID int64
+	if query.PageToken != "" {
+		var cursor alertTriggerCursor
+		if err := domain.DecodePageToken(query.PageToken, &cursor); err != nil {
+			return nil, err
+		}
+		if cursor.Query != query.Fingerprint() {
+			return nil, domain.ErrInvalidPageToken
+		}
+		beforeID = cursor.BeforeID
+	}
+
+	limit := domain.PageSize(query.PageSize)
+	// Лишняя запись показывает, есть ли следующая страница
+	triggers, err := s.alertRepo.ListTriggers(ctx, query.RuleID, query.Symbol, beforeID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.AlertTriggerPage{Triggers: triggers}
+	if len(triggers) > limit {
+		page.Triggers = triggers[:limit]
+		page.NextPageToken = domain.EncodePageToken(alertTriggerCursor{
+			BeforeID: triggers[limit-1].ID,
+			Query:    query.Fingerprint(),
+		})
+	}
+	return page, nil
+}
+
+func (s *alertService) Start() {
+	prices, unsubscribe := s.priceSubscriber.Subscribe(nil)
+	s.unsubscribe = unsubscribe
+
+	s.wg.Add(2)
+	go func() {
+		defer s.wg.Done()
+		s.receive(prices)
+	}()
+	go func() {
+		defer s.wg.Done()
+		s.evaluateLoop()
+	}()
+}
+
+// Stop отписывается от цен и дожидается завершения текущей проверки
+func (s *alertService) Stop() {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+// receive быстро вычитывает цены из подписки, чтобы рассылка не пропускала их,
+// пока идёт проверка правил
+func (s *alertService) receive(prices <-chan *domain.CurrencyPrice) {
+	defer close(s.wake)
+
+	for price := range prices {
+		if price.IsSynthetic || (s.opts.MaxPriceAge > 0 && time.Since(price.Timestamp) > s.opts.MaxPriceAge) {
+			continue
+		}
+
+		key := price.Symbol + "/" + price.Quote
+		s.mu.Lock()
+		if queued, ok := s.pending[key]; !ok || !price.Timestamp.Before(queued.Timestamp) {
+			s.pending[key] = price
+		}
+		s.mu.Unlock()
+
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *alertService) evaluateLoop() {
+	for range s.wake {
+		for {
+			price := s.nextPending()
+			if price == nil || s.ctx.Err() != nil {
+				break
+			}
+			s.evaluate(s.ctx, price)
+		}
+	}
+}
+
+func (s *alertService) nextPending() *domain.CurrencyPrice {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, price := range s.pending {
+		delete(s.pending, key)
+		return price
+	}
+	return nil
+}
+
+// evaluate проверяет все включённые правила пары по новой цене
+func (s *alertService) evaluate(ctx context.Context, price *domain.CurrencyPrice) {
+	rules, err := s.alertRepo.ListEnabledRules(ctx, price.Symbol, price.Quote)
+	if err != nil {
+		log.Printf("⚠️  Оповещения: не удалось получить правила %s/%s: %v", price.Symbol, price.Quote, err)
+		return
+	}
+
+	for _, rule := range rules {
+		if rule.ShouldRearm(price) {
+			if err := s.alertRepo.RearmRule(ctx, rule.ID); err != nil {
+				log.Printf("⚠️  Оповещения: не удалось взвести правило #%d: %v", rule.ID, err)
+			}
+			continue
+		}
+
+		now := time.Now()
+		cooldown := rule.Cooldown
+		if cooldown == 0 {
+			cooldown = s.opts.DefaultCooldown
+		}
+		if rule.CoolingDown(now, cooldown) {
+			continue
+		}
+
+		var reference *domain.CurrencyPrice
+		if rule.Condition == domain.AlertPercentChange {
+			reference, err = s.referencePrice(ctx, rule, price)
+			if err != nil {
+				log.Printf("⚠️  Оповещения: нет цены начала окна для правила #%d: %v", rule.ID, err)
+				continue
+			}
+		}
+
+		trigger := rule.Evaluate(price, reference)
+		if trigger == nil {
+			continue
+		}
+		trigger.TriggeredAt = now
+
+		recorded, err := s.alertRepo.RecordTrigger(ctx, trigger, now.Add(-cooldown))
+		if err != nil {
+			log.Printf("❌ Оповещения: не удалось сохранить срабатывание правила #%d: %v", rule.ID, err)
+			continue
+		}
+		if !recorded {
+			continue
+		}
+
+		if err := s.notifier.Notify(ctx, rule, trigger); err != nil {
+			log.Printf("⚠️  Оповещения: не удалось доставить срабатывание правила #%d: %v", rule.ID, err)
+		}
+	}
+}
+
+// latestPrice возвращает последнюю сохранённую цену пары или nil, если цен ещё нет
+func (s *alertService) latestPrice(ctx context.Context, symbol, quote string) (*domain.CurrencyPrice, error) {
+	price, err := s.priceRepo.GetLatestPrice(ctx, symbol, quote)
+	if errors.Is(err, domain.ErrCurrencyNotFound) {
+		return nil, nil
+	}
+	return price, err
+}
+
+// referencePrice возвращает последнюю цену не позже начала окна правила. Цена старше
+// ещё одного окна не используется: после пропуска в сборе изменение считалось бы за другой период.
+func (s *alertService) referencePrice(ctx context.Context, rule *domain.AlertRule, price *domain.CurrencyPrice) (*domain.CurrencyPrice, error) {
+	windowStart := price.Timestamp.Add(-rule.Window)
+	before, _, err := s.priceRepo.GetPricesAround(ctx, price.Symbol, price.Quote, windowStart)
+	if err != nil {
+		return nil, err
+	}
+	if before == nil || before.Timestamp.Before(windowStart.Add(-rule.Window)) {
+		return nil, nil
+	}
+	return before, nil
+}
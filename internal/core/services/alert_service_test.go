@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// memAlertRepo - AlertRepository в памяти для проверки взведения правил
+type memAlertRepo struct {
+	ports.AlertRepository
+
+	mu    sync.Mutex
+	rules map[int64]*domain.AlertRule
+}
+
+func (r *memAlertRepo) CreateRule(ctx context.Context, rule *domain.AlertRule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rule.ID = int64(len(r.rules) + 1)
+	copied := *rule
+	r.rules[rule.ID] = &copied
+	return nil
+}
+
+func (r *memAlertRepo) ListEnabledRules(ctx context.Context, symbol, quote string) ([]*domain.AlertRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var rules []*domain.AlertRule
+	for _, rule := range r.rules {
+		if rule.Enabled && rule.Symbol == symbol && rule.Quote == quote {
+			copied := *rule
+			rules = append(rules, &copied)
+		}
+	}
+	return rules, nil
+}
+
+func (r *memAlertRepo) RearmRule(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rules[id].Armed = true
+	return nil
+}
+
+func (r *memAlertRepo) RecordTrigger(ctx context.Context, trigger *domain.AlertTrigger, notAfter time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rule := r.rules[trigger.RuleID]
+	rule.Armed = false
+	rule.LastTriggeredAt = &trigger.TriggeredAt
+	return true, nil
+}
+
+type stubCurrencyRepo struct {
+	ports.CurrencyRepository
+	currency *domain.Currency
+}
+
+func (r stubCurrencyRepo) GetBySymbol(ctx context.Context, symbol string) (*domain.Currency, error) {
+	return r.currency, nil
+}
+
+type latestPriceRepo struct {
+	ports.PriceRepository
+	latest *domain.CurrencyPrice
+}
+
+func (r latestPriceRepo) GetLatestPrice(ctx context.Context, symbol, quote string) (*domain.CurrencyPrice, error) {
+	if r.latest == nil {
+		return nil, domain.ErrCurrencyNotFound
+	}
+	return r.latest, nil
+}
+
+type countingNotifier struct {
+	notified int
+}
+
+func (n *countingNotifier) Notify(ctx context.Context, rule *domain.AlertRule, trigger *domain.AlertTrigger) error {
+	n.notified++
+	return nil
+}
+
+func TestAlertRuleCreatedPastThresholdWaitsForCrossing(t *testing.T) {
+	price := func(value int64) *domain.CurrencyPrice {
+		return &domain.CurrencyPrice{Symbol: "BTC", Quote: "USD", Price: domain.NewDecimal(value, 0), Timestamp: time.Now()}
+	}
+
+	alertRepo := &memAlertRepo{rules: make(map[int64]*domain.AlertRule)}
+	notifier := &countingNotifier{}
+	service := NewAlertService(
+		alertRepo,
+		stubCurrencyRepo{currency: &domain.Currency{Symbol: "BTC", Quotes: []string{"USD"}}},
+		latestPriceRepo{latest: price(110)},
+		nil,
+		notifier,
+		AlertOptions{},
+	).(*alertService)
+
+	rule, err := service.CreateRule(context.Background(), &domain.AlertRule{
+		Symbol:    "btc",
+		Quote:     "usd",
+		Condition: domain.AlertAbove,
+		Threshold: domain.NewDecimal(100, 0),
+		Enabled:   true,
+	})
+	if err != nil {
+		t.Fatalf("CreateRule: %v", err)
+	}
+	if rule.Armed {
+		t.Fatal("rule created with the price past the threshold should not be armed")
+	}
+
+	steps := []struct {
+		price        int64
+		wantNotified int
+	}{
+		// Цена остаётся за порогом - пересечения не было
+		{price: 115},
+		// Возврат под порог взводит правило, следующее пересечение срабатывает
+		{price: 95},
+		{price: 105, wantNotified: 1},
+	}
+	for i, step := range steps {
+		service.evaluate(context.Background(), price(step.price))
+		if notifier.notified != step.wantNotified {
+			t.Fatalf("step %d (%d): notified = %d, want %d", i, step.price, notifier.notified, step.wantNotified)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// BackfillOptions - параметры загрузки истории
+type BackfillOptions struct {
+	// ChunkSize - длина периода, запрашиваемого у провайдера за один раз
+	ChunkSize time.Duration
+	// ChunkDelay - пауза между запросами, чтобы не упираться в rate limit
+	ChunkDelay time.Duration
+	// RetryDelay - пауза перед повтором после ошибки или rate limit
+	RetryDelay time.Duration
+	MaxRetries int
+}
+
+type backfillService struct {
+	currencyRepo ports.CurrencyRepository
+	priceRepo    ports.PriceRepository
+	backfillRepo ports.BackfillRepository
+	// maintenanceRepo создаёт секции таблицы цен для загружаемого периода
+	maintenanceRepo ports.PriceMaintenanceRepository
+	priceProvider   ports.ExternalPriceProvider
+	opts            BackfillOptions
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// runMu - задачи выполняются по одной, чтобы не превышать лимиты провайдеров
+	runMu  sync.Mutex
+	mu     sync.Mutex
+	active map[int64]struct{}
+}
+
+func NewBackfillService(
+	currencyRepo ports.CurrencyRepository,
+	priceRepo ports.PriceRepository,
+	backfillRepo ports.BackfillRepository,
+	maintenanceRepo ports.PriceMaintenanceRepository,
+	priceProvider ports.ExternalPriceProvider,
+	opts BackfillOptions,
+) ports.BackfillService {
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = 30 * 24 * time.Hour
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &backfillService{
+		currencyRepo:    currencyRepo,
+		priceRepo:       priceRepo,
+		backfillRepo:    backfillRepo,
+		maintenanceRepo: maintenanceRepo,
+		priceProvider:   priceProvider,
+		opts:            opts,
+		ctx:             ctx,
+		cancel:          cancel,
+		active:          make(map[int64]struct{}),
+	}
+}
+
+func (s *backfillService) StartBackfill(ctx context.Context, symbol, quote string, startTime, endTime time.Time) (*domain.BackfillJob, error) {
+	symbol = strings.ToUpper(symbol)
+	quote = domain.NormalizeQuote(quote)
+
+	currency, err := s.currencyRepo.GetBySymbol(ctx, symbol)
+	if err != nil {
+		return nil, domain.ErrCurrencyNotFound
+	}
+	if !currency.TracksQuote(quote) {
+		return nil, domain.ErrQuoteNotTracked
+	}
+
+	now := time.Now()
+	if endTime.IsZero() || endTime.After(now) {
+		endTime = now
+	}
+	if startTime.IsZero() || !startTime.Before(endTime) {
+		return nil, domain.ErrInvalidTimeRange
+	}
+
+	job := &domain.BackfillJob{
+		Symbol:    symbol,
+		Quote:     quote,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Cursor:    startTime,
+		Status:    domain.BackfillPending,
+	}
+	if err := s.backfillRepo.CreateJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	log.Printf("📥 Задача загрузки истории #%d: %s/%s с %s по %s",
+		job.ID, symbol, quote, startTime.Format(time.RFC3339), endTime.Format(time.RFC3339))
+
+	s.launch(job)
+	return job, nil
+}
+
+func (s *backfillService) GetBackfillJob(ctx context.Context, id int64) (*domain.BackfillJob, error) {
+	return s.backfillRepo.GetJob(ctx, id)
+}
+
+// ResumeBackfills продолжает задачи, прерванные остановкой сервиса
+func (s *backfillService) ResumeBackfills(ctx context.Context) error {
+	jobs, err := s.backfillRepo.ListUnfinishedJobs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		log.Printf("🔁 Возобновляем загрузку истории #%d (%s/%s) с %s",
+			job.ID, job.Symbol, job.Quote, job.Cursor.Format(time.RFC3339))
+		s.launch(job)
+	}
+	return nil
+}
+
+// Stop прерывает загрузку и дожидается сохранения прогресса
+func (s *backfillService) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *backfillService) launch(job *domain.BackfillJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, running := s.active[job.ID]; running {
+		return
+	}
+	s.active[job.ID] = struct{}{}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			delete(s.active, job.ID)
+			s.mu.Unlock()
+		}()
+
+		s.run(job)
+	}()
+}
+
+func (s *backfillService) run(job *domain.BackfillJob) {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	ctx := s.ctx
+	if ctx.Err() != nil {
+		return
+	}
+
+	job.Status = domain.BackfillRunning
+	s.saveJob(job)
+
+	for job.Cursor.Before(job.EndTime) {
+		chunkEnd := job.Cursor.Add(s.opts.ChunkSize)
+		if chunkEnd.After(job.EndTime) {
+			chunkEnd = job.EndTime
+		}
+
+		fetched, inserted, err := s.backfillChunk(ctx, job, job.Cursor, chunkEnd)
+		if err != nil {
+			if ctx.Err() != nil {
+				// Сервис останавливается - задача останется running и продолжится после рестарта
+				return
+			}
+			job.Status = domain.BackfillFailed
+			job.Error = err.Error()
+			s.saveJob(job)
+			log.Printf("❌ Загрузка истории #%d прервана: %v", job.ID, err)
+			return
+		}
+
+		job.FetchedCount += fetched
+		job.InsertedCount += inserted
+		job.Cursor = chunkEnd
+		s.saveJob(job)
+
+		log.Printf("📊 Загрузка истории #%d: %.0f%%, получено %d, сохранено %d",
+			job.ID, job.Progress()*100, job.FetchedCount, job.InsertedCount)
+
+		if job.Cursor.Before(job.EndTime) && !sleepContext(ctx, s.opts.ChunkDelay) {
+			return
+		}
+	}
+
+	job.Status = domain.BackfillCompleted
+	s.saveJob(job)
+	log.Printf("✅ Загрузка истории #%d завершена: сохранено %d новых цен", job.ID, job.InsertedCount)
+}
+
+// backfillChunk загружает одну часть периода и сохраняет цены, которых ещё нет в базе
+func (s *backfillService) backfillChunk(ctx context.Context, job *domain.BackfillJob, from, to time.Time) (int64, int64, error) {
+	prices, err := s.fetchWithRetry(ctx, job, from, to)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	coverage, err := s.priceRepo.GetPriceCoverage(ctx, job.Symbol, job.Quote, from, to)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	seen := make(map[int64]struct{}, len(coverage.Timestamps)+len(prices))
+	for _, ts := range coverage.Timestamps {
+		seen[ts.UnixMicro()] = struct{}{}
+	}
+
+	var fetched int64
+	fresh := make([]*domain.CurrencyPrice, 0, len(prices))
+	for _, price := range prices {
+		if price.IsSynthetic || price.Timestamp.Before(from) || price.Timestamp.After(to) {
+			continue
+		}
+		fetched++
+
+		// Свёрнутый период уже учтён в агрегате: новая сырая цена удвоила бы его count при следующем сжатии
+		key := price.Timestamp.UnixMicro()
+		if _, duplicate := seen[key]; duplicate || coverage.IsCompacted(price.Timestamp) {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		price.Symbol = job.Symbol
+		price.Quote = job.Quote
+		fresh = append(fresh, price)
+	}
+
+	if len(fresh) > 0 {
+		// Без своей секции старая история попала бы в секцию по умолчанию и не удалялась бы по сроку хранения
+		if err := s.maintenanceRepo.EnsurePricePartitions(ctx, from, to); err != nil {
+			return fetched, 0, err
+		}
+		if err := s.priceRepo.SavePrices(ctx, fresh); err != nil {
+			return fetched, 0, err
+		}
+	}
+
+	return fetched, int64(len(fresh)), nil
+}
+
+func (s *backfillService) fetchWithRetry(ctx context.Context, job *domain.BackfillJob, from, to time.Time) ([]*domain.CurrencyPrice, error) {
+	for attempt := 0; ; attempt++ {
+		prices, err := s.priceProvider.GetHistoricalPrices(ctx, job.Symbol, job.Quote, from, to)
+		if err == nil && !hasRealPrices(prices) {
+			// Пустой ответ или только синтетические цены резервного провайдера означают, что реальные
+			// провайдеры сейчас не ответили; засчитать такую часть загруженной значит потерять её
+			err = domain.ErrExternalAPIUnavailable
+		}
+		if err == nil {
+			return prices, nil
+		}
+
+		// Ни один провайдер не знает тикер - часть пропускается. Пустой ответ провайдера, знающего тикер,
+		// (ErrNoHistoricalPrices) повторяется как сбой: иначе временно пустой ответ терял бы часть истории
+		if errors.Is(err, domain.ErrCurrencyNotFound) {
+			return nil, nil
+		}
+
+		if attempt >= s.opts.MaxRetries {
+			return nil, err
+		}
+
+		log.Printf("⏳ Загрузка истории #%d: %v, повтор %d/%d через %v",
+			job.ID, err, attempt+1, s.opts.MaxRetries, s.opts.RetryDelay)
+		if !sleepContext(ctx, s.opts.RetryDelay) {
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func hasRealPrices(prices []*domain.CurrencyPrice) bool {
+	for _, price := range prices {
+		if !price.IsSynthetic {
+			return true
+		}
+	}
+	return false
+}
+
+// saveJob сохраняет прогресс задачи, не прерываясь при остановке сервиса
+func (s *backfillService) saveJob(job *domain.BackfillJob) {
+	if err := s.backfillRepo.UpdateJob(context.Background(), job); err != nil {
+		log.Printf("⚠️  Не удалось сохранить состояние задачи #%d: %v", job.ID, err)
+	}
+}
+
+// sleepContext ждёт d и возвращает false, если контекст отменён раньше
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
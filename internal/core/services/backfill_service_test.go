@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// historyResponse - один ответ провайдера истории
+type historyResponse struct {
+	prices []*domain.CurrencyPrice
+	err    error
+}
+
+// scriptedHistoryProvider отдаёт ответы по порядку, последний повторяется
+type scriptedHistoryProvider struct {
+	ports.ExternalPriceProvider
+	responses []historyResponse
+	calls     int
+}
+
+func (p *scriptedHistoryProvider) GetHistoricalPrices(ctx context.Context, symbol, quote string, startTime, endTime time.Time) ([]*domain.CurrencyPrice, error) {
+	response := p.responses[min(p.calls, len(p.responses)-1)]
+	p.calls++
+	return response.prices, response.err
+}
+
+func TestBackfillFetchRetriesEmptyHistory(t *testing.T) {
+	price := &domain.CurrencyPrice{Symbol: "BTC", Quote: "USD", Price: domain.NewDecimal(100, 0), Timestamp: time.Now()}
+	job := &domain.BackfillJob{ID: 1, Symbol: "BTC", Quote: "USD"}
+
+	tests := []struct {
+		name      string
+		responses []historyResponse
+		wantCalls int
+		wantCount int
+		wantErr   error
+	}{
+		{
+			// Провайдеры знают тикер, но пока не вернули точек - часть не пропускается
+			name: "empty history is retried",
+			responses: []historyResponse{
+				{err: domain.ErrNoHistoricalPrices},
+				{prices: []*domain.CurrencyPrice{}},
+				{prices: []*domain.CurrencyPrice{price}},
+			},
+			wantCalls: 3,
+			wantCount: 1,
+		},
+		{
+			name:      "empty history fails after retries",
+			responses: []historyResponse{{err: domain.ErrNoHistoricalPrices}},
+			wantCalls: 4,
+			wantErr:   domain.ErrNoHistoricalPrices,
+		},
+		{
+			// Тикер не знает ни один провайдер - часть пропускается без повторов
+			name:      "unknown symbol is skipped",
+			responses: []historyResponse{{err: domain.ErrCurrencyNotFound}},
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &scriptedHistoryProvider{responses: tt.responses}
+			service := NewBackfillService(nil, nil, nil, nil, provider, BackfillOptions{MaxRetries: 3}).(*backfillService)
+
+			prices, err := service.fetchWithRetry(context.Background(), job, time.Now().Add(-time.Hour), time.Now())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if len(prices) != tt.wantCount {
+				t.Errorf("got %d prices, want %d", len(prices), tt.wantCount)
+			}
+			if provider.calls != tt.wantCalls {
+				t.Errorf("provider called %d times, want %d", provider.calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// coinCatalogService разрешает тикеры в идентификаторы монет по каталогу провайдера.
+// Ручные привязки имеют приоритет над каталогом, результаты кэшируются в памяти.
+type coinCatalogService struct {
+	catalogRepo  ports.CoinCatalogRepository
+	listProvider ports.CoinListProvider
+
+	mu    sync.RWMutex
+	cache map[string]string
+}
+
+func NewCoinCatalogService(
+	catalogRepo ports.CoinCatalogRepository,
+	listProvider ports.CoinListProvider,
+) ports.CoinCatalogService {
+	return &coinCatalogService{
+		catalogRepo:  catalogRepo,
+		listProvider: listProvider,
+		cache:        make(map[string]string),
+	}
+}
+
+// SeedOverrides один раз добавляет привязки по умолчанию, не трогая уже заданные администратором.
+// Повторно они не добавляются, поэтому удалённая администратором привязка не возвращается после рестарта.
+func SeedOverrides(ctx context.Context, catalogRepo ports.CoinCatalogRepository, defaults map[string]string) {
+	seeded, err := catalogRepo.OverridesSeeded(ctx)
+	if err != nil {
+		log.Printf("⚠️  Не удалось проверить привязки по умолчанию: %v", err)
+		return
+	}
+	if seeded {
+		return
+	}
+
+	failed := false
+	for symbol, coinID := range defaults {
+		override := &domain.CoinOverride{Symbol: strings.ToUpper(symbol), CoinID: coinID}
+		if err := catalogRepo.CreateOverrideIfMissing(ctx, override); err != nil {
+			log.Printf("⚠️  Не удалось добавить привязку %s -> %s: %v", symbol, coinID, err)
+			failed = true
+		}
+	}
+
+	// При ошибке отметка не ставится, и недостающие привязки добавятся при следующем запуске
+	if failed {
+		return
+	}
+	if err := catalogRepo.MarkOverridesSeeded(ctx); err != nil {
+		log.Printf("⚠️  Не удалось отметить добавление привязок по умолчанию: %v", err)
+		return
+	}
+	log.Printf("🔗 Добавлены привязки по умолчанию: %d", len(defaults))
+}
+
+func (s *coinCatalogService) ResolveCoinID(ctx context.Context, symbol string) (string, error) {
+	symbol = strings.ToUpper(symbol)
+
+	s.mu.RLock()
+	coinID, cached := s.cache[symbol]
+	s.mu.RUnlock()
+	if cached {
+		return coinID, nil
+	}
+
+	if override, err := s.catalogRepo.GetOverride(ctx, symbol); err == nil {
+		s.remember(symbol, override.CoinID)
+		return override.CoinID, nil
+	} else if err != domain.ErrCurrencyNotFound {
+		return "", err
+	}
+
+	coins, err := s.catalogRepo.FindBySymbol(ctx, symbol)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(coins) {
+	case 0:
+		return "", domain.ErrCurrencyNotFound
+	case 1:
+		s.remember(symbol, coins[0].ID)
+		return coins[0].ID, nil
+	default:
+		ids := make([]string, len(coins))
+		for i, coin := range coins {
+			ids[i] = coin.ID
+		}
+		log.Printf("⚠️  Тикер %s неоднозначен (%s), задайте привязку", symbol, strings.Join(ids, ", "))
+		return "", domain.ErrAmbiguousSymbol
+	}
+}
+
+func (s *coinCatalogService) RefreshCatalog(ctx context.Context) error {
+	coins, err := s.listProvider.ListCoins(ctx)
+	if err != nil {
+		return err
+	}
+	// Пустой ответ провайдера не должен стирать уже загруженный каталог
+	if len(coins) == 0 {
+		return domain.ErrExternalAPIUnavailable
+	}
+
+	if err := s.catalogRepo.ReplaceCatalog(ctx, coins); err != nil {
+		return err
+	}
+
+	s.resetCache()
+	log.Printf("📚 Каталог монет обновлён: %d записей", len(coins))
+	return nil
+}
+
+func (s *coinCatalogService) SetOverride(ctx context.Context, symbol, coinID string) (*domain.CoinOverride, error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	coinID = strings.TrimSpace(coinID)
+	if symbol == "" {
+		return nil, domain.ErrInvalidCurrencySymbol
+	}
+	if coinID == "" {
+		return nil, domain.ErrCoinNotInCatalog
+	}
+
+	// Пока каталог не загружен, привязку проверить не по чему - принимаем как есть
+	if size, err := s.catalogRepo.CatalogSize(ctx); err != nil {
+		return nil, err
+	} else if size > 0 {
+		exists, err := s.catalogRepo.CoinExists(ctx, coinID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, domain.ErrCoinNotInCatalog
+		}
+	}
+
+	override := &domain.CoinOverride{Symbol: symbol, CoinID: coinID}
+	if err := s.catalogRepo.SaveOverride(ctx, override); err != nil {
+		return nil, err
+	}
+
+	s.forget(symbol)
+	return override, nil
+}
+
+func (s *coinCatalogService) DeleteOverride(ctx context.Context, symbol string) error {
+	symbol = strings.ToUpper(symbol)
+	if err := s.catalogRepo.DeleteOverride(ctx, symbol); err != nil {
+		return err
+	}
+
+	s.forget(symbol)
+	return nil
+}
+
+func (s *coinCatalogService) ListOverrides(ctx context.Context) ([]*domain.CoinOverride, error) {
+	return s.catalogRepo.ListOverrides(ctx)
+}
+
+func (s *coinCatalogService) remember(symbol, coinID string) {
+	s.mu.Lock()
+	s.cache[symbol] = coinID
+	s.mu.Unlock()
+}
+
+func (s *coinCatalogService) forget(symbol string) {
+	s.mu.Lock()
+	delete(s.cache, symbol)
+	s.mu.Unlock()
+}
+
+func (s *coinCatalogService) resetCache() {
+	s.mu.Lock()
+	s.cache = make(map[string]string)
+	s.mu.Unlock()
+}
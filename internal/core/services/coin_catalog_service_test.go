@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// memCatalogRepo - привязки тикеров в памяти
+type memCatalogRepo struct {
+	ports.CoinCatalogRepository
+
+	overrides map[string]string
+	seeded    bool
+}
+
+func (r *memCatalogRepo) CreateOverrideIfMissing(ctx context.Context, override *domain.CoinOverride) error {
+	if _, exists := r.overrides[override.Symbol]; !exists {
+		r.overrides[override.Symbol] = override.CoinID
+	}
+	return nil
+}
+
+func (r *memCatalogRepo) DeleteOverride(ctx context.Context, symbol string) error {
+	if _, exists := r.overrides[symbol]; !exists {
+		return domain.ErrCurrencyNotFound
+	}
+	delete(r.overrides, symbol)
+	return nil
+}
+
+func (r *memCatalogRepo) OverridesSeeded(ctx context.Context) (bool, error) {
+	return r.seeded, nil
+}
+
+func (r *memCatalogRepo) MarkOverridesSeeded(ctx context.Context) error {
+	r.seeded = true
+	return nil
+}
+
+func TestSeedOverridesKeepsDeletedOverrides(t *testing.T) {
+	ctx := context.Background()
+	repo := &memCatalogRepo{overrides: map[string]string{"ETH": "custom-eth"}}
+	defaults := map[string]string{"btc": "bitcoin", "ETH": "ethereum"}
+
+	SeedOverrides(ctx, repo, defaults)
+	// Привязка администратора не перезаписывается
+	if repo.overrides["BTC"] != "bitcoin" || repo.overrides["ETH"] != "custom-eth" {
+		t.Fatalf("overrides after seeding = %v", repo.overrides)
+	}
+
+	if err := repo.DeleteOverride(ctx, "BTC"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Следующий запуск не возвращает удалённую привязку
+	SeedOverrides(ctx, repo, defaults)
+	if coinID, exists := repo.overrides["BTC"]; exists {
+		t.Errorf("deleted override BTC -> %s was seeded again", coinID)
+	}
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// CurrencyRemovedEvent - данные события currency.removed
+type CurrencyRemovedEvent struct {
+	Symbol    string    `json:"symbol"`
+	RemovedAt time.Time `json:"removed_at"`
+}
+
+// currencyEvents публикует события об изменении списка валют после успешных
+// вызовов сервиса. Ошибка публикации не отменяет уже выполненную операцию.
+type currencyEvents struct {
+	ports.CurrencyService
+	publisher ports.EventPublisher
+}
+
+func NewCurrencyEvents(service ports.CurrencyService, publisher ports.EventPublisher) ports.CurrencyService {
+	return &currencyEvents{CurrencyService: service, publisher: publisher}
+}
+
+func (s *currencyEvents) AddCurrency(ctx context.Context, currency *domain.Currency) (*domain.Currency, error) {
+	added, err := s.CurrencyService.AddCurrency(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, domain.EventCurrencyAdded, added)
+	return added, nil
+}
+
+func (s *currencyEvents) RestoreCurrency(ctx context.Context, symbol string) (*domain.Currency, error) {
+	restored, err := s.CurrencyService.RestoreCurrency(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, domain.EventCurrencyAdded, restored)
+	return restored, nil
+}
+
+func (s *currencyEvents) RemoveCurrency(ctx context.Context, symbol string) error {
+	if err := s.CurrencyService.RemoveCurrency(ctx, symbol); err != nil {
+		return err
+	}
+
+	s.publish(ctx, domain.EventCurrencyRemoved, CurrencyRemovedEvent{
+		Symbol:    strings.ToUpper(symbol),
+		RemovedAt: time.Now().UTC(),
+	})
+	return nil
+}
+
+func (s *currencyEvents) publish(ctx context.Context, event domain.WebhookEvent, data any) {
+	// Операция уже выполнена, поэтому событие ставится в очередь даже после отмены запроса клиентом
+	if err := s.publisher.Publish(context.WithoutCancel(ctx), event, data); err != nil {
+		log.Printf("⚠️  Не удалось опубликовать событие %s: %v", event, err)
+	}
+}
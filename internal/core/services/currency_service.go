@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
@@ -10,36 +11,69 @@ import (
 	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
 )
 
+// defaultCandleCount - количество свечей, если начало периода не указано
+const defaultCandleCount = 100
+
 type currencyService struct {
-	currencyRepo  ports.CurrencyRepository
-	priceRepo     ports.PriceRepository
-	priceProvider ports.ExternalPriceProvider
+	currencyRepo    ports.CurrencyRepository
+	priceRepo       ports.PriceRepository
+	priceProvider   ports.ExternalPriceProvider
+	priceSubscriber ports.PriceSubscriber
+	coinResolver    ports.CoinResolver
+	defaultQuotes   []string
 }
 
 func NewCurrencyService(
 	currencyRepo ports.CurrencyRepository,
 	priceRepo ports.PriceRepository,
 	priceProvider ports.ExternalPriceProvider,
+	priceSubscriber ports.PriceSubscriber,
+	coinResolver ports.CoinResolver,
+	defaultQuotes []string,
 ) ports.CurrencyService {
 	return &currencyService{
-		currencyRepo:  currencyRepo,
-		priceRepo:     priceRepo,
-		priceProvider: priceProvider,
+		currencyRepo:    currencyRepo,
+		priceRepo:       priceRepo,
+		priceProvider:   priceProvider,
+		priceSubscriber: priceSubscriber,
+		coinResolver:    coinResolver,
+		defaultQuotes:   domain.NormalizeQuotes(defaultQuotes),
 	}
 }
 
-func (s *currencyService) AddCurrency(ctx context.Context, symbol, name string) (*domain.Currency, error) {
-	symbol = strings.ToUpper(symbol)
+func (s *currencyService) AddCurrency(ctx context.Context, currency *domain.Currency) (*domain.Currency, error) {
+	symbol := strings.ToUpper(currency.Symbol)
 	existing, err := s.currencyRepo.GetBySymbol(ctx, symbol)
 	if err == nil && existing != nil {
 		return nil, domain.ErrCurrencyAlreadyExists
 	}
 
-	currency := &domain.Currency{
-		Symbol:    symbol,
-		Name:      name,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	// Удалённую валюту нужно восстановить или дождаться её очистки
+	if removed, err := s.currencyRepo.GetRemovedBySymbol(ctx, symbol); err == nil && removed != nil {
+		return nil, domain.ErrCurrencyRemoved
+	}
+
+	quotes := currency.Quotes
+	if len(quotes) == 0 {
+		quotes = s.defaultQuotes
+	}
+
+	tracking := currency.Tracking
+	if tracking == "" {
+		tracking = domain.TrackingActive
+	}
+
+	currency = &domain.Currency{
+		Symbol:         symbol,
+		Name:           currency.Name,
+		Quotes:         domain.NormalizeQuotes(quotes),
+		Decimals:       currency.Decimals,
+		Tags:           domain.NormalizeTags(currency.Tags),
+		ProviderCoinID: s.resolveCoinID(ctx, symbol),
+		Description:    currency.Description,
+		Tracking:       tracking,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 
 	if err := currency.Validate(); err != nil {
@@ -50,8 +84,10 @@ func (s *currencyService) AddCurrency(ctx context.Context, symbol, name string)
 		return nil, err
 	}
 
-	if currentPrice, err := s.priceProvider.GetCurrentPrice(ctx, symbol); err == nil {
-		_ = s.priceRepo.SavePrice(ctx, currentPrice)
+	for _, quote := range currency.Quotes {
+		if currentPrice, err := s.priceProvider.GetCurrentPrice(ctx, symbol, quote); err == nil && !currentPrice.IsSynthetic {
+			_ = s.priceRepo.SavePrice(ctx, currentPrice)
+		}
 	}
 
 	return currency, nil
@@ -63,50 +99,443 @@ func (s *currencyService) RemoveCurrency(ctx context.Context, symbol string) err
 		return domain.ErrCurrencyNotFound
 	}
 
-	return s.currencyRepo.Delete(ctx, symbol)
+	return s.currencyRepo.Delete(ctx, existing.Symbol)
+}
+
+func (s *currencyService) UpdateCurrency(ctx context.Context, symbol string, update domain.CurrencyUpdate) (*domain.Currency, error) {
+	currency, err := s.currencyRepo.GetBySymbol(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	update.Apply(currency)
+	// Сбой каталога или ставший неоднозначным тикер не должны стирать уже определённый идентификатор,
+	// поэтому он меняется только при успешном определении (в том числе по новой привязке)
+	if coinID := s.resolveCoinID(ctx, currency.Symbol); coinID != "" {
+		currency.ProviderCoinID = coinID
+	}
+	if err := currency.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.currencyRepo.Update(ctx, currency); err != nil {
+		return nil, err
+	}
+
+	return s.currencyRepo.GetBySymbol(ctx, currency.Symbol)
 }
 
-func (s *currencyService) GetCurrencyPrice(ctx context.Context, symbol string, timestamp time.Time) (*domain.CurrencyPrice, error) {
+// resolveCoinID возвращает идентификатор монеты у провайдера или пустую строку, если он не определён
+func (s *currencyService) resolveCoinID(ctx context.Context, symbol string) string {
+	if s.coinResolver == nil {
+		return ""
+	}
+
+	coinID, err := s.coinResolver.ResolveCoinID(ctx, symbol)
+	if err != nil {
+		return ""
+	}
+	return coinID
+}
+
+func (s *currencyService) RestoreCurrency(ctx context.Context, symbol string) (*domain.Currency, error) {
+	removed, err := s.currencyRepo.GetRemovedBySymbol(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.currencyRepo.Restore(ctx, removed.Symbol); err != nil {
+		return nil, err
+	}
+
+	return s.currencyRepo.GetBySymbol(ctx, removed.Symbol)
+}
+
+func (s *currencyService) ListRemovedCurrencies(ctx context.Context) ([]*domain.Currency, error) {
+	return s.currencyRepo.ListRemoved(ctx, time.Time{})
+}
+
+func (s *currencyService) GetCurrencyPrice(ctx context.Context, symbol, quote string, timestamp time.Time, lookup domain.PriceLookup) (*domain.PriceLookupResult, error) {
 	symbol = strings.ToUpper(symbol)
-	_, err := s.currencyRepo.GetBySymbol(ctx, symbol)
+	quote, err := s.trackedQuote(ctx, symbol, quote)
 	if err != nil {
-		return nil, domain.ErrCurrencyNotFound
+		return nil, err
 	}
 
 	if timestamp.IsZero() {
-		return s.priceRepo.GetLatestPrice(ctx, symbol)
+		price, err := s.priceRepo.GetLatestPrice(ctx, symbol, quote)
+		if err != nil {
+			return nil, err
+		}
+		return latestPriceResult(price, lookup, time.Now())
 	}
 
-	price, err := s.priceRepo.GetPriceByTimestamp(ctx, symbol, timestamp)
+	// Историческая цена берётся только из базы: подставлять текущую цену провайдера нельзя
+	before, after, err := s.priceRepo.GetPricesAround(ctx, symbol, quote, timestamp)
 	if err != nil {
-		return s.priceProvider.GetCurrentPrice(ctx, symbol)
+		return nil, err
 	}
 
-	return price, nil
+	return lookup.Resolve(before, after, timestamp)
 }
 
-func (s *currencyService) ListCurrencies(ctx context.Context) ([]*domain.Currency, error) {
-	return s.currencyRepo.List(ctx)
+// latestPriceResult проверяет давность последней цены относительно now
+func latestPriceResult(price *domain.CurrencyPrice, lookup domain.PriceLookup, now time.Time) (*domain.PriceLookupResult, error) {
+	distance := now.Sub(price.Timestamp)
+	if lookup.MaxStaleness > 0 && distance > lookup.MaxStaleness {
+		return nil, domain.ErrPriceNotFound
+	}
+	return &domain.PriceLookupResult{Price: price, RequestedTime: now, Distance: distance}, nil
 }
 
-func (s *currencyService) GetPriceHistory(ctx context.Context, symbol string, startTime, endTime time.Time, limit int) ([]*domain.CurrencyPrice, error) {
-	_, err := s.currencyRepo.GetBySymbol(ctx, symbol)
+func (s *currencyService) BatchGetPrices(ctx context.Context, query domain.BatchPriceQuery) ([]*domain.BatchPriceResult, error) {
+	if size := query.Size(); size == 0 || size > domain.MaxBatchPriceItems {
+		return nil, domain.ErrBatchTooLarge
+	}
+	if err := query.Lookup.Validate(); err != nil {
+		return nil, err
+	}
+	quote := domain.NormalizeQuote(query.Quote)
+
+	symbols := make([]string, len(query.Symbols))
+	for i, symbol := range query.Symbols {
+		symbols[i] = strings.ToUpper(symbol)
+	}
+
+	currencies, err := s.currencyRepo.GetBySymbols(ctx, symbols)
 	if err != nil {
 		return nil, err
 	}
 
-	prices, err := s.priceRepo.GetPriceHistory(ctx, symbol, startTime, endTime, limit)
+	// Ошибки уровня символа (нет валюты, не отслеживается котировка) относятся ко всем его элементам
+	symbolErrs := make(map[string]error, len(symbols))
+	for _, symbol := range symbols {
+		symbolErrs[symbol] = domain.ErrCurrencyNotFound
+	}
+	var valid []string
+	for _, currency := range currencies {
+		if !currency.TracksQuote(quote) {
+			symbolErrs[currency.Symbol] = domain.ErrQuoteNotTracked
+			continue
+		}
+		delete(symbolErrs, currency.Symbol)
+		valid = append(valid, currency.Symbol)
+	}
+
+	if len(query.Timestamps) == 0 {
+		return s.batchLatestPrices(ctx, symbols, valid, symbolErrs, quote, query.Lookup)
+	}
 
-	if err != nil || len(prices) == 0 {
-		log.Printf("📡 Запрашиваем исторические данные у внешнего провайдера для %s", symbol)
-		externalPrices, extErr := s.priceProvider.GetHistoricalPrices(ctx, symbol, startTime, endTime)
+	var points []domain.PricePoint
+	for _, symbol := range symbols {
+		if symbolErrs[symbol] != nil {
+			continue
+		}
+		for _, timestamp := range query.Timestamps {
+			points = append(points, domain.PricePoint{Symbol: symbol, Timestamp: timestamp})
+		}
+	}
+
+	before, after, err := s.priceRepo.GetPricesAroundBatch(ctx, points, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*domain.BatchPriceResult, 0, query.Size())
+	next := 0
+	for _, symbol := range symbols {
+		for _, timestamp := range query.Timestamps {
+			item := &domain.BatchPriceResult{Symbol: symbol, Timestamp: timestamp, Err: symbolErrs[symbol]}
+			if item.Err == nil {
+				item.Result, item.Err = query.Lookup.Resolve(before[next], after[next], timestamp)
+				next++
+			}
+			results = append(results, item)
+		}
+	}
+
+	return results, nil
+}
+
+func (s *currencyService) batchLatestPrices(
+	ctx context.Context,
+	symbols, valid []string,
+	symbolErrs map[string]error,
+	quote string,
+	lookup domain.PriceLookup,
+) ([]*domain.BatchPriceResult, error) {
+	var latest map[string]*domain.CurrencyPrice
+	if len(valid) > 0 {
+		var err error
+		if latest, err = s.priceRepo.GetLatestPrices(ctx, valid, quote); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	results := make([]*domain.BatchPriceResult, len(symbols))
+	for i, symbol := range symbols {
+		item := &domain.BatchPriceResult{Symbol: symbol, Err: symbolErrs[symbol]}
+		if item.Err == nil {
+			if price, ok := latest[symbol]; ok {
+				item.Result, item.Err = latestPriceResult(price, lookup, now)
+			} else {
+				item.Err = domain.ErrPriceNotFound
+			}
+		}
+		results[i] = item
+	}
+
+	return results, nil
+}
+
+func (s *currencyService) Convert(ctx context.Context, query domain.ConversionQuery) (*domain.Conversion, error) {
+	if query.Amount.Sign() < 0 {
+		return nil, domain.ErrInvalidAmount
+	}
+	if err := query.Lookup.Validate(); err != nil {
+		return nil, err
+	}
+
+	conversion := &domain.Conversion{
+		From:   strings.ToUpper(query.From),
+		To:     strings.ToUpper(query.To),
+		Amount: query.Amount,
+	}
+
+	fromPrice, fromLeg, err := s.pivotPrice(ctx, conversion.From, query)
+	if err != nil {
+		return nil, err
+	}
+	toPrice, toLeg, err := s.pivotPrice(ctx, conversion.To, query)
+	if err != nil {
+		return nil, err
+	}
+	conversion.FromLeg, conversion.ToLeg = fromLeg, toLeg
+
+	rate, err := fromPrice.Div(toPrice, domain.ConversionPrecision)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := query.Amount.Mul(fromPrice).Div(toPrice, domain.ConversionPrecision)
+	if err != nil {
+		return nil, err
+	}
+	conversion.Rate, conversion.ConvertedAmount = rate.Trim(), converted.Trim()
+
+	return conversion, nil
+}
+
+// pivotPrice возвращает цену символа в domain.PivotQuote и использованную цену (nil для самой PivotQuote)
+func (s *currencyService) pivotPrice(ctx context.Context, symbol string, query domain.ConversionQuery) (domain.Decimal, *domain.PriceLookupResult, error) {
+	if domain.IsPivot(symbol) {
+		return domain.NewDecimal(1, 0), nil, nil
+	}
+
+	leg, err := s.GetCurrencyPrice(ctx, symbol, domain.PivotQuote, query.Timestamp, query.Lookup)
+	if err != nil {
+		return domain.Decimal{}, nil, err
+	}
+	if leg.Price.Price.Sign() <= 0 {
+		return domain.Decimal{}, nil, domain.ErrInvalidPrice
+	}
+
+	return leg.Price.Price, leg, nil
+}
+
+// currencyPageCursor - содержимое токена страницы списка валют
+type currencyPageCursor struct {
+	After domain.CurrencyPosition `json:"a"`
+	Query string                  `json:"q"`
+}
+
+func (s *currencyService) ListCurrencies(ctx context.Context, query domain.CurrencyListQuery) (*domain.CurrencyPage, error) {
+	if query.Filter.Tracking != "" && query.Filter.Tracking != domain.TrackingActive && query.Filter.Tracking != domain.TrackingPaused {
+		return nil, domain.ErrInvalidTrackingState
+	}
+
+	var after *domain.CurrencyPosition
+	if query.PageToken != "" {
+		var cursor currencyPageCursor
+		if err := domain.DecodePageToken(query.PageToken, &cursor); err != nil {
+			return nil, err
+		}
+		if cursor.Query != query.Fingerprint() {
+			return nil, domain.ErrInvalidPageToken
+		}
+		after = &cursor.After
+	}
+
+	pageSize := domain.PageSize(query.PageSize)
+	// Лишняя запись показывает, есть ли следующая страница
+	currencies, err := s.currencyRepo.ListPage(ctx, query.Filter, query.Order, after, pageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.CurrencyPage{Currencies: currencies}
+	if len(currencies) > pageSize {
+		page.Currencies = currencies[:pageSize]
+		page.NextPageToken = domain.EncodePageToken(currencyPageCursor{
+			After: currencies[pageSize-1].Position(query.Order.Field),
+			Query: query.Fingerprint(),
+		})
+	}
+
+	return page, nil
+}
+
+// priceHistoryCursor - содержимое токена страницы истории цен
+type priceHistoryCursor struct {
+	After domain.PricePosition `json:"a"`
+	Query string               `json:"q"`
+}
+
+func (s *currencyService) GetPriceHistory(ctx context.Context, query domain.PriceHistoryQuery) (*domain.PriceHistoryPage, error) {
+	symbol := strings.ToUpper(query.Symbol)
+	quote, err := s.trackedQuote(ctx, symbol, query.Quote)
+	if err != nil {
+		return nil, err
+	}
+	query.Symbol, query.Quote = symbol, quote
+	startTime, endTime := query.StartTime, query.EndTime
+
+	if !startTime.IsZero() && !endTime.IsZero() && startTime.After(endTime) {
+		return nil, domain.ErrInvalidTimeRange
+	}
+
+	var after *domain.PricePosition
+	if query.PageToken != "" {
+		var cursor priceHistoryCursor
+		if err := domain.DecodePageToken(query.PageToken, &cursor); err != nil {
+			return nil, err
+		}
+		if cursor.Query != query.Fingerprint() {
+			return nil, domain.ErrInvalidPageToken
+		}
+		after = &cursor.After
+	}
+
+	limit := domain.PageSize(query.PageSize)
+	// Лишняя запись показывает, есть ли следующая страница
+	prices, err := s.priceRepo.GetPriceHistory(ctx, symbol, quote, startTime, endTime, query.Ascending, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(prices) > limit {
+		return s.priceHistoryPage(query, prices[:limit], true), nil
+	}
+
+	// К провайдеру обращаемся только за пустой первой страницей: продолжение всегда читается из базы
+	if after == nil && len(prices) == 0 {
+		log.Printf("📡 Запрашиваем исторические данные у внешнего провайдера для %s/%s", symbol, quote)
+		externalPrices, extErr := s.priceProvider.GetHistoricalPrices(ctx, symbol, quote, startTime, endTime)
 		if extErr == nil && len(externalPrices) > 0 {
-			for _, price := range externalPrices {
-				s.priceRepo.SavePrice(ctx, price)
+			// Синтетические цены отдаём клиенту с пометкой, но в историю не записываем
+			saved := false
+			if toSave := realPrices(externalPrices); len(toSave) > 0 {
+				if err := s.priceRepo.SavePrices(ctx, toSave); err != nil {
+					log.Printf("⚠️  Не удалось сохранить историю %s/%s: %v", symbol, quote, err)
+				} else {
+					saved = len(toSave) == len(externalPrices)
+				}
 			}
-			return externalPrices[:min(len(externalPrices), limit)], nil
+			sortPrices(externalPrices, query.Ascending)
+			if len(externalPrices) <= limit {
+				return &domain.PriceHistoryPage{Prices: externalPrices}, nil
+			}
+			// Остаток диапазона уже сохранён, поэтому следующие страницы читаются из базы
+			return s.priceHistoryPage(query, externalPrices[:limit], saved), nil
+		}
+	}
+
+	return &domain.PriceHistoryPage{Prices: prices}, nil
+}
+
+// priceHistoryPage собирает страницу истории; при hasMore токен продолжения указывает на последнюю цену страницы
+func (s *currencyService) priceHistoryPage(query domain.PriceHistoryQuery, prices []*domain.CurrencyPrice, hasMore bool) *domain.PriceHistoryPage {
+	page := &domain.PriceHistoryPage{Prices: prices}
+	if hasMore {
+		last := prices[len(prices)-1]
+		page.NextPageToken = domain.EncodePageToken(priceHistoryCursor{
+			After: domain.PricePosition{Timestamp: last.Timestamp, Source: last.Source},
+			Query: query.Fingerprint(),
+		})
+	}
+	return page
+}
+
+// sortPrices упорядочивает цены по времени так же, как история из базы
+func sortPrices(prices []*domain.CurrencyPrice, ascending bool) {
+	sort.SliceStable(prices, func(i, j int) bool {
+		if ascending {
+			return prices[i].Timestamp.Before(prices[j].Timestamp)
+		}
+		return prices[i].Timestamp.After(prices[j].Timestamp)
+	})
+}
+
+func (s *currencyService) GetCandles(ctx context.Context, symbol, quote, interval string, startTime, endTime time.Time) ([]*domain.Candle, error) {
+	symbol = strings.ToUpper(symbol)
+	quote, err := s.trackedQuote(ctx, symbol, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, err := domain.ParseCandleInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	if endTime.IsZero() {
+		endTime = time.Now()
+	}
+	if startTime.IsZero() {
+		startTime = endTime.Add(-defaultCandleCount * bucket)
+	}
+	if startTime.After(endTime) {
+		return nil, domain.ErrInvalidTimeRange
+	}
+
+	return s.priceRepo.GetCandles(ctx, symbol, quote, bucket, startTime, endTime)
+}
+
+func (s *currencyService) StreamPrices(ctx context.Context, symbols []string) (<-chan *domain.CurrencyPrice, func(), error) {
+	normalized := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(symbol)
+		if _, err := s.currencyRepo.GetBySymbol(ctx, symbol); err != nil {
+			return nil, nil, err
 		}
+		normalized = append(normalized, symbol)
 	}
 
-	return prices, err
+	updates, unsubscribe := s.priceSubscriber.Subscribe(normalized)
+	return updates, unsubscribe, nil
+}
+
+// trackedQuote проверяет, что валюта существует и её цена отслеживается в запрошенной котировке
+func (s *currencyService) trackedQuote(ctx context.Context, symbol, quote string) (string, error) {
+	currency, err := s.currencyRepo.GetBySymbol(ctx, symbol)
+	if err != nil {
+		return "", err
+	}
+
+	quote = domain.NormalizeQuote(quote)
+	if !currency.TracksQuote(quote) {
+		return "", domain.ErrQuoteNotTracked
+	}
+
+	return quote, nil
+}
+
+// realPrices отбрасывает синтетические цены, которые не сохраняются в истории
+func realPrices(prices []*domain.CurrencyPrice) []*domain.CurrencyPrice {
+	persisted := make([]*domain.CurrencyPrice, 0, len(prices))
+	for _, price := range prices {
+		if !price.IsSynthetic {
+			persisted = append(persisted, price)
+		}
+	}
+	return persisted
 }
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+const subscriberBufferSize = 64
+
+// livePriceMaxAge - цены старше этого возраста (например, история, сохранённая из ответа провайдера)
+// подписчикам не рассылаются
+const livePriceMaxAge = 10 * time.Minute
+
+// priceSubscription передаёт подписчику цены через очередь, в которой хранится последняя
+// ещё не отправленная цена каждой пары. Медленный подписчик пропускает только цены,
+// вытесненные более новыми ценами той же пары, но не цены других пар.
+type priceSubscription struct {
+	symbols map[string]struct{}
+	ch      chan *domain.CurrencyPrice
+
+	mu      sync.Mutex
+	pending map[string]*domain.CurrencyPrice
+	order   []string
+	wake    chan struct{}
+	done    chan struct{}
+}
+
+func (s *priceSubscription) matches(symbol string) bool {
+	if len(s.symbols) == 0 {
+		return true
+	}
+	_, ok := s.symbols[symbol]
+	return ok
+}
+
+func (s *priceSubscription) enqueue(key string, price *domain.CurrencyPrice) {
+	s.mu.Lock()
+	if _, queued := s.pending[key]; !queued {
+		s.order = append(s.order, key)
+	}
+	s.pending[key] = price
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *priceSubscription) next() *domain.CurrencyPrice {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.order) == 0 {
+		return nil
+	}
+	key := s.order[0]
+	s.order = s.order[1:]
+	price := s.pending[key]
+	delete(s.pending, key)
+	return price
+}
+
+// forward переносит цены из очереди в канал подписчика и закрывает его после отписки
+func (s *priceSubscription) forward() {
+	defer close(s.ch)
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.wake:
+		}
+
+		for price := s.next(); price != nil; price = s.next() {
+			select {
+			case s.ch <- price:
+			case <-s.done:
+				return
+			}
+		}
+	}
+}
+
+// priceBroadcaster оборачивает PriceRepository и рассылает подписчикам каждую новую сохранённую цену
+type priceBroadcaster struct {
+	ports.PriceRepository
+
+	mu     sync.RWMutex
+	nextID int
+	subs   map[int]*priceSubscription
+	// lastPublished - время последней разосланной цены каждой пары; более старые цены не рассылаются
+	lastPublished map[string]time.Time
+}
+
+func NewPriceBroadcaster(repo ports.PriceRepository) (ports.PriceRepository, ports.PriceSubscriber) {
+	b := &priceBroadcaster{
+		PriceRepository: repo,
+		subs:            make(map[int]*priceSubscription),
+		lastPublished:   make(map[string]time.Time),
+	}
+	return b, b
+}
+
+func (b *priceBroadcaster) SavePrice(ctx context.Context, price *domain.CurrencyPrice) error {
+	if err := b.PriceRepository.SavePrice(ctx, price); err != nil {
+		return err
+	}
+
+	b.publish(price)
+	return nil
+}
+
+func (b *priceBroadcaster) SavePrices(ctx context.Context, prices []*domain.CurrencyPrice) error {
+	if err := b.PriceRepository.SavePrices(ctx, prices); err != nil {
+		return err
+	}
+
+	for _, price := range prices {
+		b.publish(price)
+	}
+	return nil
+}
+
+func (b *priceBroadcaster) Subscribe(symbols []string) (<-chan *domain.CurrencyPrice, func()) {
+	sub := &priceSubscription{
+		symbols: make(map[string]struct{}, len(symbols)),
+		ch:      make(chan *domain.CurrencyPrice, subscriberBufferSize),
+		pending: make(map[string]*domain.CurrencyPrice),
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	for _, symbol := range symbols {
+		sub.symbols[strings.ToUpper(symbol)] = struct{}{}
+	}
+
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = sub
+	b.mu.Unlock()
+
+	go sub.forward()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, id)
+			b.mu.Unlock()
+			close(sub.done)
+		})
+	}
+
+	return sub.ch, unsubscribe
+}
+
+// publish рассылает цену как новую, только если она свежая и новее уже разосланной цены пары:
+// сохранённая история не должна попадать в поток текущих цен
+func (b *priceBroadcaster) publish(price *domain.CurrencyPrice) {
+	if time.Since(price.Timestamp) > livePriceMaxAge {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	symbol := strings.ToUpper(price.Symbol)
+	key := symbol + "/" + price.Quote
+	if last, ok := b.lastPublished[key]; ok && !price.Timestamp.After(last) {
+		return
+	}
+	b.lastPublished[key] = price.Timestamp
+
+	for _, sub := range b.subs {
+		if !sub.matches(symbol) {
+			continue
+		}
+
+		update := *price
+		sub.enqueue(key, &update)
+	}
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// savingRepo - PriceRepository, в котором сохранение всегда успешно
+type savingRepo struct {
+	ports.PriceRepository
+}
+
+func (savingRepo) SavePrice(ctx context.Context, price *domain.CurrencyPrice) error { return nil }
+
+func (savingRepo) SavePrices(ctx context.Context, prices []*domain.CurrencyPrice) error { return nil }
+
+func TestPriceBroadcasterDeliversLargeBatch(t *testing.T) {
+	repo, subscriber := NewPriceBroadcaster(savingRepo{})
+	prices, unsubscribe := subscriber.Subscribe(nil)
+	defer unsubscribe()
+
+	// Пачка больше буфера подписчика, который пока ничего не читает
+	const pairs = subscriberBufferSize * 3
+	now := time.Now()
+	batch := make([]*domain.CurrencyPrice, pairs)
+	for i := range batch {
+		batch[i] = &domain.CurrencyPrice{Symbol: fmt.Sprintf("C%d", i), Quote: "USD", Price: domain.NewDecimal(int64(i), 0), Timestamp: now}
+	}
+	if err := repo.SavePrices(context.Background(), batch); err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[string]bool)
+	for len(seen) < pairs {
+		select {
+		case price := <-prices:
+			seen[price.Symbol] = true
+		case <-time.After(time.Second):
+			t.Fatalf("received %d of %d prices", len(seen), pairs)
+		}
+	}
+}
+
+func TestPriceBroadcasterSkipsHistory(t *testing.T) {
+	repo, subscriber := NewPriceBroadcaster(savingRepo{})
+	prices, unsubscribe := subscriber.Subscribe([]string{"btc"})
+	defer unsubscribe()
+
+	now := time.Now()
+	tick := func(age time.Duration, price int64) *domain.CurrencyPrice {
+		return &domain.CurrencyPrice{Symbol: "BTC", Quote: "USD", Price: domain.NewDecimal(price, 0), Timestamp: now.Add(-age)}
+	}
+
+	expect := func(want string) {
+		t.Helper()
+		select {
+		case price := <-prices:
+			if price.Price.String() != want {
+				t.Fatalf("got price %s, want %s", price.Price, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("price %s not delivered", want)
+		}
+	}
+
+	if err := repo.SavePrice(context.Background(), tick(0, 100)); err != nil {
+		t.Fatal(err)
+	}
+	expect("100")
+
+	// История за год и точка старше уже разосланной цены в поток не попадают
+	history := []*domain.CurrencyPrice{tick(365*24*time.Hour, 1), tick(time.Hour, 2), tick(time.Minute, 3)}
+	if err := repo.SavePrices(context.Background(), history); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SavePrice(context.Background(), tick(-time.Second, 101)); err != nil {
+		t.Fatal(err)
+	}
+	expect("101")
+
+	select {
+	case price := <-prices:
+		t.Fatalf("unexpected price %s", price.Price)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestPriceBroadcasterUnsubscribeClosesChannel(t *testing.T) {
+	_, subscriber := NewPriceBroadcaster(savingRepo{})
+	prices, unsubscribe := subscriber.Subscribe(nil)
+	unsubscribe()
+	unsubscribe()
+
+	select {
+	case _, ok := <-prices:
+		if ok {
+			t.Fatal("channel should be closed without prices")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after unsubscribe")
+	}
+}
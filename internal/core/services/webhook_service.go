@@ -0,0 +1,462 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// webhookErrorLimit - максимальная длина текста ошибки последней попытки в журнале
+const webhookErrorLimit = 500
+
+// WebhookOptions - параметры доставки webhook
+type WebhookOptions struct {
+	// Timeout - время ожидания ответа получателя на одну попытку
+	Timeout time.Duration
+	// MaxAttempts - число попыток, после которого доставка считается неудачной
+	MaxAttempts int
+	// RetryBase и RetryMax - первая пауза между попытками и её предел; пауза удваивается после каждой ошибки
+	RetryBase time.Duration
+	RetryMax  time.Duration
+	// DisableAfter - число неудачных попыток подряд, после которого получатель отключается, 0 - не отключать
+	DisableAfter int
+	// PollInterval - как часто проверяются доставки, время повтора которых наступило
+	PollInterval time.Duration
+	// Workers - число одновременных запросов к получателям
+	Workers int
+	// MaxPriceAge - более старые цены не рассылаются как price.updated, 0 - без ограничения
+	MaxPriceAge time.Duration
+}
+
+type webhookDeliveryCursor struct {
+	BeforeID int64  `json:"b"`
+	Query    string `json:"q"`
+}
+
+type webhookService struct {
+	repo            ports.WebhookRepository
+	sender          ports.WebhookSender
+	priceSubscriber ports.PriceSubscriber
+	opts            WebhookOptions
+
+	ctx         context.Context
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+	unsubscribe func()
+
+	// pending - последняя ещё не разосланная цена каждой пары символ-котировка
+	mu      sync.Mutex
+	pending map[string]*domain.CurrencyPrice
+	wake    chan struct{}
+	// dispatch будит доставку сразу после публикации события, не дожидаясь PollInterval
+	dispatch chan struct{}
+}
+
+func NewWebhookService(
+	repo ports.WebhookRepository,
+	sender ports.WebhookSender,
+	priceSubscriber ports.PriceSubscriber,
+	opts WebhookOptions,
+) ports.WebhookService {
+	opts.Workers = max(opts.Workers, 1)
+	opts.MaxAttempts = max(opts.MaxAttempts, 1)
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &webhookService{
+		repo:            repo,
+		sender:          sender,
+		priceSubscriber: priceSubscriber,
+		opts:            opts,
+		ctx:             ctx,
+		cancel:          cancel,
+		pending:         make(map[string]*domain.CurrencyPrice),
+		wake:            make(chan struct{}, 1),
+		dispatch:        make(chan struct{}, 1),
+	}
+}
+
+func (s *webhookService) CreateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) (*domain.WebhookEndpoint, error) {
+	endpoint = &domain.WebhookEndpoint{
+		URL:         endpoint.URL,
+		Events:      uniqueEvents(endpoint.Events),
+		Description: endpoint.Description,
+		Enabled:     endpoint.Enabled,
+	}
+
+	if err := endpoint.Validate(); err != nil {
+		return nil, err
+	}
+
+	secret, err := randomHex(24)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Secret = "whsec_" + secret
+
+	if err := s.repo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	log.Printf("📮 Добавлен webhook #%d: %s %v", endpoint.ID, endpoint.URL, endpoint.Events)
+	return endpoint, nil
+}
+
+func (s *webhookService) GetEndpoint(ctx context.Context, id int64) (*domain.WebhookEndpoint, error) {
+	return s.repo.GetEndpoint(ctx, id)
+}
+
+func (s *webhookService) ListEndpoints(ctx context.Context) ([]*domain.WebhookEndpoint, error) {
+	return s.repo.ListEndpoints(ctx)
+}
+
+func (s *webhookService) UpdateEndpoint(ctx context.Context, id int64, update domain.WebhookEndpointUpdate) (*domain.WebhookEndpoint, error) {
+	endpoint, err := s.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Events != nil {
+		update.Events = uniqueEvents(update.Events)
+	}
+	update.Apply(endpoint)
+
+	if err := endpoint.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	if endpoint.Enabled {
+		s.wakeDispatch()
+	}
+	return endpoint, nil
+}
+
+func (s *webhookService) DeleteEndpoint(ctx context.Context, id int64) error {
+	return s.repo.DeleteEndpoint(ctx, id)
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, query domain.WebhookDeliveryQuery) (*domain.WebhookDeliveryPage, error) {
+	switch query.Status {
+	case "", domain.DeliveryPending, domain.DeliverySucceeded, domain.DeliveryFailed:
+	default:
+		return nil, domain.ErrInvalidDeliveryStatus
+	}
+
+	var beforeID int64
+	if query.PageToken != "" {
+		var cursor webhookDeliveryCursor
+		if err := domain.DecodePageToken(query.PageToken, &cursor); err != nil {
+			return nil, err
+		}
+		if cursor.Query != query.Fingerprint() {
+			return nil, domain.ErrInvalidPageToken
+		}
+		beforeID = cursor.BeforeID
+	}
+
+	// Несуществующий получатель - ошибка, а не пустой журнал
+	if _, err := s.repo.GetEndpoint(ctx, query.EndpointID); err != nil {
+		return nil, err
+	}
+
+	limit := domain.PageSize(query.PageSize)
+	// Лишняя запись показывает, есть ли следующая страница
+	deliveries, err := s.repo.ListDeliveries(ctx, query.EndpointID, query.Status, beforeID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.WebhookDeliveryPage{Deliveries: deliveries}
+	if len(deliveries) > limit {
+		page.Deliveries = deliveries[:limit]
+		page.NextPageToken = domain.EncodePageToken(webhookDeliveryCursor{
+			BeforeID: deliveries[limit-1].ID,
+			Query:    query.Fingerprint(),
+		})
+	}
+	return page, nil
+}
+
+// Publish ставит событие в очередь доставки всем включённым получателям, подписанным на него.
+// Сама отправка идёт в фоне, поэтому недоступный получатель не задерживает вызывающего.
+func (s *webhookService) Publish(ctx context.Context, event domain.WebhookEvent, data any) error {
+	if !event.IsValid() {
+		return domain.ErrInvalidWebhookEvent
+	}
+
+	endpoints, err := s.repo.ListEndpointsForEvent(ctx, event)
+	if err != nil {
+		return err
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	eventID, err := randomHex(16)
+	if err != nil {
+		return err
+	}
+	eventID = "evt_" + eventID
+
+	now := time.Now()
+	payload, err := json.Marshal(domain.WebhookPayload{
+		ID:        eventID,
+		Type:      event,
+		CreatedAt: now.UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]*domain.WebhookDelivery, len(endpoints))
+	for i, endpoint := range endpoints {
+		deliveries[i] = &domain.WebhookDelivery{
+			EndpointID:    endpoint.ID,
+			EventID:       eventID,
+			Event:         event,
+			Payload:       payload,
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: now,
+		}
+	}
+
+	if err := s.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return err
+	}
+
+	s.wakeDispatch()
+	return nil
+}
+
+func (s *webhookService) Start() {
+	prices, unsubscribe := s.priceSubscriber.Subscribe(nil)
+	s.unsubscribe = unsubscribe
+
+	s.wg.Add(3)
+	go func() {
+		defer s.wg.Done()
+		s.receive(prices)
+	}()
+	go func() {
+		defer s.wg.Done()
+		s.publishLoop()
+	}()
+	go func() {
+		defer s.wg.Done()
+		s.dispatchLoop()
+	}()
+}
+
+// Stop отписывается от цен и дожидается завершения текущих попыток доставки.
+// Прерванные попытки остаются в очереди и повторяются после перезапуска.
+func (s *webhookService) Stop() {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+// receive быстро вычитывает цены из подписки, чтобы рассылка не пропускала их,
+// пока события сохраняются в очередь доставки
+func (s *webhookService) receive(prices <-chan *domain.CurrencyPrice) {
+	defer close(s.wake)
+
+	for price := range prices {
+		if price.IsSynthetic || (s.opts.MaxPriceAge > 0 && time.Since(price.Timestamp) > s.opts.MaxPriceAge) {
+			continue
+		}
+
+		key := price.Symbol + "/" + price.Quote
+		s.mu.Lock()
+		if queued, ok := s.pending[key]; !ok || !price.Timestamp.Before(queued.Timestamp) {
+			s.pending[key] = price
+		}
+		s.mu.Unlock()
+
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *webhookService) publishLoop() {
+	for range s.wake {
+		for {
+			price := s.nextPending()
+			if price == nil || s.ctx.Err() != nil {
+				break
+			}
+			if err := s.Publish(s.ctx, domain.EventPriceUpdated, price); err != nil {
+				log.Printf("⚠️  Webhook: не удалось поставить в очередь цену %s/%s: %v", price.Symbol, price.Quote, err)
+			}
+		}
+	}
+}
+
+func (s *webhookService) nextPending() *domain.CurrencyPrice {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, price := range s.pending {
+		delete(s.pending, key)
+		return price
+	}
+	return nil
+}
+
+func (s *webhookService) wakeDispatch() {
+	select {
+	case s.dispatch <- struct{}{}:
+	default:
+	}
+}
+
+func (s *webhookService) dispatchLoop() {
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.dispatchDue()
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.dispatch:
+		}
+	}
+}
+
+// dispatchDue отправляет доставки, время попытки которых наступило, пачками по Workers.
+// Пачка занимается на время двух таймаутов: если экземпляр упадёт посреди попытки,
+// доставку повторит другой.
+func (s *webhookService) dispatchDue() {
+	lease := 2*s.opts.Timeout + time.Minute
+
+	for s.ctx.Err() == nil {
+		deliveries, err := s.repo.ClaimDueDeliveries(s.ctx, time.Now(), lease, s.opts.Workers)
+		if err != nil {
+			if s.ctx.Err() == nil {
+				log.Printf("⚠️  Webhook: не удалось получить очередь доставок: %v", err)
+			}
+			return
+		}
+
+		endpoints := make(map[int64]*domain.WebhookEndpoint)
+		var wg sync.WaitGroup
+		for _, delivery := range deliveries {
+			endpoint, ok := endpoints[delivery.EndpointID]
+			if !ok {
+				endpoint, err = s.repo.GetEndpoint(s.ctx, delivery.EndpointID)
+				if err != nil {
+					// Получатель удалён вместе с доставками или база недоступна - попытка вернётся в очередь
+					continue
+				}
+				endpoints[delivery.EndpointID] = endpoint
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.deliver(endpoint, delivery)
+			}()
+		}
+		wg.Wait()
+
+		if len(deliveries) < s.opts.Workers {
+			return
+		}
+	}
+}
+
+// deliver выполняет одну попытку доставки и записывает её результат
+func (s *webhookService) deliver(endpoint *domain.WebhookEndpoint, delivery *domain.WebhookDelivery) {
+	ctx, cancel := context.WithTimeout(s.ctx, s.opts.Timeout)
+	statusCode, sendErr := s.sender.Send(ctx, endpoint, delivery)
+	cancel()
+
+	if s.ctx.Err() != nil {
+		// Попытку прервала остановка сервиса - не засчитываем её
+		return
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	switch {
+	case sendErr == nil:
+		delivery.Status = domain.DeliverySucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= s.opts.MaxAttempts:
+		delivery.Status = domain.DeliveryFailed
+		delivery.LastError = truncateError(sendErr)
+	default:
+		delivery.LastError = truncateError(sendErr)
+		delivery.NextAttemptAt = now.Add(domain.WebhookRetryDelay(delivery.Attempts, s.opts.RetryBase, s.opts.RetryMax))
+	}
+
+	if err := s.repo.UpdateDelivery(s.ctx, delivery); err != nil {
+		log.Printf("❌ Webhook: не удалось сохранить результат доставки #%d: %v", delivery.ID, err)
+		return
+	}
+
+	if sendErr != nil {
+		log.Printf("⚠️  Webhook #%d: доставка #%d (%s), попытка %d: %v",
+			endpoint.ID, delivery.ID, delivery.Event, delivery.Attempts, sendErr)
+	}
+
+	disabled, err := s.repo.RecordEndpointResult(s.ctx, endpoint.ID, sendErr == nil, s.opts.DisableAfter)
+	if err != nil {
+		log.Printf("⚠️  Webhook #%d: не удалось обновить счётчик ошибок: %v", endpoint.ID, err)
+		return
+	}
+	if disabled {
+		log.Printf("🚫 Webhook #%d (%s) отключён после %d ошибок доставки подряд", endpoint.ID, endpoint.URL, s.opts.DisableAfter)
+	}
+}
+
+// uniqueEvents убирает повторяющиеся события, сохраняя порядок
+func uniqueEvents(events []domain.WebhookEvent) []domain.WebhookEvent {
+	seen := make(map[domain.WebhookEvent]bool, len(events))
+	unique := make([]domain.WebhookEvent, 0, len(events))
+	for _, event := range events {
+		if !seen[event] {
+			seen[event] = true
+			unique = append(unique, event)
+		}
+	}
+	return unique
+}
+
+func truncateError(err error) string {
+	message := err.Error()
+	if len(message) > webhookErrorLimit {
+		message = message[:webhookErrorLimit]
+	}
+	return message
+}
+
+func randomHex(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// memWebhookRepo - WebhookRepository в памяти с той же логикой очереди и отключения, что и в базе
+type memWebhookRepo struct {
+	ports.WebhookRepository
+
+	mu         sync.Mutex
+	endpoints  map[int64]*domain.WebhookEndpoint
+	deliveries []*domain.WebhookDelivery
+}
+
+func newMemWebhookRepo(endpoints ...*domain.WebhookEndpoint) *memWebhookRepo {
+	repo := &memWebhookRepo{endpoints: make(map[int64]*domain.WebhookEndpoint)}
+	for _, endpoint := range endpoints {
+		repo.endpoints[endpoint.ID] = endpoint
+	}
+	return repo
+}
+
+func (r *memWebhookRepo) GetEndpoint(ctx context.Context, id int64) (*domain.WebhookEndpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	endpoint, ok := r.endpoints[id]
+	if !ok {
+		return nil, domain.ErrWebhookEndpointNotFound
+	}
+	copied := *endpoint
+	return &copied, nil
+}
+
+func (r *memWebhookRepo) ListEndpointsForEvent(ctx context.Context, event domain.WebhookEvent) ([]*domain.WebhookEndpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var endpoints []*domain.WebhookEndpoint
+	for _, endpoint := range r.endpoints {
+		if endpoint.Enabled && endpoint.Subscribes(event) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints, nil
+}
+
+func (r *memWebhookRepo) RecordEndpointResult(ctx context.Context, id int64, success bool, disableAfter int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	endpoint := r.endpoints[id]
+	if success {
+		endpoint.ConsecutiveFailures = 0
+		return false, nil
+	}
+	if !endpoint.Enabled {
+		return false, nil
+	}
+
+	endpoint.ConsecutiveFailures++
+	if disableAfter > 0 && endpoint.ConsecutiveFailures >= disableAfter {
+		now := time.Now()
+		endpoint.Enabled = false
+		endpoint.DisabledAt = &now
+		return true, nil
+	}
+	return false, nil
+}
+
+func (r *memWebhookRepo) CreateDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, delivery := range deliveries {
+		delivery.ID = int64(len(r.deliveries) + 1)
+		copied := *delivery
+		r.deliveries = append(r.deliveries, &copied)
+	}
+	return nil
+}
+
+func (r *memWebhookRepo) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var claimed []*domain.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if len(claimed) == limit {
+			break
+		}
+		if delivery.Status != domain.DeliveryPending || delivery.NextAttemptAt.After(now) || !r.endpoints[delivery.EndpointID].Enabled {
+			continue
+		}
+		delivery.NextAttemptAt = now.Add(lease)
+		copied := *delivery
+		claimed = append(claimed, &copied)
+	}
+	return claimed, nil
+}
+
+func (r *memWebhookRepo) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := *delivery
+	r.deliveries[delivery.ID-1] = &copied
+	return nil
+}
+
+// makeDue делает все ожидающие доставки готовыми к следующей попытке
+func (r *memWebhookRepo) makeDue() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, delivery := range r.deliveries {
+		delivery.NextAttemptAt = time.Now().Add(-time.Second)
+	}
+}
+
+func (r *memWebhookRepo) delivery(id int64) domain.WebhookDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return *r.deliveries[id-1]
+}
+
+// senderFunc - WebhookSender из функции
+type senderFunc func(endpoint *domain.WebhookEndpoint, delivery *domain.WebhookDelivery) (int, error)
+
+func (f senderFunc) Send(ctx context.Context, endpoint *domain.WebhookEndpoint, delivery *domain.WebhookDelivery) (int, error) {
+	return f(endpoint, delivery)
+}
+
+func newTestWebhookService(repo ports.WebhookRepository, sender ports.WebhookSender, opts WebhookOptions) *webhookService {
+	opts.Timeout = time.Second
+	return NewWebhookService(repo, sender, nil, opts).(*webhookService)
+}
+
+func TestWebhookDeliveryRetriesUntilFailed(t *testing.T) {
+	repo := newMemWebhookRepo(&domain.WebhookEndpoint{ID: 1, URL: "http://example.com/hook", Events: []domain.WebhookEvent{domain.EventCurrencyAdded}, Enabled: true})
+	sender := senderFunc(func(*domain.WebhookEndpoint, *domain.WebhookDelivery) (int, error) {
+		return 503, errors.New("HTTP 503")
+	})
+	service := newTestWebhookService(repo, sender, WebhookOptions{MaxAttempts: 3, RetryBase: time.Minute, RetryMax: time.Hour})
+
+	if err := service.Publish(context.Background(), domain.EventCurrencyAdded, map[string]string{"symbol": "BTC"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		started := time.Now()
+		service.dispatchDue()
+
+		delivery := repo.delivery(1)
+		if delivery.Attempts != attempt || delivery.LastStatusCode != 503 || delivery.LastError != "HTTP 503" {
+			t.Fatalf("attempt %d: got attempts=%d status=%d error=%q", attempt, delivery.Attempts, delivery.LastStatusCode, delivery.LastError)
+		}
+
+		if attempt < 3 {
+			// Пауза перед повтором удваивается: минута, затем две
+			want := domain.WebhookRetryDelay(attempt, time.Minute, time.Hour)
+			if delivery.Status != domain.DeliveryPending {
+				t.Fatalf("attempt %d: status = %s, want pending", attempt, delivery.Status)
+			}
+			if delay := delivery.NextAttemptAt.Sub(started); delay < want || delay > want+time.Second {
+				t.Errorf("attempt %d: next attempt in %v, want %v", attempt, delay, want)
+			}
+
+			// До наступления времени повтора доставка не отправляется
+			service.dispatchDue()
+			if repo.delivery(1).Attempts != attempt {
+				t.Fatalf("attempt %d: delivery retried before its time", attempt)
+			}
+			repo.makeDue()
+		}
+	}
+
+	if delivery := repo.delivery(1); delivery.Status != domain.DeliveryFailed {
+		t.Errorf("status after max attempts = %s, want failed", delivery.Status)
+	}
+
+	// Неудачная доставка больше не отправляется
+	repo.makeDue()
+	service.dispatchDue()
+	if attempts := repo.delivery(1).Attempts; attempts != 3 {
+		t.Errorf("attempts = %d after failure, want 3", attempts)
+	}
+}
+
+func TestWebhookEndpointAutoDisable(t *testing.T) {
+	endpoint := &domain.WebhookEndpoint{ID: 1, URL: "http://example.com/hook", Events: []domain.WebhookEvent{domain.EventCurrencyAdded}, Enabled: true}
+	repo := newMemWebhookRepo(endpoint)
+
+	var mu sync.Mutex
+	fail := true
+	sender := senderFunc(func(*domain.WebhookEndpoint, *domain.WebhookDelivery) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if fail {
+			return 500, errors.New("HTTP 500")
+		}
+		return 200, nil
+	})
+	service := newTestWebhookService(repo, sender, WebhookOptions{MaxAttempts: 10, RetryBase: time.Minute, RetryMax: time.Hour, DisableAfter: 3})
+
+	if err := service.Publish(context.Background(), domain.EventCurrencyAdded, "first"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Успех сбрасывает счётчик ошибок подряд
+	service.dispatchDue()
+	repo.makeDue()
+	mu.Lock()
+	fail = false
+	mu.Unlock()
+	service.dispatchDue()
+	if endpoint.ConsecutiveFailures != 0 || repo.delivery(1).Status != domain.DeliverySucceeded {
+		t.Fatalf("after success: failures=%d status=%s", endpoint.ConsecutiveFailures, repo.delivery(1).Status)
+	}
+
+	mu.Lock()
+	fail = true
+	mu.Unlock()
+	if err := service.Publish(context.Background(), domain.EventCurrencyAdded, "second"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		service.dispatchDue()
+		repo.makeDue()
+	}
+
+	if endpoint.Enabled || endpoint.DisabledAt == nil {
+		t.Fatalf("endpoint must be disabled after 3 failures in a row, failures=%d", endpoint.ConsecutiveFailures)
+	}
+
+	// Доставки отключённого получателя остаются в очереди и не отправляются
+	service.dispatchDue()
+	if delivery := repo.delivery(2); delivery.Attempts != 3 || delivery.Status != domain.DeliveryPending {
+		t.Errorf("delivery of disabled endpoint: attempts=%d status=%s, want 3 pending", delivery.Attempts, delivery.Status)
+	}
+
+	// Новые события отключённому получателю не ставятся в очередь
+	if err := service.Publish(context.Background(), domain.EventCurrencyAdded, "third"); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.deliveries) != 2 {
+		t.Errorf("got %d deliveries, want 2", len(repo.deliveries))
+	}
+}
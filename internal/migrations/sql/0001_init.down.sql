@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS currency_prices;
+DROP TABLE IF EXISTS currencies;
+DROP FUNCTION IF EXISTS update_updated_at_column();
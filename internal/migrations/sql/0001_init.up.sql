@@ -0,0 +1,37 @@
+-- Базовая схема: валюты и цены.
+-- IF NOT EXISTS позволяет принять базы, созданные раньше через AutoMigrate.
+CREATE TABLE IF NOT EXISTS currencies (
+    id BIGSERIAL PRIMARY KEY,
+    symbol VARCHAR(10) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_currencies_symbol ON currencies(symbol);
+
+CREATE TABLE IF NOT EXISTS currency_prices (
+    id BIGSERIAL PRIMARY KEY,
+    symbol VARCHAR(10) NOT NULL,
+    price NUMERIC NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_currency_prices_symbol ON currency_prices(symbol);
+CREATE INDEX IF NOT EXISTS idx_currency_prices_timestamp ON currency_prices(timestamp);
+CREATE INDEX IF NOT EXISTS idx_currency_prices_symbol_timestamp ON currency_prices(symbol, timestamp);
+
+-- Автоматическое обновление updated_at
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_currencies_updated_at ON currencies;
+CREATE TRIGGER trg_currencies_updated_at
+    BEFORE UPDATE ON currencies
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
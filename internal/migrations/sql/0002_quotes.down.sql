@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_currency_prices_quote;
+ALTER TABLE currency_prices DROP COLUMN IF EXISTS quote;
+ALTER TABLE currencies DROP COLUMN IF EXISTS quotes;
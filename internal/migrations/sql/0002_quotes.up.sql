@@ -0,0 +1,5 @@
+-- Котировки в валютах помимо USD
+ALTER TABLE currencies ADD COLUMN IF NOT EXISTS quotes TEXT[] NOT NULL DEFAULT '{USD}';
+
+ALTER TABLE currency_prices ADD COLUMN IF NOT EXISTS quote VARCHAR(10) NOT NULL DEFAULT 'USD';
+CREATE INDEX IF NOT EXISTS idx_currency_prices_quote ON currency_prices(quote);
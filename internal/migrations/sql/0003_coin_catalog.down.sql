@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS coin_id_overrides;
+DROP TABLE IF EXISTS coin_catalog;
@@ -0,0 +1,16 @@
+-- Каталог монет провайдера и ручные привязки тикеров
+CREATE TABLE IF NOT EXISTS coin_catalog (
+    coin_id VARCHAR(150) PRIMARY KEY,
+    symbol VARCHAR(50) NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_coin_catalog_symbol ON coin_catalog(symbol);
+
+CREATE TABLE IF NOT EXISTS coin_id_overrides (
+    symbol VARCHAR(50) PRIMARY KEY,
+    coin_id VARCHAR(150) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
@@ -0,0 +1,2 @@
+ALTER TABLE currency_prices DROP COLUMN IF EXISTS is_synthetic;
+ALTER TABLE currency_prices DROP COLUMN IF EXISTS source;
@@ -0,0 +1,3 @@
+-- Источник цены; синтетические цены не сохраняются, колонка фиксирует это явно
+ALTER TABLE currency_prices ADD COLUMN IF NOT EXISTS source VARCHAR(50) NOT NULL DEFAULT 'manual';
+ALTER TABLE currency_prices ADD COLUMN IF NOT EXISTS is_synthetic BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,18 @@
+-- Задачи загрузки истории цен
+CREATE TABLE IF NOT EXISTS backfill_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    symbol VARCHAR(10) NOT NULL,
+    quote VARCHAR(10) NOT NULL,
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    cursor TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    fetched_count BIGINT NOT NULL DEFAULT 0,
+    inserted_count BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_backfill_jobs_symbol ON backfill_jobs(symbol);
+CREATE INDEX IF NOT EXISTS idx_backfill_jobs_status ON backfill_jobs(status);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_currency_prices_unique_point;
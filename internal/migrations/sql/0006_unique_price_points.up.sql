@@ -0,0 +1,11 @@
+-- Удаляем накопившиеся дубликаты (остаётся последняя запись) и запрещаем новые
+DELETE FROM currency_prices older
+USING currency_prices newer
+WHERE older.symbol = newer.symbol
+  AND older.quote = newer.quote
+  AND older.timestamp = newer.timestamp
+  AND older.source = newer.source
+  AND older.id < newer.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_currency_prices_unique_point
+    ON currency_prices(symbol, quote, timestamp, source);
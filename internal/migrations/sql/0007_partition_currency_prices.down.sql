@@ -0,0 +1,33 @@
+-- Возврат к обычной таблице: данные всех секций копируются обратно
+ALTER TABLE currency_prices RENAME TO currency_prices_partitioned;
+DROP INDEX IF EXISTS idx_currency_prices_symbol;
+DROP INDEX IF EXISTS idx_currency_prices_quote;
+DROP INDEX IF EXISTS idx_currency_prices_timestamp;
+DROP INDEX IF EXISTS idx_currency_prices_symbol_timestamp;
+DROP INDEX IF EXISTS idx_currency_prices_unique_point;
+ALTER SEQUENCE currency_prices_id_seq OWNED BY NONE;
+
+CREATE TABLE currency_prices (
+    id BIGINT PRIMARY KEY DEFAULT nextval('currency_prices_id_seq'),
+    symbol VARCHAR(10) NOT NULL,
+    quote VARCHAR(10) NOT NULL DEFAULT 'USD',
+    price NUMERIC NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    source VARCHAR(50) NOT NULL DEFAULT 'manual',
+    is_synthetic BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ
+);
+
+ALTER SEQUENCE currency_prices_id_seq OWNED BY currency_prices.id;
+
+INSERT INTO currency_prices (id, symbol, quote, price, timestamp, source, is_synthetic, created_at)
+SELECT id, symbol, quote, price, timestamp, source, is_synthetic, created_at
+FROM currency_prices_partitioned;
+
+DROP TABLE currency_prices_partitioned;
+
+CREATE INDEX idx_currency_prices_symbol ON currency_prices(symbol);
+CREATE INDEX idx_currency_prices_quote ON currency_prices(quote);
+CREATE INDEX idx_currency_prices_timestamp ON currency_prices(timestamp);
+CREATE INDEX idx_currency_prices_symbol_timestamp ON currency_prices(symbol, timestamp);
+CREATE UNIQUE INDEX idx_currency_prices_unique_point ON currency_prices(symbol, quote, timestamp, source);
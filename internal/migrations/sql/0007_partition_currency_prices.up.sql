@@ -0,0 +1,58 @@
+-- Помесячное секционирование currency_prices по timestamp.
+-- Первичный ключ секционированной таблицы обязан включать ключ секционирования.
+ALTER TABLE currency_prices RENAME TO currency_prices_legacy;
+ALTER TABLE currency_prices_legacy DROP CONSTRAINT currency_prices_pkey;
+DROP INDEX IF EXISTS idx_currency_prices_symbol;
+DROP INDEX IF EXISTS idx_currency_prices_quote;
+DROP INDEX IF EXISTS idx_currency_prices_timestamp;
+DROP INDEX IF EXISTS idx_currency_prices_symbol_timestamp;
+DROP INDEX IF EXISTS idx_currency_prices_unique_point;
+ALTER SEQUENCE currency_prices_id_seq OWNED BY NONE;
+
+CREATE TABLE currency_prices (
+    id BIGINT NOT NULL DEFAULT nextval('currency_prices_id_seq'),
+    symbol VARCHAR(10) NOT NULL,
+    quote VARCHAR(10) NOT NULL DEFAULT 'USD',
+    price NUMERIC NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    source VARCHAR(50) NOT NULL DEFAULT 'manual',
+    is_synthetic BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ,
+    PRIMARY KEY (id, timestamp)
+) PARTITION BY RANGE (timestamp);
+
+ALTER SEQUENCE currency_prices_id_seq OWNED BY currency_prices.id;
+
+CREATE INDEX idx_currency_prices_symbol ON currency_prices(symbol);
+CREATE INDEX idx_currency_prices_quote ON currency_prices(quote);
+CREATE INDEX idx_currency_prices_timestamp ON currency_prices(timestamp);
+CREATE INDEX idx_currency_prices_symbol_timestamp ON currency_prices(symbol, timestamp);
+CREATE UNIQUE INDEX idx_currency_prices_unique_point ON currency_prices(symbol, quote, timestamp, source);
+
+-- Секция по умолчанию принимает цены за месяцы без своей секции (например, старую историю)
+CREATE TABLE currency_prices_default PARTITION OF currency_prices DEFAULT;
+
+-- Секции для уже накопленных данных и на два месяца вперёд
+DO $$
+DECLARE
+    month_start TIMESTAMPTZ;
+BEGIN
+    FOR month_start IN
+        SELECT generate_series(
+            date_trunc('month', COALESCE((SELECT MIN(timestamp) FROM currency_prices_legacy), NOW()) AT TIME ZONE 'UTC') AT TIME ZONE 'UTC',
+            date_trunc('month', NOW() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' + INTERVAL '2 months',
+            INTERVAL '1 month')
+    LOOP
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS %I PARTITION OF currency_prices FOR VALUES FROM (%L) TO (%L)',
+            'currency_prices_' || to_char(month_start AT TIME ZONE 'UTC', 'YYYY_MM'),
+            month_start,
+            month_start + INTERVAL '1 month');
+    END LOOP;
+END $$;
+
+INSERT INTO currency_prices (id, symbol, quote, price, timestamp, source, is_synthetic, created_at)
+SELECT id, symbol, quote, price, timestamp, source, is_synthetic, created_at
+FROM currency_prices_legacy;
+
+DROP TABLE currency_prices_legacy;
@@ -0,0 +1,3 @@
+DROP VIEW IF EXISTS price_history;
+DROP TABLE IF EXISTS price_rollups_daily;
+DROP TABLE IF EXISTS price_rollups_hourly;
@@ -0,0 +1,34 @@
+-- Агрегаты старых цен: часовые и дневные OHLC.
+-- first_time/last_time - время первой и последней цены в интервале, нужны для слияния агрегатов.
+CREATE TABLE price_rollups_hourly (
+    symbol VARCHAR(10) NOT NULL,
+    quote VARCHAR(10) NOT NULL,
+    bucket TIMESTAMPTZ NOT NULL,
+    open NUMERIC NOT NULL,
+    high NUMERIC NOT NULL,
+    low NUMERIC NOT NULL,
+    close NUMERIC NOT NULL,
+    count BIGINT NOT NULL,
+    first_time TIMESTAMPTZ NOT NULL,
+    last_time TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (symbol, quote, bucket)
+);
+
+CREATE INDEX idx_price_rollups_hourly_bucket ON price_rollups_hourly(bucket);
+
+CREATE TABLE price_rollups_daily (LIKE price_rollups_hourly INCLUDING DEFAULTS);
+ALTER TABLE price_rollups_daily ADD PRIMARY KEY (symbol, quote, bucket);
+CREATE INDEX idx_price_rollups_daily_bucket ON price_rollups_daily(bucket);
+
+-- История цен во всех разрешениях: сырые цены и цены закрытия агрегатов.
+-- Интервалы не пересекаются, так как сжатие удаляет исходные строки.
+CREATE VIEW price_history AS
+SELECT id, symbol, quote, price, timestamp, source, is_synthetic, created_at
+FROM currency_prices
+UNION ALL
+SELECT 0::BIGINT, symbol, quote, close, last_time, 'rollup_1h', FALSE, updated_at
+FROM price_rollups_hourly
+UNION ALL
+SELECT 0::BIGINT, symbol, quote, close, last_time, 'rollup_1d', FALSE, updated_at
+FROM price_rollups_daily;
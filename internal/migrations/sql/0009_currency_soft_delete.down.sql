@@ -0,0 +1,3 @@
+DELETE FROM currencies WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_currencies_deleted_at;
+ALTER TABLE currencies DROP COLUMN IF EXISTS deleted_at;
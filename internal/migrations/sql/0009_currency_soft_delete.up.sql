@@ -0,0 +1,3 @@
+-- Мягкое удаление валют: строка остаётся до окончания периода ожидания
+ALTER TABLE currencies ADD COLUMN deleted_at TIMESTAMPTZ;
+CREATE INDEX idx_currencies_deleted_at ON currencies(deleted_at);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_currencies_tags;
+
+ALTER TABLE currencies
+    DROP COLUMN IF EXISTS tracking,
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS provider_coin_id,
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS decimals;
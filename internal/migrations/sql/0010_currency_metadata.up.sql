@@ -0,0 +1,10 @@
+-- Метаданные валют для отображения и состояние отслеживания
+ALTER TABLE currencies
+    ADD COLUMN decimals SMALLINT NOT NULL DEFAULT 2,
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN provider_coin_id VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN tracking VARCHAR(10) NOT NULL DEFAULT 'active'
+        CONSTRAINT chk_currencies_tracking CHECK (tracking IN ('active', 'paused'));
+
+CREATE INDEX idx_currencies_tags ON currencies USING GIN (tags);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_currencies_created_at;
+DROP TABLE IF EXISTS currency_market_caps;
@@ -0,0 +1,9 @@
+-- Последняя известная капитализация валют в USD для сортировки списка.
+-- Хранится отдельно, чтобы частое обновление не трогало updated_at валюты.
+CREATE TABLE currency_market_caps (
+    symbol VARCHAR(10) PRIMARY KEY,
+    market_cap NUMERIC NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_currencies_created_at ON currencies(created_at);
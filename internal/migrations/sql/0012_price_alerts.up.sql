@@ -0,0 +1,36 @@
+-- Правила оповещений о ценах
+CREATE TABLE alert_rules (
+    id BIGSERIAL PRIMARY KEY,
+    symbol VARCHAR(10) NOT NULL,
+    quote VARCHAR(10) NOT NULL,
+    condition VARCHAR(20) NOT NULL
+        CONSTRAINT chk_alert_rules_condition CHECK (condition IN ('above', 'below', 'percent_change')),
+    threshold NUMERIC NOT NULL,
+    window_seconds BIGINT NOT NULL DEFAULT 0,
+    cooldown_seconds BIGINT NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    description TEXT NOT NULL DEFAULT '',
+    last_triggered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_alert_rules_symbol_quote ON alert_rules(symbol, quote) WHERE enabled;
+
+-- История срабатываний, удаляется вместе с правилом
+CREATE TABLE alert_triggers (
+    id BIGSERIAL PRIMARY KEY,
+    rule_id BIGINT NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+    symbol VARCHAR(10) NOT NULL,
+    quote VARCHAR(10) NOT NULL,
+    condition VARCHAR(20) NOT NULL,
+    threshold NUMERIC NOT NULL,
+    price NUMERIC NOT NULL,
+    price_time TIMESTAMPTZ NOT NULL,
+    reference_price NUMERIC,
+    change_percent NUMERIC,
+    triggered_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_alert_triggers_rule_id ON alert_triggers(rule_id, id);
+CREATE INDEX idx_alert_triggers_symbol ON alert_triggers(symbol, id);
@@ -0,0 +1,3 @@
+-- Правило уровня срабатывает при пересечении порога: после срабатывания оно взводится
+-- снова, только когда цена вернётся по другую сторону порога
+ALTER TABLE alert_rules ADD COLUMN armed BOOLEAN NOT NULL DEFAULT TRUE;
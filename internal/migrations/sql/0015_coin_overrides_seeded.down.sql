@@ -0,0 +1 @@
+DROP TABLE IF EXISTS coin_catalog_state;
@@ -0,0 +1,10 @@
+-- Отметка о том, что привязки тикеров по умолчанию уже добавлены. Без неё привязка,
+-- удалённая администратором, появлялась бы снова при каждом запуске.
+CREATE TABLE coin_catalog_state (
+    name VARCHAR(100) PRIMARY KEY,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- В существующих базах привязки по умолчанию уже добавлялись при запусках
+INSERT INTO coin_catalog_state (name)
+SELECT 'overrides_seeded' WHERE EXISTS (SELECT 1 FROM coin_id_overrides);
@@ -0,0 +1,23 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// CatalogRefresher периодически перезагружает каталог монет провайдера
+type CatalogRefresher struct {
+	catalog ports.CoinCatalogService
+}
+
+func NewCatalogRefresher(catalog ports.CoinCatalogService) *CatalogRefresher {
+	return &CatalogRefresher{catalog: catalog}
+}
+
+func (r *CatalogRefresher) Refresh(ctx context.Context) {
+	if err := r.catalog.RefreshCatalog(ctx); err != nil {
+		log.Printf("❌ Не удалось обновить каталог монет: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// CurrencyPurger окончательно удаляет валюты, удалённые раньше периода ожидания.
+// При withPrices вместе с валютой удаляется её история цен, иначе история
+// остаётся и снова становится доступной, если валюту добавят заново.
+type CurrencyPurger struct {
+	currencyRepo ports.CurrencyRepository
+	grace        time.Duration
+	withPrices   bool
+}
+
+func NewCurrencyPurger(currencyRepo ports.CurrencyRepository, grace time.Duration, withPrices bool) *CurrencyPurger {
+	return &CurrencyPurger{
+		currencyRepo: currencyRepo,
+		grace:        grace,
+		withPrices:   withPrices,
+	}
+}
+
+func (p *CurrencyPurger) Purge(ctx context.Context) {
+	removed, err := p.currencyRepo.ListRemoved(ctx, time.Now().Add(-p.grace))
+	if err != nil {
+		log.Printf("❌ Не удалось получить удалённые валюты: %v", err)
+		return
+	}
+
+	for _, currency := range removed {
+		if err := p.currencyRepo.Purge(ctx, currency.Symbol, p.withPrices); err != nil {
+			log.Printf("❌ Не удалось очистить валюту %s: %v", currency.Symbol, err)
+			continue
+		}
+		log.Printf("🗑️  Валюта %s окончательно удалена (история цен удалена: %t)", currency.Symbol, p.withPrices)
+	}
+}
@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// PartitionManager заранее создаёт секции таблицы цен и удаляет цены старше срока хранения
+type PartitionManager struct {
+	maintenanceRepo ports.PriceMaintenanceRepository
+	retention       domain.RetentionPolicy
+	monthsAhead     int
+}
+
+func NewPartitionManager(
+	maintenanceRepo ports.PriceMaintenanceRepository,
+	retention domain.RetentionPolicy,
+	monthsAhead int,
+) *PartitionManager {
+	return &PartitionManager{
+		maintenanceRepo: maintenanceRepo,
+		retention:       retention,
+		monthsAhead:     max(monthsAhead, 1),
+	}
+}
+
+func (m *PartitionManager) Maintain(ctx context.Context) {
+	now := time.Now().UTC()
+
+	if err := m.maintenanceRepo.EnsurePricePartitions(ctx, now, now.AddDate(0, m.monthsAhead, 0)); err != nil {
+		log.Printf("❌ Не удалось создать секции цен: %v", err)
+	}
+	// Цены из секции по умолчанию раскладываются по своим секциям, иначе они не удалились бы по сроку хранения
+	if err := m.maintenanceRepo.PartitionDefaultPrices(ctx); err != nil {
+		log.Printf("❌ Не удалось разложить цены из секции по умолчанию: %v", err)
+	}
+
+	m.dropExpiredPartitions(ctx, now)
+	m.deleteExpiredPrices(ctx, now)
+}
+
+// dropExpiredPartitions удаляет секции, все цены которых старше самого длинного срока хранения
+func (m *PartitionManager) dropExpiredPartitions(ctx context.Context, now time.Time) {
+	longest, limited := m.retention.Longest()
+	if !limited {
+		return
+	}
+
+	partitions, err := m.maintenanceRepo.ListPricePartitions(ctx)
+	if err != nil {
+		log.Printf("❌ Не удалось получить секции цен: %v", err)
+		return
+	}
+
+	cutoff := now.Add(-longest)
+	for _, partition := range partitions {
+		if partition.To.After(cutoff) {
+			continue
+		}
+		if err := m.maintenanceRepo.DropPricePartition(ctx, partition); err != nil {
+			log.Printf("❌ Не удалось удалить секцию %s: %v", partition.Name, err)
+			continue
+		}
+		log.Printf("🗑️  Удалена устаревшая секция цен %s", partition.Name)
+	}
+}
+
+// deleteExpiredPrices удаляет цены старше срока хранения своей валюты
+// из секций, которые целиком удалить ещё нельзя
+func (m *PartitionManager) deleteExpiredPrices(ctx context.Context, now time.Time) {
+	var deleted int64
+	overridden := make([]string, 0, len(m.retention.PerSymbol))
+
+	for symbol, retention := range m.retention.PerSymbol {
+		overridden = append(overridden, symbol)
+		if retention <= 0 {
+			continue
+		}
+
+		count, err := m.maintenanceRepo.DeleteSymbolPricesBefore(ctx, symbol, now.Add(-retention))
+		if err != nil {
+			log.Printf("❌ Не удалось удалить устаревшие цены %s: %v", symbol, err)
+			continue
+		}
+		deleted += count
+	}
+
+	if m.retention.Default > 0 {
+		count, err := m.maintenanceRepo.DeletePricesBeforeExcept(ctx, now.Add(-m.retention.Default), overridden)
+		if err != nil {
+			log.Printf("❌ Не удалось удалить устаревшие цены: %v", err)
+		}
+		deleted += count
+	}
+
+	if deleted > 0 {
+		log.Printf("🧹 Удалено %d цен старше срока хранения", deleted)
+	}
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/domain"
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// PriceCollector периодически запрашивает цены всех отслеживаемых валют и сохраняет их.
+// Приостановленные валюты пропускаются.
+type PriceCollector struct {
+	currencyRepo  ports.CurrencyRepository
+	priceRepo     ports.PriceRepository
+	priceProvider ports.ExternalPriceProvider
+}
+
+func NewPriceCollector(
+	currencyRepo ports.CurrencyRepository,
+	priceRepo ports.PriceRepository,
+	priceProvider ports.ExternalPriceProvider,
+) *PriceCollector {
+	return &PriceCollector{
+		currencyRepo:  currencyRepo,
+		priceRepo:     priceRepo,
+		priceProvider: priceProvider,
+	}
+}
+
+func (c *PriceCollector) Collect(ctx context.Context) {
+	currencies, err := c.currencyRepo.List(ctx)
+	if err != nil {
+		log.Printf("❌ Сборщик цен: не удалось получить список валют: %v", err)
+		return
+	}
+
+	total := 0
+	var prices []*domain.CurrencyPrice
+	marketCaps := make(map[string]domain.Decimal)
+	for _, currency := range currencies {
+		if currency.IsPaused() {
+			continue
+		}
+		for _, quote := range currency.Quotes {
+			if ctx.Err() != nil {
+				return
+			}
+			total++
+
+			price, err := c.priceProvider.GetCurrentPrice(ctx, currency.Symbol, quote)
+			if err != nil {
+				log.Printf("⚠️  Сборщик цен: нет цены для %s/%s: %v", currency.Symbol, quote, err)
+				continue
+			}
+			if price.IsSynthetic {
+				log.Printf("⚠️  Сборщик цен: для %s/%s доступна только синтетическая цена, пропускаем", currency.Symbol, quote)
+				continue
+			}
+
+			prices = append(prices, price)
+			if quote == domain.DefaultQuote && price.MarketCap.Sign() > 0 {
+				marketCaps[currency.Symbol] = price.MarketCap
+			}
+		}
+	}
+
+	if len(prices) > 0 {
+		if err := c.priceRepo.SavePrices(ctx, prices); err != nil {
+			log.Printf("❌ Сборщик цен: не удалось сохранить цены: %v", err)
+			return
+		}
+	}
+
+	if err := c.currencyRepo.UpdateMarketCaps(ctx, marketCaps); err != nil {
+		log.Printf("⚠️  Сборщик цен: не удалось обновить капитализацию: %v", err)
+	}
+
+	log.Printf("📈 Сборщик цен: сохранено %d из %d цен", len(prices), total)
+}
@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// RollupCompactor сворачивает старые цены в часовые и дневные агрегаты.
+// Нулевой возраст отключает соответствующий уровень.
+type RollupCompactor struct {
+	maintenanceRepo ports.PriceMaintenanceRepository
+	hourlyAfter     time.Duration
+	dailyAfter      time.Duration
+}
+
+func NewRollupCompactor(maintenanceRepo ports.PriceMaintenanceRepository, hourlyAfter, dailyAfter time.Duration) *RollupCompactor {
+	if hourlyAfter > 0 && dailyAfter > 0 && dailyAfter < hourlyAfter {
+		log.Printf("⚠️  Дневные агрегаты (%v) не могут начинаться раньше часовых (%v), выравниваем", dailyAfter, hourlyAfter)
+		dailyAfter = hourlyAfter
+	}
+
+	return &RollupCompactor{
+		maintenanceRepo: maintenanceRepo,
+		hourlyAfter:     hourlyAfter,
+		dailyAfter:      dailyAfter,
+	}
+}
+
+func (c *RollupCompactor) Compact(ctx context.Context) {
+	now := time.Now().UTC()
+
+	// Границы выравниваются по началу часа/суток, чтобы агрегаты покрывали интервалы целиком
+	if c.hourlyAfter > 0 {
+		before := now.Add(-c.hourlyAfter).Truncate(time.Hour)
+		rows, err := c.maintenanceRepo.CompactToHourly(ctx, before)
+		if err != nil {
+			log.Printf("❌ Не удалось свернуть цены в часовые агрегаты: %v", err)
+			return
+		}
+		if rows > 0 {
+			log.Printf("🗜️  Цены до %s свёрнуты в %d часовых агрегатов", before.Format(time.RFC3339), rows)
+		}
+	}
+
+	if c.dailyAfter > 0 {
+		before := now.Add(-c.dailyAfter).Truncate(24 * time.Hour)
+		rows, err := c.maintenanceRepo.CompactToDaily(ctx, before, c.hourlyAfter <= 0)
+		if err != nil {
+			log.Printf("❌ Не удалось свернуть цены в дневные агрегаты: %v", err)
+			return
+		}
+		if rows > 0 {
+			log.Printf("🗜️  Цены до %s свёрнуты в %d дневных агрегатов", before.Format(time.RFC3339), rows)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Task - периодическая задача планировщика
+type Task func(ctx context.Context)
+
+type job struct {
+	name     string
+	interval time.Duration
+	jitter   time.Duration
+	task     Task
+}
+
+// Scheduler запускает зарегистрированные задачи с заданным интервалом и джиттером
+type Scheduler struct {
+	jobs    []job
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	started bool
+}
+
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Add регистрирует задачу. Задачи добавляются до вызова Start.
+// Задача с неположительным интервалом не регистрируется: иначе она выполнялась бы без пауз.
+func (s *Scheduler) Add(name string, interval, jitter time.Duration, task Task) {
+	if interval <= 0 {
+		log.Printf("⚠️  Задача %s не запущена: интервал должен быть положительным, получено %v", name, interval)
+		return
+	}
+
+	s.jobs = append(s.jobs, job{
+		name:     name,
+		interval: interval,
+		jitter:   jitter,
+		task:     task,
+	})
+}
+
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go func(j job) {
+			defer s.wg.Done()
+			s.run(ctx, j)
+		}(j)
+	}
+}
+
+// Stop останавливает все задачи и дожидается завершения текущих запусков
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	if !s.started {
+		s.mu.Unlock()
+		return
+	}
+	s.started = false
+	s.cancel()
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context, j job) {
+	log.Printf("⏱️  Задача %s запущена (интервал %v, джиттер %v)", j.name, j.interval, j.jitter)
+
+	for {
+		j.task(ctx)
+
+		timer := time.NewTimer(nextDelay(j.interval, j.jitter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			log.Printf("🛑 Задача %s остановлена", j.name)
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func nextDelay(interval, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return interval
+	}
+	return interval + rand.N(jitter)
+}
@@ -0,0 +1,34 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/kk7453603/RybakovTestGo/internal/core/ports"
+)
+
+// WebhookLogCleaner удаляет из журнала завершённые доставки webhook старше срока хранения
+type WebhookLogCleaner struct {
+	webhookRepo ports.WebhookRepository
+	retention   time.Duration
+}
+
+func NewWebhookLogCleaner(webhookRepo ports.WebhookRepository, retention time.Duration) *WebhookLogCleaner {
+	return &WebhookLogCleaner{
+		webhookRepo: webhookRepo,
+		retention:   retention,
+	}
+}
+
+func (c *WebhookLogCleaner) Clean(ctx context.Context) {
+	deleted, err := c.webhookRepo.DeleteFinishedDeliveries(ctx, time.Now().Add(-c.retention))
+	if err != nil {
+		log.Printf("❌ Не удалось очистить журнал доставок webhook: %v", err)
+		return
+	}
+
+	if deleted > 0 {
+		log.Printf("🗑️  Удалено %d записей журнала доставок webhook", deleted)
+	}
+}
@@ -10,7 +10,9 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -24,14 +26,356 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Состояние сбора цен криптовалюты
+type TrackingState int32
+
+const (
+	TrackingState_TRACKING_STATE_UNSPECIFIED TrackingState = 0
+	TrackingState_TRACKING_STATE_ACTIVE      TrackingState = 1
+	// Валюта остаётся в списке, но сборщик не запрашивает её цены
+	TrackingState_TRACKING_STATE_PAUSED TrackingState = 2
+)
+
+// Enum value maps for TrackingState.
+var (
+	TrackingState_name = map[int32]string{
+		0: "TRACKING_STATE_UNSPECIFIED",
+		1: "TRACKING_STATE_ACTIVE",
+		2: "TRACKING_STATE_PAUSED",
+	}
+	TrackingState_value = map[string]int32{
+		"TRACKING_STATE_UNSPECIFIED": 0,
+		"TRACKING_STATE_ACTIVE":      1,
+		"TRACKING_STATE_PAUSED":      2,
+	}
+)
+
+func (x TrackingState) Enum() *TrackingState {
+	p := new(TrackingState)
+	*p = x
+	return p
+}
+
+func (x TrackingState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TrackingState) Descriptor() protoreflect.EnumDescriptor {
+	return file_service_proto_enumTypes[0].Descriptor()
+}
+
+func (TrackingState) Type() protoreflect.EnumType {
+	return &file_service_proto_enumTypes[0]
+}
+
+func (x TrackingState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TrackingState.Descriptor instead.
+func (TrackingState) EnumDescriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{0}
+}
+
+// Способ выбора цены на момент времени
+type LookupMode int32
+
+const (
+	LookupMode_LOOKUP_MODE_UNSPECIFIED LookupMode = 0
+	// Последняя цена не позже запрошенного момента
+	LookupMode_LOOKUP_MODE_PREVIOUS LookupMode = 1
+	// Первая цена не раньше запрошенного момента
+	LookupMode_LOOKUP_MODE_NEXT LookupMode = 2
+	// Ближайшая по времени цена
+	LookupMode_LOOKUP_MODE_NEAREST LookupMode = 3
+	// Линейная интерполяция между предыдущей и следующей ценами
+	LookupMode_LOOKUP_MODE_INTERPOLATE LookupMode = 4
+)
+
+// Enum value maps for LookupMode.
+var (
+	LookupMode_name = map[int32]string{
+		0: "LOOKUP_MODE_UNSPECIFIED",
+		1: "LOOKUP_MODE_PREVIOUS",
+		2: "LOOKUP_MODE_NEXT",
+		3: "LOOKUP_MODE_NEAREST",
+		4: "LOOKUP_MODE_INTERPOLATE",
+	}
+	LookupMode_value = map[string]int32{
+		"LOOKUP_MODE_UNSPECIFIED": 0,
+		"LOOKUP_MODE_PREVIOUS":    1,
+		"LOOKUP_MODE_NEXT":        2,
+		"LOOKUP_MODE_NEAREST":     3,
+		"LOOKUP_MODE_INTERPOLATE": 4,
+	}
+)
+
+func (x LookupMode) Enum() *LookupMode {
+	p := new(LookupMode)
+	*p = x
+	return p
+}
+
+func (x LookupMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LookupMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_service_proto_enumTypes[1].Descriptor()
+}
+
+func (LookupMode) Type() protoreflect.EnumType {
+	return &file_service_proto_enumTypes[1]
+}
+
+func (x LookupMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LookupMode.Descriptor instead.
+func (LookupMode) EnumDescriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{1}
+}
+
+// Порядок сортировки по времени
+type SortOrder int32
+
+const (
+	SortOrder_SORT_ORDER_UNSPECIFIED SortOrder = 0
+	SortOrder_SORT_ORDER_DESC        SortOrder = 1
+	SortOrder_SORT_ORDER_ASC         SortOrder = 2
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_UNSPECIFIED",
+		1: "SORT_ORDER_DESC",
+		2: "SORT_ORDER_ASC",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_UNSPECIFIED": 0,
+		"SORT_ORDER_DESC":        1,
+		"SORT_ORDER_ASC":         2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_service_proto_enumTypes[2].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_service_proto_enumTypes[2]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{2}
+}
+
+// Условие срабатывания правила оповещения
+type AlertCondition int32
+
+const (
+	AlertCondition_ALERT_CONDITION_UNSPECIFIED AlertCondition = 0
+	// Цена не ниже порога
+	AlertCondition_ALERT_CONDITION_ABOVE AlertCondition = 1
+	// Цена не выше порога
+	AlertCondition_ALERT_CONDITION_BELOW AlertCondition = 2
+	// Изменение цены за window не меньше threshold процентов в любую сторону
+	AlertCondition_ALERT_CONDITION_PERCENT_CHANGE AlertCondition = 3
+)
+
+// Enum value maps for AlertCondition.
+var (
+	AlertCondition_name = map[int32]string{
+		0: "ALERT_CONDITION_UNSPECIFIED",
+		1: "ALERT_CONDITION_ABOVE",
+		2: "ALERT_CONDITION_BELOW",
+		3: "ALERT_CONDITION_PERCENT_CHANGE",
+	}
+	AlertCondition_value = map[string]int32{
+		"ALERT_CONDITION_UNSPECIFIED":    0,
+		"ALERT_CONDITION_ABOVE":          1,
+		"ALERT_CONDITION_BELOW":          2,
+		"ALERT_CONDITION_PERCENT_CHANGE": 3,
+	}
+)
+
+func (x AlertCondition) Enum() *AlertCondition {
+	p := new(AlertCondition)
+	*p = x
+	return p
+}
+
+func (x AlertCondition) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlertCondition) Descriptor() protoreflect.EnumDescriptor {
+	return file_service_proto_enumTypes[3].Descriptor()
+}
+
+func (AlertCondition) Type() protoreflect.EnumType {
+	return &file_service_proto_enumTypes[3]
+}
+
+func (x AlertCondition) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlertCondition.Descriptor instead.
+func (AlertCondition) EnumDescriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{3}
+}
+
+// Тип события webhook
+type WebhookEvent int32
+
+const (
+	WebhookEvent_WEBHOOK_EVENT_UNSPECIFIED WebhookEvent = 0
+	// Сохранена новая цена (price.updated)
+	WebhookEvent_WEBHOOK_EVENT_PRICE_UPDATED WebhookEvent = 1
+	// Валюта добавлена или восстановлена (currency.added)
+	WebhookEvent_WEBHOOK_EVENT_CURRENCY_ADDED WebhookEvent = 2
+	// Валюта удалена (currency.removed)
+	WebhookEvent_WEBHOOK_EVENT_CURRENCY_REMOVED WebhookEvent = 3
+)
+
+// Enum value maps for WebhookEvent.
+var (
+	WebhookEvent_name = map[int32]string{
+		0: "WEBHOOK_EVENT_UNSPECIFIED",
+		1: "WEBHOOK_EVENT_PRICE_UPDATED",
+		2: "WEBHOOK_EVENT_CURRENCY_ADDED",
+		3: "WEBHOOK_EVENT_CURRENCY_REMOVED",
+	}
+	WebhookEvent_value = map[string]int32{
+		"WEBHOOK_EVENT_UNSPECIFIED":      0,
+		"WEBHOOK_EVENT_PRICE_UPDATED":    1,
+		"WEBHOOK_EVENT_CURRENCY_ADDED":   2,
+		"WEBHOOK_EVENT_CURRENCY_REMOVED": 3,
+	}
+)
+
+func (x WebhookEvent) Enum() *WebhookEvent {
+	p := new(WebhookEvent)
+	*p = x
+	return p
+}
+
+func (x WebhookEvent) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookEvent) Descriptor() protoreflect.EnumDescriptor {
+	return file_service_proto_enumTypes[4].Descriptor()
+}
+
+func (WebhookEvent) Type() protoreflect.EnumType {
+	return &file_service_proto_enumTypes[4]
+}
+
+func (x WebhookEvent) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookEvent.Descriptor instead.
+func (WebhookEvent) EnumDescriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{4}
+}
+
+// Статус доставки события
+type WebhookDeliveryStatus int32
+
+const (
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED WebhookDeliveryStatus = 0
+	// Ожидает первой или повторной попытки
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING   WebhookDeliveryStatus = 1
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_SUCCEEDED WebhookDeliveryStatus = 2
+	// Попытки исчерпаны
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED WebhookDeliveryStatus = 3
+)
+
+// Enum value maps for WebhookDeliveryStatus.
+var (
+	WebhookDeliveryStatus_name = map[int32]string{
+		0: "WEBHOOK_DELIVERY_STATUS_UNSPECIFIED",
+		1: "WEBHOOK_DELIVERY_STATUS_PENDING",
+		2: "WEBHOOK_DELIVERY_STATUS_SUCCEEDED",
+		3: "WEBHOOK_DELIVERY_STATUS_FAILED",
+	}
+	WebhookDeliveryStatus_value = map[string]int32{
+		"WEBHOOK_DELIVERY_STATUS_UNSPECIFIED": 0,
+		"WEBHOOK_DELIVERY_STATUS_PENDING":     1,
+		"WEBHOOK_DELIVERY_STATUS_SUCCEEDED":   2,
+		"WEBHOOK_DELIVERY_STATUS_FAILED":      3,
+	}
+)
+
+func (x WebhookDeliveryStatus) Enum() *WebhookDeliveryStatus {
+	p := new(WebhookDeliveryStatus)
+	*p = x
+	return p
+}
+
+func (x WebhookDeliveryStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookDeliveryStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_service_proto_enumTypes[5].Descriptor()
+}
+
+func (WebhookDeliveryStatus) Type() protoreflect.EnumType {
+	return &file_service_proto_enumTypes[5]
+}
+
+func (x WebhookDeliveryStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookDeliveryStatus.Descriptor instead.
+func (WebhookDeliveryStatus) EnumDescriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{5}
+}
+
 // Модель криптовалюты
 type Currency struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Symbol    string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Name      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Валюты котировки, в которых отслеживается цена (USD, EUR, ...)
+	Quotes []string `protobuf:"bytes,6,rep,name=quotes,proto3" json:"quotes,omitempty"`
+	// Время удаления, заполнено только у удалённых криптовалют
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Число знаков после запятой при отображении цены
+	Decimals int32 `protobuf:"varint,8,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	// Теги/категории: l1, defi, stablecoin, ...
+	Tags []string `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Идентификатор монеты у провайдера (только чтение)
+	ProviderCoinId string        `protobuf:"bytes,10,opt,name=provider_coin_id,json=providerCoinId,proto3" json:"provider_coin_id,omitempty"`
+	Description    string        `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
+	Tracking       TrackingState `protobuf:"varint,12,opt,name=tracking,proto3,enum=currency.v1.TrackingState" json:"tracking,omitempty"`
+	// Последняя известная капитализация в USD (только чтение), пусто - неизвестна
+	MarketCap     *Decimal `protobuf:"bytes,13,opt,name=market_cap,json=marketCap,proto3" json:"market_cap,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -101,20 +445,151 @@ func (x *Currency) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Currency) GetQuotes() []string {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+func (x *Currency) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Currency) GetDecimals() int32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *Currency) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Currency) GetProviderCoinId() string {
+	if x != nil {
+		return x.ProviderCoinId
+	}
+	return ""
+}
+
+func (x *Currency) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Currency) GetTracking() TrackingState {
+	if x != nil {
+		return x.Tracking
+	}
+	return TrackingState_TRACKING_STATE_UNSPECIFIED
+}
+
+func (x *Currency) GetMarketCap() *Decimal {
+	if x != nil {
+		return x.MarketCap
+	}
+	return nil
+}
+
+// Десятичное число без потери точности
+type Decimal struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Точное значение строкой, например "0.000012345678"
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// Целая часть
+	Units int64 `protobuf:"varint,2,opt,name=units,proto3" json:"units,omitempty"`
+	// Дробная часть в миллиардных долях, знак совпадает с units
+	Nanos         int32 `protobuf:"varint,3,opt,name=nanos,proto3" json:"nanos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Decimal) Reset() {
+	*x = Decimal{}
+	mi := &file_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Decimal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decimal) ProtoMessage() {}
+
+func (x *Decimal) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decimal.ProtoReflect.Descriptor instead.
+func (*Decimal) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Decimal) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Decimal) GetUnits() int64 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *Decimal) GetNanos() int32 {
+	if x != nil {
+		return x.Nanos
+	}
+	return 0
+}
+
 // Модель цены криптовалюты
 type CurrencyPrice struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Symbol string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Устаревшее приближённое значение, используйте price_value
+	Price     float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Валюта котировки
+	Quote string `protobuf:"bytes,5,opt,name=quote,proto3" json:"quote,omitempty"`
+	// Точное значение цены
+	PriceValue *Decimal `protobuf:"bytes,6,opt,name=price_value,json=priceValue,proto3" json:"price_value,omitempty"`
+	// Провайдеры, по которым рассчитана консенсусная цена
+	Sources []string `protobuf:"bytes,7,rep,name=sources,proto3" json:"sources,omitempty"`
+	// Источник цены: имя провайдера, consensus, fallback или manual
+	Source string `protobuf:"bytes,8,opt,name=source,proto3" json:"source,omitempty"`
+	// Синтетическая цена (fallback) - не настоящая котировка и не сохраняется в истории
+	IsSynthetic   bool `protobuf:"varint,9,opt,name=is_synthetic,json=isSynthetic,proto3" json:"is_synthetic,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CurrencyPrice) Reset() {
 	*x = CurrencyPrice{}
-	mi := &file_service_proto_msgTypes[1]
+	mi := &file_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -126,7 +601,7 @@ func (x *CurrencyPrice) String() string {
 func (*CurrencyPrice) ProtoMessage() {}
 
 func (x *CurrencyPrice) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[1]
+	mi := &file_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -139,61 +614,2881 @@ func (x *CurrencyPrice) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CurrencyPrice.ProtoReflect.Descriptor instead.
 func (*CurrencyPrice) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{1}
+	return file_service_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CurrencyPrice) GetId() int64 {
 	if x != nil {
-		return x.Id
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CurrencyPrice) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CurrencyPrice) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CurrencyPrice) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *CurrencyPrice) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *CurrencyPrice) GetPriceValue() *Decimal {
+	if x != nil {
+		return x.PriceValue
+	}
+	return nil
+}
+
+func (x *CurrencyPrice) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *CurrencyPrice) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *CurrencyPrice) GetIsSynthetic() bool {
+	if x != nil {
+		return x.IsSynthetic
+	}
+	return false
+}
+
+// Запрос на добавление криптовалюты
+type AddCurrencyRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Symbol string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Name   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Котировки для отслеживания. Если не указаны - используются котировки по умолчанию
+	Quotes []string `protobuf:"bytes,3,rep,name=quotes,proto3" json:"quotes,omitempty"`
+	// Число знаков после запятой при отображении цены, по умолчанию 2
+	Decimals    *int32   `protobuf:"varint,4,opt,name=decimals,proto3,oneof" json:"decimals,omitempty"`
+	Tags        []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Description string   `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	// По умолчанию TRACKING_STATE_ACTIVE
+	Tracking      TrackingState `protobuf:"varint,7,opt,name=tracking,proto3,enum=currency.v1.TrackingState" json:"tracking,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCurrencyRequest) Reset() {
+	*x = AddCurrencyRequest{}
+	mi := &file_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCurrencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCurrencyRequest) ProtoMessage() {}
+
+func (x *AddCurrencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCurrencyRequest.ProtoReflect.Descriptor instead.
+func (*AddCurrencyRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AddCurrencyRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AddCurrencyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddCurrencyRequest) GetQuotes() []string {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+func (x *AddCurrencyRequest) GetDecimals() int32 {
+	if x != nil && x.Decimals != nil {
+		return *x.Decimals
+	}
+	return 0
+}
+
+func (x *AddCurrencyRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *AddCurrencyRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AddCurrencyRequest) GetTracking() TrackingState {
+	if x != nil {
+		return x.Tracking
+	}
+	return TrackingState_TRACKING_STATE_UNSPECIFIED
+}
+
+// Ответ с информацией о криптовалюте
+type CurrencyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      *Currency              `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CurrencyResponse) Reset() {
+	*x = CurrencyResponse{}
+	mi := &file_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrencyResponse) ProtoMessage() {}
+
+func (x *CurrencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrencyResponse.ProtoReflect.Descriptor instead.
+func (*CurrencyResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CurrencyResponse) GetCurrency() *Currency {
+	if x != nil {
+		return x.Currency
+	}
+	return nil
+}
+
+// Запрос на удаление криптовалюты
+type RemoveCurrencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCurrencyRequest) Reset() {
+	*x = RemoveCurrencyRequest{}
+	mi := &file_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCurrencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCurrencyRequest) ProtoMessage() {}
+
+func (x *RemoveCurrencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCurrencyRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCurrencyRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RemoveCurrencyRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+// Запрос на изменение криптовалюты
+type UpdateCurrencyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Символ определяет изменяемую валюту и сам не меняется
+	Currency *Currency `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Изменяемые поля: name, quotes, decimals, tags, description, tracking. Если не задан - обновляются все заполненные поля,
+	// поэтому обнулить decimals или очистить tags можно только с явной маской
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCurrencyRequest) Reset() {
+	*x = UpdateCurrencyRequest{}
+	mi := &file_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCurrencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCurrencyRequest) ProtoMessage() {}
+
+func (x *UpdateCurrencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCurrencyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCurrencyRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateCurrencyRequest) GetCurrency() *Currency {
+	if x != nil {
+		return x.Currency
+	}
+	return nil
+}
+
+func (x *UpdateCurrencyRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// Запрос на восстановление криптовалюты
+type RestoreCurrencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreCurrencyRequest) Reset() {
+	*x = RestoreCurrencyRequest{}
+	mi := &file_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreCurrencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreCurrencyRequest) ProtoMessage() {}
+
+func (x *RestoreCurrencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreCurrencyRequest.ProtoReflect.Descriptor instead.
+func (*RestoreCurrencyRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RestoreCurrencyRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+// Запрос цены криптовалюты
+type GetCurrencyPriceRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Symbol string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Момент времени; если не задан - последняя известная цена
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Валюта котировки, по умолчанию USD
+	Quote string `protobuf:"bytes,3,opt,name=quote,proto3" json:"quote,omitempty"`
+	// Способ выбора цены на момент timestamp, по умолчанию LOOKUP_MODE_PREVIOUS
+	Mode LookupMode `protobuf:"varint,4,opt,name=mode,proto3,enum=currency.v1.LookupMode" json:"mode,omitempty"`
+	// Наибольшее допустимое расстояние до использованной цены; если не задано - без ограничений
+	MaxStaleness  *durationpb.Duration `protobuf:"bytes,5,opt,name=max_staleness,json=maxStaleness,proto3" json:"max_staleness,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrencyPriceRequest) Reset() {
+	*x = GetCurrencyPriceRequest{}
+	mi := &file_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrencyPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrencyPriceRequest) ProtoMessage() {}
+
+func (x *GetCurrencyPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrencyPriceRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrencyPriceRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetCurrencyPriceRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetCurrencyPriceRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *GetCurrencyPriceRequest) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *GetCurrencyPriceRequest) GetMode() LookupMode {
+	if x != nil {
+		return x.Mode
+	}
+	return LookupMode_LOOKUP_MODE_UNSPECIFIED
+}
+
+func (x *GetCurrencyPriceRequest) GetMaxStaleness() *durationpb.Duration {
+	if x != nil {
+		return x.MaxStaleness
+	}
+	return nil
+}
+
+// Ответ с ценой криптовалюты
+type CurrencyPriceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Использованная цена; timestamp - её фактическое время
+	Price *CurrencyPrice `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	// Запрошенный момент (для последней цены - время запроса)
+	RequestedTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=requested_time,json=requestedTime,proto3" json:"requested_time,omitempty"`
+	// Расстояние от запрошенного момента до использованной цены
+	Distance      *durationpb.Duration `protobuf:"bytes,3,opt,name=distance,proto3" json:"distance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CurrencyPriceResponse) Reset() {
+	*x = CurrencyPriceResponse{}
+	mi := &file_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrencyPriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrencyPriceResponse) ProtoMessage() {}
+
+func (x *CurrencyPriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrencyPriceResponse.ProtoReflect.Descriptor instead.
+func (*CurrencyPriceResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CurrencyPriceResponse) GetPrice() *CurrencyPrice {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *CurrencyPriceResponse) GetRequestedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedTime
+	}
+	return nil
+}
+
+func (x *CurrencyPriceResponse) GetDistance() *durationpb.Duration {
+	if x != nil {
+		return x.Distance
+	}
+	return nil
+}
+
+// Пакетный запрос цен: каждый символ на каждый момент времени (не больше 10000 пар)
+type BatchGetPricesRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Symbols []string               `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"`
+	// Моменты времени; если не заданы - последняя цена каждого символа
+	Timestamps []*timestamppb.Timestamp `protobuf:"bytes,2,rep,name=timestamps,proto3" json:"timestamps,omitempty"`
+	// Валюта котировки для всех элементов, по умолчанию USD
+	Quote         string               `protobuf:"bytes,3,opt,name=quote,proto3" json:"quote,omitempty"`
+	Mode          LookupMode           `protobuf:"varint,4,opt,name=mode,proto3,enum=currency.v1.LookupMode" json:"mode,omitempty"`
+	MaxStaleness  *durationpb.Duration `protobuf:"bytes,5,opt,name=max_staleness,json=maxStaleness,proto3" json:"max_staleness,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetPricesRequest) Reset() {
+	*x = BatchGetPricesRequest{}
+	mi := &file_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetPricesRequest) ProtoMessage() {}
+
+func (x *BatchGetPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetPricesRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetPricesRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchGetPricesRequest) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+func (x *BatchGetPricesRequest) GetTimestamps() []*timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamps
+	}
+	return nil
+}
+
+func (x *BatchGetPricesRequest) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *BatchGetPricesRequest) GetMode() LookupMode {
+	if x != nil {
+		return x.Mode
+	}
+	return LookupMode_LOOKUP_MODE_UNSPECIFIED
+}
+
+func (x *BatchGetPricesRequest) GetMaxStaleness() *durationpb.Duration {
+	if x != nil {
+		return x.MaxStaleness
+	}
+	return nil
+}
+
+// Ошибка отдельного элемента пакета
+type BatchPriceError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Код gRPC (5 - NOT_FOUND, 3 - INVALID_ARGUMENT, ...)
+	Code          int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchPriceError) Reset() {
+	*x = BatchPriceError{}
+	mi := &file_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPriceError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPriceError) ProtoMessage() {}
+
+func (x *BatchPriceError) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPriceError.ProtoReflect.Descriptor instead.
+func (*BatchPriceError) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchPriceError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchPriceError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Результат для пары (символ, момент): цена или ошибка
+type BatchPriceResult struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Symbol string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Запрошенный момент, пуст для последней цены
+	RequestedTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=requested_time,json=requestedTime,proto3" json:"requested_time,omitempty"`
+	Price         *CurrencyPrice         `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	Distance      *durationpb.Duration   `protobuf:"bytes,4,opt,name=distance,proto3" json:"distance,omitempty"`
+	Error         *BatchPriceError       `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchPriceResult) Reset() {
+	*x = BatchPriceResult{}
+	mi := &file_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPriceResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPriceResult) ProtoMessage() {}
+
+func (x *BatchPriceResult) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPriceResult.ProtoReflect.Descriptor instead.
+func (*BatchPriceResult) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchPriceResult) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *BatchPriceResult) GetRequestedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedTime
+	}
+	return nil
+}
+
+func (x *BatchPriceResult) GetPrice() *CurrencyPrice {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *BatchPriceResult) GetDistance() *durationpb.Duration {
+	if x != nil {
+		return x.Distance
+	}
+	return nil
+}
+
+func (x *BatchPriceResult) GetError() *BatchPriceError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// Результаты в порядке запроса: символы, для каждого - моменты
+type BatchGetPricesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchPriceResult    `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetPricesResponse) Reset() {
+	*x = BatchGetPricesResponse{}
+	mi := &file_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetPricesResponse) ProtoMessage() {}
+
+func (x *BatchGetPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetPricesResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetPricesResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BatchGetPricesResponse) GetResults() []*BatchPriceResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// Запрос конвертации. USD можно указывать как from или to - его цена равна единице
+type ConvertRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// Сумма в валюте from десятичной строкой, по умолчанию "1"
+	Amount string `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Момент времени; если не задан - последние цены
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Mode          LookupMode             `protobuf:"varint,5,opt,name=mode,proto3,enum=currency.v1.LookupMode" json:"mode,omitempty"`
+	MaxStaleness  *durationpb.Duration   `protobuf:"bytes,6,opt,name=max_staleness,json=maxStaleness,proto3" json:"max_staleness,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertRequest) Reset() {
+	*x = ConvertRequest{}
+	mi := &file_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertRequest) ProtoMessage() {}
+
+func (x *ConvertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertRequest.ProtoReflect.Descriptor instead.
+func (*ConvertRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ConvertRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ConvertRequest) GetMode() LookupMode {
+	if x != nil {
+		return x.Mode
+	}
+	return LookupMode_LOOKUP_MODE_UNSPECIFIED
+}
+
+func (x *ConvertRequest) GetMaxStaleness() *durationpb.Duration {
+	if x != nil {
+		return x.MaxStaleness
+	}
+	return nil
+}
+
+// Цена одной из валют в USD, использованная при конвертации
+type ConversionLeg struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Цена в USD; timestamp - её фактическое время
+	Price         *CurrencyPrice       `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	Distance      *durationpb.Duration `protobuf:"bytes,2,opt,name=distance,proto3" json:"distance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConversionLeg) Reset() {
+	*x = ConversionLeg{}
+	mi := &file_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversionLeg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversionLeg) ProtoMessage() {}
+
+func (x *ConversionLeg) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversionLeg.ProtoReflect.Descriptor instead.
+func (*ConversionLeg) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ConversionLeg) GetPrice() *CurrencyPrice {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *ConversionLeg) GetDistance() *durationpb.Duration {
+	if x != nil {
+		return x.Distance
+	}
+	return nil
+}
+
+type ConvertResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	From   string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To     string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Amount *Decimal               `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Стоимость единицы from в валюте to
+	Rate            *Decimal `protobuf:"bytes,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	ConvertedAmount *Decimal `protobuf:"bytes,5,opt,name=converted_amount,json=convertedAmount,proto3" json:"converted_amount,omitempty"`
+	// Пусто, если соответствующая валюта - USD
+	FromLeg       *ConversionLeg `protobuf:"bytes,6,opt,name=from_leg,json=fromLeg,proto3" json:"from_leg,omitempty"`
+	ToLeg         *ConversionLeg `protobuf:"bytes,7,opt,name=to_leg,json=toLeg,proto3" json:"to_leg,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertResponse) Reset() {
+	*x = ConvertResponse{}
+	mi := &file_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertResponse) ProtoMessage() {}
+
+func (x *ConvertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertResponse.ProtoReflect.Descriptor instead.
+func (*ConvertResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ConvertResponse) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetAmount() *Decimal {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *ConvertResponse) GetRate() *Decimal {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+func (x *ConvertResponse) GetConvertedAmount() *Decimal {
+	if x != nil {
+		return x.ConvertedAmount
+	}
+	return nil
+}
+
+func (x *ConvertResponse) GetFromLeg() *ConversionLeg {
+	if x != nil {
+		return x.FromLeg
+	}
+	return nil
+}
+
+func (x *ConvertResponse) GetToLeg() *ConversionLeg {
+	if x != nil {
+		return x.ToLeg
+	}
+	return nil
+}
+
+// Запрос списка криптовалют (AIP-158)
+type ListCurrenciesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Размер страницы: 0 - 50, максимум 1000
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token предыдущей страницы; остальные параметры должны совпадать
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Только валюты с этим тегом
+	Tag string `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	// Только валюты, название которых начинается с этой строки (без учёта регистра)
+	NamePrefix string `protobuf:"bytes,4,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	// Только валюты в этом состоянии, TRACKING_STATE_UNSPECIFIED - любые
+	Tracking TrackingState `protobuf:"varint,5,opt,name=tracking,proto3,enum=currency.v1.TrackingState" json:"tracking,omitempty"`
+	// Сортировка: symbol (по умолчанию), created_at или market_cap, с необязательным " desc"
+	OrderBy       string `protobuf:"bytes,6,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCurrenciesRequest) Reset() {
+	*x = ListCurrenciesRequest{}
+	mi := &file_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCurrenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurrenciesRequest) ProtoMessage() {}
+
+func (x *ListCurrenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurrenciesRequest.ProtoReflect.Descriptor instead.
+func (*ListCurrenciesRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListCurrenciesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCurrenciesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListCurrenciesRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListCurrenciesRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *ListCurrenciesRequest) GetTracking() TrackingState {
+	if x != nil {
+		return x.Tracking
+	}
+	return TrackingState_TRACKING_STATE_UNSPECIFIED
+}
+
+func (x *ListCurrenciesRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+type ListCurrenciesResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Currencies []*Currency            `protobuf:"bytes,1,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	// Токен следующей страницы, пуст на последней
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCurrenciesResponse) Reset() {
+	*x = ListCurrenciesResponse{}
+	mi := &file_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCurrenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurrenciesResponse) ProtoMessage() {}
+
+func (x *ListCurrenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurrenciesResponse.ProtoReflect.Descriptor instead.
+func (*ListCurrenciesResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListCurrenciesResponse) GetCurrencies() []*Currency {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+func (x *ListCurrenciesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Запрос исторических данных
+type GetPriceHistoryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Symbol string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Границы периода включительно; незаданная граница не ограничивает выборку
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Размер страницы: 0 - 100, максимум 1000
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Валюта котировки, по умолчанию USD
+	Quote string `protobuf:"bytes,5,opt,name=quote,proto3" json:"quote,omitempty"`
+	// next_page_token предыдущей страницы; остальные параметры должны совпадать
+	PageToken string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Порядок по времени, по умолчанию от новых к старым
+	Order         SortOrder `protobuf:"varint,7,opt,name=order,proto3,enum=currency.v1.SortOrder" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryRequest) Reset() {
+	*x = GetPriceHistoryRequest{}
+	mi := &file_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryRequest) ProtoMessage() {}
+
+func (x *GetPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetPriceHistoryRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetPriceHistoryRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetPriceHistoryRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetPriceHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryRequest) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *GetPriceHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetPriceHistoryRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+// Ответ с историческими данными
+type PriceHistoryResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prices []*CurrencyPrice       `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"`
+	// Токен следующей страницы, пуст на последней
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceHistoryResponse) Reset() {
+	*x = PriceHistoryResponse{}
+	mi := &file_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceHistoryResponse) ProtoMessage() {}
+
+func (x *PriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*PriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PriceHistoryResponse) GetPrices() []*CurrencyPrice {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *PriceHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Запрос подписки на поток цен. Пустой список символов - все валюты
+type StreamPricesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbols       []string               `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPricesRequest) Reset() {
+	*x = StreamPricesRequest{}
+	mi := &file_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPricesRequest) ProtoMessage() {}
+
+func (x *StreamPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPricesRequest.ProtoReflect.Descriptor instead.
+func (*StreamPricesRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StreamPricesRequest) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+// OHLC-свеча
+type Candle struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	OpenTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=open_time,json=openTime,proto3" json:"open_time,omitempty"`
+	Open     float64                `protobuf:"fixed64,2,opt,name=open,proto3" json:"open,omitempty"`
+	High     float64                `protobuf:"fixed64,3,opt,name=high,proto3" json:"high,omitempty"`
+	Low      float64                `protobuf:"fixed64,4,opt,name=low,proto3" json:"low,omitempty"`
+	Close    float64                `protobuf:"fixed64,5,opt,name=close,proto3" json:"close,omitempty"`
+	Count    int64                  `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	// Точные значения open/high/low/close
+	OpenValue     *Decimal `protobuf:"bytes,7,opt,name=open_value,json=openValue,proto3" json:"open_value,omitempty"`
+	HighValue     *Decimal `protobuf:"bytes,8,opt,name=high_value,json=highValue,proto3" json:"high_value,omitempty"`
+	LowValue      *Decimal `protobuf:"bytes,9,opt,name=low_value,json=lowValue,proto3" json:"low_value,omitempty"`
+	CloseValue    *Decimal `protobuf:"bytes,10,opt,name=close_value,json=closeValue,proto3" json:"close_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Candle) Reset() {
+	*x = Candle{}
+	mi := &file_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Candle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candle) ProtoMessage() {}
+
+func (x *Candle) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candle.ProtoReflect.Descriptor instead.
+func (*Candle) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Candle) GetOpenTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OpenTime
+	}
+	return nil
+}
+
+func (x *Candle) GetOpen() float64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *Candle) GetHigh() float64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *Candle) GetLow() float64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *Candle) GetClose() float64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *Candle) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Candle) GetOpenValue() *Decimal {
+	if x != nil {
+		return x.OpenValue
+	}
+	return nil
+}
+
+func (x *Candle) GetHighValue() *Decimal {
+	if x != nil {
+		return x.HighValue
+	}
+	return nil
+}
+
+func (x *Candle) GetLowValue() *Decimal {
+	if x != nil {
+		return x.LowValue
+	}
+	return nil
+}
+
+func (x *Candle) GetCloseValue() *Decimal {
+	if x != nil {
+		return x.CloseValue
+	}
+	return nil
+}
+
+// Запрос свечей. interval: 1m, 5m, 15m, 30m, 1h, 4h, 1d
+type GetCandlesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Symbol    string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Interval  string                 `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Валюта котировки, по умолчанию USD
+	Quote         string `protobuf:"bytes,5,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCandlesRequest) Reset() {
+	*x = GetCandlesRequest{}
+	mi := &file_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCandlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCandlesRequest) ProtoMessage() {}
+
+func (x *GetCandlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCandlesRequest.ProtoReflect.Descriptor instead.
+func (*GetCandlesRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetCandlesRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetCandlesRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *GetCandlesRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetCandlesRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetCandlesRequest) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+// Ответ со свечами
+type CandlesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Interval      string                 `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	Candles       []*Candle              `protobuf:"bytes,3,rep,name=candles,proto3" json:"candles,omitempty"`
+	Quote         string                 `protobuf:"bytes,4,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CandlesResponse) Reset() {
+	*x = CandlesResponse{}
+	mi := &file_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CandlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CandlesResponse) ProtoMessage() {}
+
+func (x *CandlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CandlesResponse.ProtoReflect.Descriptor instead.
+func (*CandlesResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CandlesResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CandlesResponse) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *CandlesResponse) GetCandles() []*Candle {
+	if x != nil {
+		return x.Candles
+	}
+	return nil
+}
+
+func (x *CandlesResponse) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+// Привязка тикера к идентификатору монеты провайдера
+type CoinOverride struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Symbol string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Идентификатор монеты в каталоге CoinGecko, например "bitcoin"
+	CoinId        string                 `protobuf:"bytes,2,opt,name=coin_id,json=coinId,proto3" json:"coin_id,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoinOverride) Reset() {
+	*x = CoinOverride{}
+	mi := &file_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoinOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoinOverride) ProtoMessage() {}
+
+func (x *CoinOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoinOverride.ProtoReflect.Descriptor instead.
+func (*CoinOverride) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CoinOverride) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CoinOverride) GetCoinId() string {
+	if x != nil {
+		return x.CoinId
+	}
+	return ""
+}
+
+func (x *CoinOverride) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Запрос на установку привязки тикера
+type SetCoinOverrideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	CoinId        string                 `protobuf:"bytes,2,opt,name=coin_id,json=coinId,proto3" json:"coin_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCoinOverrideRequest) Reset() {
+	*x = SetCoinOverrideRequest{}
+	mi := &file_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCoinOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCoinOverrideRequest) ProtoMessage() {}
+
+func (x *SetCoinOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCoinOverrideRequest.ProtoReflect.Descriptor instead.
+func (*SetCoinOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SetCoinOverrideRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *SetCoinOverrideRequest) GetCoinId() string {
+	if x != nil {
+		return x.CoinId
+	}
+	return ""
+}
+
+// Запрос на удаление привязки тикера
+type DeleteCoinOverrideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCoinOverrideRequest) Reset() {
+	*x = DeleteCoinOverrideRequest{}
+	mi := &file_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCoinOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCoinOverrideRequest) ProtoMessage() {}
+
+func (x *DeleteCoinOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCoinOverrideRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCoinOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteCoinOverrideRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+// Список привязок тикеров
+type ListCoinOverridesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Overrides     []*CoinOverride        `protobuf:"bytes,1,rep,name=overrides,proto3" json:"overrides,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCoinOverridesResponse) Reset() {
+	*x = ListCoinOverridesResponse{}
+	mi := &file_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCoinOverridesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCoinOverridesResponse) ProtoMessage() {}
+
+func (x *ListCoinOverridesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCoinOverridesResponse.ProtoReflect.Descriptor instead.
+func (*ListCoinOverridesResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListCoinOverridesResponse) GetOverrides() []*CoinOverride {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+// Запрос на загрузку истории цен
+type BackfillHistoryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Symbol string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Валюта котировки, по умолчанию USD
+	Quote     string                 `protobuf:"bytes,2,opt,name=quote,proto3" json:"quote,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Конец периода, по умолчанию - текущий момент
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackfillHistoryRequest) Reset() {
+	*x = BackfillHistoryRequest{}
+	mi := &file_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackfillHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackfillHistoryRequest) ProtoMessage() {}
+
+func (x *BackfillHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackfillHistoryRequest.ProtoReflect.Descriptor instead.
+func (*BackfillHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *BackfillHistoryRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *BackfillHistoryRequest) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *BackfillHistoryRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *BackfillHistoryRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+// Запрос состояния задачи загрузки истории
+type GetBackfillJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBackfillJobRequest) Reset() {
+	*x = GetBackfillJobRequest{}
+	mi := &file_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBackfillJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackfillJobRequest) ProtoMessage() {}
+
+func (x *GetBackfillJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackfillJobRequest.ProtoReflect.Descriptor instead.
+func (*GetBackfillJobRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetBackfillJobRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Задача загрузки истории цен
+type BackfillJob struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Symbol    string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Quote     string                 `protobuf:"bytes,3,opt,name=quote,proto3" json:"quote,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Начало ещё не загруженной части периода
+	Cursor *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// pending, running, completed или failed
+	Status string `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	// Доля обработанного периода от 0 до 1
+	Progress float64 `protobuf:"fixed64,8,opt,name=progress,proto3" json:"progress,omitempty"`
+	// Получено точек от провайдера
+	FetchedCount int64 `protobuf:"varint,9,opt,name=fetched_count,json=fetchedCount,proto3" json:"fetched_count,omitempty"`
+	// Сохранено новых точек (без дубликатов)
+	InsertedCount int64                  `protobuf:"varint,10,opt,name=inserted_count,json=insertedCount,proto3" json:"inserted_count,omitempty"`
+	Error         string                 `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackfillJob) Reset() {
+	*x = BackfillJob{}
+	mi := &file_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackfillJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackfillJob) ProtoMessage() {}
+
+func (x *BackfillJob) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackfillJob.ProtoReflect.Descriptor instead.
+func (*BackfillJob) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *BackfillJob) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BackfillJob) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *BackfillJob) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *BackfillJob) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *BackfillJob) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *BackfillJob) GetCursor() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
+}
+
+func (x *BackfillJob) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BackfillJob) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *BackfillJob) GetFetchedCount() int64 {
+	if x != nil {
+		return x.FetchedCount
+	}
+	return 0
+}
+
+func (x *BackfillJob) GetInsertedCount() int64 {
+	if x != nil {
+		return x.InsertedCount
+	}
+	return 0
+}
+
+func (x *BackfillJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BackfillJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *BackfillJob) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Правило оповещения о цене
+type AlertRule struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Symbol string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Валюта котировки, по умолчанию USD
+	Quote     string         `protobuf:"bytes,3,opt,name=quote,proto3" json:"quote,omitempty"`
+	Condition AlertCondition `protobuf:"varint,4,opt,name=condition,proto3,enum=currency.v1.AlertCondition" json:"condition,omitempty"`
+	// Уровень цены для ABOVE/BELOW или изменение в процентах для PERCENT_CHANGE
+	Threshold *Decimal `protobuf:"bytes,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// Период сравнения, только для PERCENT_CHANGE
+	Window *durationpb.Duration `protobuf:"bytes,6,opt,name=window,proto3" json:"window,omitempty"`
+	// Минимальный интервал между срабатываниями, пусто - значение по умолчанию сервиса
+	Cooldown *durationpb.Duration `protobuf:"bytes,7,opt,name=cooldown,proto3" json:"cooldown,omitempty"`
+	// Включено ли правило, при создании по умолчанию true
+	Enabled         *bool                  `protobuf:"varint,8,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	Description     string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	LastTriggeredAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_triggered_at,json=lastTriggeredAt,proto3" json:"last_triggered_at,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Для ABOVE/BELOW: false после срабатывания, пока цена не вернётся по другую сторону порога
+	Armed         bool `protobuf:"varint,13,opt,name=armed,proto3" json:"armed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertRule) Reset() {
+	*x = AlertRule{}
+	mi := &file_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertRule) ProtoMessage() {}
+
+func (x *AlertRule) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertRule.ProtoReflect.Descriptor instead.
+func (*AlertRule) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AlertRule) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AlertRule) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AlertRule) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *AlertRule) GetCondition() AlertCondition {
+	if x != nil {
+		return x.Condition
+	}
+	return AlertCondition_ALERT_CONDITION_UNSPECIFIED
+}
+
+func (x *AlertRule) GetThreshold() *Decimal {
+	if x != nil {
+		return x.Threshold
+	}
+	return nil
+}
+
+func (x *AlertRule) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *AlertRule) GetCooldown() *durationpb.Duration {
+	if x != nil {
+		return x.Cooldown
+	}
+	return nil
+}
+
+func (x *AlertRule) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *AlertRule) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AlertRule) GetLastTriggeredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastTriggeredAt
+	}
+	return nil
+}
+
+func (x *AlertRule) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AlertRule) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *AlertRule) GetArmed() bool {
+	if x != nil {
+		return x.Armed
+	}
+	return false
+}
+
+type CreateAlertRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *AlertRule             `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAlertRuleRequest) Reset() {
+	*x = CreateAlertRuleRequest{}
+	mi := &file_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAlertRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAlertRuleRequest) ProtoMessage() {}
+
+func (x *CreateAlertRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAlertRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreateAlertRuleRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateAlertRuleRequest) GetRule() *AlertRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type GetAlertRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlertRuleRequest) Reset() {
+	*x = GetAlertRuleRequest{}
+	mi := &file_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlertRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlertRuleRequest) ProtoMessage() {}
+
+func (x *GetAlertRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlertRuleRequest.ProtoReflect.Descriptor instead.
+func (*GetAlertRuleRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetAlertRuleRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListAlertRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertRulesRequest) Reset() {
+	*x = ListAlertRulesRequest{}
+	mi := &file_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertRulesRequest) ProtoMessage() {}
+
+func (x *ListAlertRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListAlertRulesRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListAlertRulesRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type ListAlertRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*AlertRule           `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertRulesResponse) Reset() {
+	*x = ListAlertRulesResponse{}
+	mi := &file_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertRulesResponse) ProtoMessage() {}
+
+func (x *ListAlertRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListAlertRulesResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListAlertRulesResponse) GetRules() []*AlertRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// Запрос на изменение правила. Пустая маска означает все заполненные изменяемые поля.
+type UpdateAlertRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *AlertRule             `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAlertRuleRequest) Reset() {
+	*x = UpdateAlertRuleRequest{}
+	mi := &file_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAlertRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAlertRuleRequest) ProtoMessage() {}
+
+func (x *UpdateAlertRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAlertRuleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAlertRuleRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UpdateAlertRuleRequest) GetRule() *AlertRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *UpdateAlertRuleRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteAlertRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAlertRuleRequest) Reset() {
+	*x = DeleteAlertRuleRequest{}
+	mi := &file_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAlertRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAlertRuleRequest) ProtoMessage() {}
+
+func (x *DeleteAlertRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAlertRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAlertRuleRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeleteAlertRuleRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Запрос истории срабатываний (AIP-158)
+type ListAlertTriggersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Необязательные фильтры по правилу и валюте
+	RuleId        int64  `protobuf:"varint,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Symbol        string `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	PageSize      int32  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertTriggersRequest) Reset() {
+	*x = ListAlertTriggersRequest{}
+	mi := &file_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertTriggersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertTriggersRequest) ProtoMessage() {}
+
+func (x *ListAlertTriggersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertTriggersRequest.ProtoReflect.Descriptor instead.
+func (*ListAlertTriggersRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListAlertTriggersRequest) GetRuleId() int64 {
+	if x != nil {
+		return x.RuleId
+	}
+	return 0
+}
+
+func (x *ListAlertTriggersRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ListAlertTriggersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAlertTriggersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Срабатывание правила оповещения
+type AlertTrigger struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RuleId    int64                  `protobuf:"varint,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Symbol    string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Quote     string                 `protobuf:"bytes,4,opt,name=quote,proto3" json:"quote,omitempty"`
+	Condition AlertCondition         `protobuf:"varint,5,opt,name=condition,proto3,enum=currency.v1.AlertCondition" json:"condition,omitempty"`
+	Threshold *Decimal               `protobuf:"bytes,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// Цена, на которой сработало правило, и её время
+	Price     *Decimal               `protobuf:"bytes,7,opt,name=price,proto3" json:"price,omitempty"`
+	PriceTime *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=price_time,json=priceTime,proto3" json:"price_time,omitempty"`
+	// Цена начала окна и изменение в процентах, только для PERCENT_CHANGE
+	ReferencePrice *Decimal               `protobuf:"bytes,9,opt,name=reference_price,json=referencePrice,proto3" json:"reference_price,omitempty"`
+	ChangePercent  *Decimal               `protobuf:"bytes,10,opt,name=change_percent,json=changePercent,proto3" json:"change_percent,omitempty"`
+	TriggeredAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=triggered_at,json=triggeredAt,proto3" json:"triggered_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AlertTrigger) Reset() {
+	*x = AlertTrigger{}
+	mi := &file_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertTrigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertTrigger) ProtoMessage() {}
+
+func (x *AlertTrigger) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertTrigger.ProtoReflect.Descriptor instead.
+func (*AlertTrigger) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AlertTrigger) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AlertTrigger) GetRuleId() int64 {
+	if x != nil {
+		return x.RuleId
+	}
+	return 0
+}
+
+func (x *AlertTrigger) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AlertTrigger) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *AlertTrigger) GetCondition() AlertCondition {
+	if x != nil {
+		return x.Condition
+	}
+	return AlertCondition_ALERT_CONDITION_UNSPECIFIED
+}
+
+func (x *AlertTrigger) GetThreshold() *Decimal {
+	if x != nil {
+		return x.Threshold
+	}
+	return nil
+}
+
+func (x *AlertTrigger) GetPrice() *Decimal {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *AlertTrigger) GetPriceTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PriceTime
+	}
+	return nil
+}
+
+func (x *AlertTrigger) GetReferencePrice() *Decimal {
+	if x != nil {
+		return x.ReferencePrice
+	}
+	return nil
+}
+
+func (x *AlertTrigger) GetChangePercent() *Decimal {
+	if x != nil {
+		return x.ChangePercent
+	}
+	return nil
+}
+
+func (x *AlertTrigger) GetTriggeredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TriggeredAt
+	}
+	return nil
+}
+
+type ListAlertTriggersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Triggers      []*AlertTrigger        `protobuf:"bytes,1,rep,name=triggers,proto3" json:"triggers,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertTriggersResponse) Reset() {
+	*x = ListAlertTriggersResponse{}
+	mi := &file_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertTriggersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertTriggersResponse) ProtoMessage() {}
+
+func (x *ListAlertTriggersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertTriggersResponse.ProtoReflect.Descriptor instead.
+func (*ListAlertTriggersResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListAlertTriggersResponse) GetTriggers() []*AlertTrigger {
+	if x != nil {
+		return x.Triggers
+	}
+	return nil
+}
+
+func (x *ListAlertTriggersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Получатель событий webhook
+type WebhookEndpoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Адрес http(s), на который отправляются POST-запросы
+	Url    string         `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Events []WebhookEvent `protobuf:"varint,3,rep,packed,name=events,proto3,enum=currency.v1.WebhookEvent" json:"events,omitempty"`
+	// Секрет подписи HMAC-SHA256, заполнен только в ответе на создание
+	Secret      string `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// Включён ли получатель, при создании по умолчанию true
+	Enabled *bool `protobuf:"varint,6,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// Неудачные попытки доставки подряд
+	ConsecutiveFailures int32 `protobuf:"varint,7,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	// Время автоматического отключения из-за ошибок доставки
+	DisabledAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=disabled_at,json=disabledAt,proto3" json:"disabled_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookEndpoint) Reset() {
+	*x = WebhookEndpoint{}
+	mi := &file_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookEndpoint) ProtoMessage() {}
+
+func (x *WebhookEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookEndpoint.ProtoReflect.Descriptor instead.
+func (*WebhookEndpoint) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *WebhookEndpoint) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WebhookEndpoint) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetEvents() []WebhookEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *WebhookEndpoint) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *WebhookEndpoint) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
 	}
 	return 0
 }
 
-func (x *CurrencyPrice) GetSymbol() string {
+func (x *WebhookEndpoint) GetDisabledAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Symbol
+		return x.DisabledAt
 	}
-	return ""
+	return nil
 }
 
-func (x *CurrencyPrice) GetPrice() float64 {
+func (x *WebhookEndpoint) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Price
+		return x.CreatedAt
 	}
-	return 0
+	return nil
 }
 
-func (x *CurrencyPrice) GetTimestamp() *timestamppb.Timestamp {
+func (x *WebhookEndpoint) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Timestamp
+		return x.UpdatedAt
 	}
 	return nil
 }
 
-// Запрос на добавление криптовалюты
-type AddCurrencyRequest struct {
+type CreateWebhookEndpointRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Endpoint      *WebhookEndpoint       `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddCurrencyRequest) Reset() {
-	*x = AddCurrencyRequest{}
-	mi := &file_service_proto_msgTypes[2]
+func (x *CreateWebhookEndpointRequest) Reset() {
+	*x = CreateWebhookEndpointRequest{}
+	mi := &file_service_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddCurrencyRequest) String() string {
+func (x *CreateWebhookEndpointRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddCurrencyRequest) ProtoMessage() {}
+func (*CreateWebhookEndpointRequest) ProtoMessage() {}
 
-func (x *AddCurrencyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[2]
+func (x *CreateWebhookEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -204,48 +3499,40 @@ func (x *AddCurrencyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddCurrencyRequest.ProtoReflect.Descriptor instead.
-func (*AddCurrencyRequest) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *AddCurrencyRequest) GetSymbol() string {
-	if x != nil {
-		return x.Symbol
-	}
-	return ""
+// Deprecated: Use CreateWebhookEndpointRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *AddCurrencyRequest) GetName() string {
+func (x *CreateWebhookEndpointRequest) GetEndpoint() *WebhookEndpoint {
 	if x != nil {
-		return x.Name
+		return x.Endpoint
 	}
-	return ""
+	return nil
 }
 
-// Ответ с информацией о криптовалюте
-type CurrencyResponse struct {
+type GetWebhookEndpointRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Currency      *Currency              `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CurrencyResponse) Reset() {
-	*x = CurrencyResponse{}
-	mi := &file_service_proto_msgTypes[3]
+func (x *GetWebhookEndpointRequest) Reset() {
+	*x = GetWebhookEndpointRequest{}
+	mi := &file_service_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CurrencyResponse) String() string {
+func (x *GetWebhookEndpointRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CurrencyResponse) ProtoMessage() {}
+func (*GetWebhookEndpointRequest) ProtoMessage() {}
 
-func (x *CurrencyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[3]
+func (x *GetWebhookEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -256,41 +3543,40 @@ func (x *CurrencyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CurrencyResponse.ProtoReflect.Descriptor instead.
-func (*CurrencyResponse) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use GetWebhookEndpointRequest.ProtoReflect.Descriptor instead.
+func (*GetWebhookEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *CurrencyResponse) GetCurrency() *Currency {
+func (x *GetWebhookEndpointRequest) GetId() int64 {
 	if x != nil {
-		return x.Currency
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-// Запрос на удаление криптовалюты
-type RemoveCurrencyRequest struct {
+type ListWebhookEndpointsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Endpoints     []*WebhookEndpoint     `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveCurrencyRequest) Reset() {
-	*x = RemoveCurrencyRequest{}
-	mi := &file_service_proto_msgTypes[4]
+func (x *ListWebhookEndpointsResponse) Reset() {
+	*x = ListWebhookEndpointsResponse{}
+	mi := &file_service_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveCurrencyRequest) String() string {
+func (x *ListWebhookEndpointsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveCurrencyRequest) ProtoMessage() {}
+func (*ListWebhookEndpointsResponse) ProtoMessage() {}
 
-func (x *RemoveCurrencyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[4]
+func (x *ListWebhookEndpointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -301,42 +3587,42 @@ func (x *RemoveCurrencyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveCurrencyRequest.ProtoReflect.Descriptor instead.
-func (*RemoveCurrencyRequest) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ListWebhookEndpointsResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookEndpointsResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *RemoveCurrencyRequest) GetSymbol() string {
+func (x *ListWebhookEndpointsResponse) GetEndpoints() []*WebhookEndpoint {
 	if x != nil {
-		return x.Symbol
+		return x.Endpoints
 	}
-	return ""
+	return nil
 }
 
-// Запрос цены криптовалюты
-type GetCurrencyPriceRequest struct {
+// Запрос на изменение получателя. Пустая маска означает все заполненные изменяемые поля.
+type UpdateWebhookEndpointRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Endpoint      *WebhookEndpoint       `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCurrencyPriceRequest) Reset() {
-	*x = GetCurrencyPriceRequest{}
-	mi := &file_service_proto_msgTypes[5]
+func (x *UpdateWebhookEndpointRequest) Reset() {
+	*x = UpdateWebhookEndpointRequest{}
+	mi := &file_service_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCurrencyPriceRequest) String() string {
+func (x *UpdateWebhookEndpointRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCurrencyPriceRequest) ProtoMessage() {}
+func (*UpdateWebhookEndpointRequest) ProtoMessage() {}
 
-func (x *GetCurrencyPriceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[5]
+func (x *UpdateWebhookEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -347,48 +3633,47 @@ func (x *GetCurrencyPriceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCurrencyPriceRequest.ProtoReflect.Descriptor instead.
-func (*GetCurrencyPriceRequest) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use UpdateWebhookEndpointRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWebhookEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *GetCurrencyPriceRequest) GetSymbol() string {
+func (x *UpdateWebhookEndpointRequest) GetEndpoint() *WebhookEndpoint {
 	if x != nil {
-		return x.Symbol
+		return x.Endpoint
 	}
-	return ""
+	return nil
 }
 
-func (x *GetCurrencyPriceRequest) GetTimestamp() *timestamppb.Timestamp {
+func (x *UpdateWebhookEndpointRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
 	if x != nil {
-		return x.Timestamp
+		return x.UpdateMask
 	}
 	return nil
 }
 
-// Ответ с ценой криптовалюты
-type CurrencyPriceResponse struct {
+type DeleteWebhookEndpointRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Price         *CurrencyPrice         `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CurrencyPriceResponse) Reset() {
-	*x = CurrencyPriceResponse{}
-	mi := &file_service_proto_msgTypes[6]
+func (x *DeleteWebhookEndpointRequest) Reset() {
+	*x = DeleteWebhookEndpointRequest{}
+	mi := &file_service_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CurrencyPriceResponse) String() string {
+func (x *DeleteWebhookEndpointRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CurrencyPriceResponse) ProtoMessage() {}
+func (*DeleteWebhookEndpointRequest) ProtoMessage() {}
 
-func (x *CurrencyPriceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[6]
+func (x *DeleteWebhookEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -399,41 +3684,45 @@ func (x *CurrencyPriceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CurrencyPriceResponse.ProtoReflect.Descriptor instead.
-func (*CurrencyPriceResponse) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use DeleteWebhookEndpointRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *CurrencyPriceResponse) GetPrice() *CurrencyPrice {
+func (x *DeleteWebhookEndpointRequest) GetId() int64 {
 	if x != nil {
-		return x.Price
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-// Ответ со списком криптовалют
-type ListCurrenciesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Currencies    []*Currency            `protobuf:"bytes,1,rep,name=currencies,proto3" json:"currencies,omitempty"`
+// Запрос журнала доставок (AIP-158)
+type ListWebhookDeliveriesRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EndpointId int64                  `protobuf:"varint,1,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	// Необязательный фильтр по статусу
+	Status        WebhookDeliveryStatus `protobuf:"varint,2,opt,name=status,proto3,enum=currency.v1.WebhookDeliveryStatus" json:"status,omitempty"`
+	PageSize      int32                 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListCurrenciesResponse) Reset() {
-	*x = ListCurrenciesResponse{}
-	mi := &file_service_proto_msgTypes[7]
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_service_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListCurrenciesResponse) String() string {
+func (x *ListWebhookDeliveriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCurrenciesResponse) ProtoMessage() {}
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
 
-func (x *ListCurrenciesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[7]
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,44 +3733,76 @@ func (x *ListCurrenciesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCurrenciesResponse.ProtoReflect.Descriptor instead.
-func (*ListCurrenciesResponse) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *ListCurrenciesResponse) GetCurrencies() []*Currency {
+func (x *ListWebhookDeliveriesRequest) GetEndpointId() int64 {
 	if x != nil {
-		return x.Currencies
+		return x.EndpointId
 	}
-	return nil
+	return 0
 }
 
-// Запрос исторических данных
-type GetPriceHistoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ListWebhookDeliveriesRequest) GetStatus() WebhookDeliveryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED
 }
 
-func (x *GetPriceHistoryRequest) Reset() {
-	*x = GetPriceHistoryRequest{}
-	mi := &file_service_proto_msgTypes[8]
+func (x *ListWebhookDeliveriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Доставка одного события получателю
+type WebhookDelivery struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EndpointId int64                  `protobuf:"varint,2,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	// Идентификатор события, одинаковый у доставок разным получателям
+	EventId string       `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Event   WebhookEvent `protobuf:"varint,4,opt,name=event,proto3,enum=currency.v1.WebhookEvent" json:"event,omitempty"`
+	// Тело запроса (JSON)
+	Payload  string                `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Status   WebhookDeliveryStatus `protobuf:"varint,6,opt,name=status,proto3,enum=currency.v1.WebhookDeliveryStatus" json:"status,omitempty"`
+	Attempts int32                 `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// HTTP-статус и ошибка последней попытки
+	LastStatusCode int32                  `protobuf:"varint,8,opt,name=last_status_code,json=lastStatusCode,proto3" json:"last_status_code,omitempty"`
+	LastError      string                 `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	NextAttemptAt  *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	DeliveredAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_service_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPriceHistoryRequest) String() string {
+func (x *WebhookDelivery) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPriceHistoryRequest) ProtoMessage() {}
+func (*WebhookDelivery) ProtoMessage() {}
 
-func (x *GetPriceHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[8]
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -492,62 +3813,118 @@ func (x *GetPriceHistoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPriceHistoryRequest.ProtoReflect.Descriptor instead.
-func (*GetPriceHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *GetPriceHistoryRequest) GetSymbol() string {
+func (x *WebhookDelivery) GetId() int64 {
 	if x != nil {
-		return x.Symbol
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetEndpointId() int64 {
+	if x != nil {
+		return x.EndpointId
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetEventId() string {
+	if x != nil {
+		return x.EventId
 	}
 	return ""
 }
 
-func (x *GetPriceHistoryRequest) GetStartTime() *timestamppb.Timestamp {
+func (x *WebhookDelivery) GetEvent() WebhookEvent {
 	if x != nil {
-		return x.StartTime
+		return x.Event
+	}
+	return WebhookEvent_WEBHOOK_EVENT_UNSPECIFIED
+}
+
+func (x *WebhookDelivery) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() WebhookDeliveryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastStatusCode() int32 {
+	if x != nil {
+		return x.LastStatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
 	}
 	return nil
 }
 
-func (x *GetPriceHistoryRequest) GetEndTime() *timestamppb.Timestamp {
+func (x *WebhookDelivery) GetDeliveredAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.EndTime
+		return x.DeliveredAt
 	}
 	return nil
 }
 
-func (x *GetPriceHistoryRequest) GetLimit() int32 {
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Limit
+		return x.CreatedAt
 	}
-	return 0
+	return nil
 }
 
-// Ответ с историческими данными
-type PriceHistoryResponse struct {
+type ListWebhookDeliveriesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Prices        []*CurrencyPrice       `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PriceHistoryResponse) Reset() {
-	*x = PriceHistoryResponse{}
-	mi := &file_service_proto_msgTypes[9]
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_service_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PriceHistoryResponse) String() string {
+func (x *ListWebhookDeliveriesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PriceHistoryResponse) ProtoMessage() {}
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
 
-func (x *PriceHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[9]
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -558,23 +3935,30 @@ func (x *PriceHistoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PriceHistoryResponse.ProtoReflect.Descriptor instead.
-func (*PriceHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *PriceHistoryResponse) GetPrices() []*CurrencyPrice {
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
 	if x != nil {
-		return x.Prices
+		return x.Deliveries
 	}
 	return nil
 }
 
+func (x *ListWebhookDeliveriesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 var File_service_proto protoreflect.FileDescriptor
 
 const file_service_proto_rawDesc = "" +
 	"\n" +
-	"\rservice.proto\x12\vcurrency.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x15google/api/http.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xbc\x01\n" +
+	"\rservice.proto\x12\vcurrency.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x15google/api/http.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\"\xf8\x03\n" +
 	"\bCurrency\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
 	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x12\n" +
@@ -582,42 +3966,370 @@ const file_service_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x87\x01\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x16\n" +
+	"\x06quotes\x18\x06 \x03(\tR\x06quotes\x129\n" +
+	"\n" +
+	"deleted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x1a\n" +
+	"\bdecimals\x18\b \x01(\x05R\bdecimals\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\x12(\n" +
+	"\x10provider_coin_id\x18\n" +
+	" \x01(\tR\x0eproviderCoinId\x12 \n" +
+	"\vdescription\x18\v \x01(\tR\vdescription\x126\n" +
+	"\btracking\x18\f \x01(\x0e2\x1a.currency.v1.TrackingStateR\btracking\x123\n" +
+	"\n" +
+	"market_cap\x18\r \x01(\v2\x14.currency.v1.DecimalR\tmarketCap\"K\n" +
+	"\aDecimal\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05units\x18\x02 \x01(\x03R\x05units\x12\x14\n" +
+	"\x05nanos\x18\x03 \x01(\x05R\x05nanos\"\xa9\x02\n" +
 	"\rCurrencyPrice\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
 	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x14\n" +
 	"\x05price\x18\x03 \x01(\x01R\x05price\x128\n" +
-	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"@\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x14\n" +
+	"\x05quote\x18\x05 \x01(\tR\x05quote\x125\n" +
+	"\vprice_value\x18\x06 \x01(\v2\x14.currency.v1.DecimalR\n" +
+	"priceValue\x12\x18\n" +
+	"\asources\x18\a \x03(\tR\asources\x12\x16\n" +
+	"\x06source\x18\b \x01(\tR\x06source\x12!\n" +
+	"\fis_synthetic\x18\t \x01(\bR\visSynthetic\"\xf4\x01\n" +
 	"\x12AddCurrencyRequest\x12\x16\n" +
 	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"E\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06quotes\x18\x03 \x03(\tR\x06quotes\x12\x1f\n" +
+	"\bdecimals\x18\x04 \x01(\x05H\x00R\bdecimals\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x126\n" +
+	"\btracking\x18\a \x01(\x0e2\x1a.currency.v1.TrackingStateR\btrackingB\v\n" +
+	"\t_decimals\"E\n" +
 	"\x10CurrencyResponse\x121\n" +
 	"\bcurrency\x18\x01 \x01(\v2\x15.currency.v1.CurrencyR\bcurrency\"/\n" +
 	"\x15RemoveCurrencyRequest\x12\x16\n" +
-	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"k\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"\x87\x01\n" +
+	"\x15UpdateCurrencyRequest\x121\n" +
+	"\bcurrency\x18\x01 \x01(\v2\x15.currency.v1.CurrencyR\bcurrency\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"0\n" +
+	"\x16RestoreCurrencyRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"\xee\x01\n" +
 	"\x17GetCurrencyPriceRequest\x12\x16\n" +
 	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x128\n" +
-	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"I\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x14\n" +
+	"\x05quote\x18\x03 \x01(\tR\x05quote\x12+\n" +
+	"\x04mode\x18\x04 \x01(\x0e2\x17.currency.v1.LookupModeR\x04mode\x12>\n" +
+	"\rmax_staleness\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\fmaxStaleness\"\xc3\x01\n" +
 	"\x15CurrencyPriceResponse\x120\n" +
-	"\x05price\x18\x01 \x01(\v2\x1a.currency.v1.CurrencyPriceR\x05price\"O\n" +
+	"\x05price\x18\x01 \x01(\v2\x1a.currency.v1.CurrencyPriceR\x05price\x12A\n" +
+	"\x0erequested_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\rrequestedTime\x125\n" +
+	"\bdistance\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bdistance\"\xf0\x01\n" +
+	"\x15BatchGetPricesRequest\x12\x18\n" +
+	"\asymbols\x18\x01 \x03(\tR\asymbols\x12:\n" +
+	"\n" +
+	"timestamps\x18\x02 \x03(\v2\x1a.google.protobuf.TimestampR\n" +
+	"timestamps\x12\x14\n" +
+	"\x05quote\x18\x03 \x01(\tR\x05quote\x12+\n" +
+	"\x04mode\x18\x04 \x01(\x0e2\x17.currency.v1.LookupModeR\x04mode\x12>\n" +
+	"\rmax_staleness\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\fmaxStaleness\"?\n" +
+	"\x0fBatchPriceError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x8a\x02\n" +
+	"\x10BatchPriceResult\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12A\n" +
+	"\x0erequested_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\rrequestedTime\x120\n" +
+	"\x05price\x18\x03 \x01(\v2\x1a.currency.v1.CurrencyPriceR\x05price\x125\n" +
+	"\bdistance\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\bdistance\x122\n" +
+	"\x05error\x18\x05 \x01(\v2\x1c.currency.v1.BatchPriceErrorR\x05error\"Q\n" +
+	"\x16BatchGetPricesResponse\x127\n" +
+	"\aresults\x18\x01 \x03(\v2\x1d.currency.v1.BatchPriceResultR\aresults\"\xf3\x01\n" +
+	"\x0eConvertRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12+\n" +
+	"\x04mode\x18\x05 \x01(\x0e2\x17.currency.v1.LookupModeR\x04mode\x12>\n" +
+	"\rmax_staleness\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\fmaxStaleness\"x\n" +
+	"\rConversionLeg\x120\n" +
+	"\x05price\x18\x01 \x01(\v2\x1a.currency.v1.CurrencyPriceR\x05price\x125\n" +
+	"\bdistance\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bdistance\"\xb8\x02\n" +
+	"\x0fConvertResponse\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12,\n" +
+	"\x06amount\x18\x03 \x01(\v2\x14.currency.v1.DecimalR\x06amount\x12(\n" +
+	"\x04rate\x18\x04 \x01(\v2\x14.currency.v1.DecimalR\x04rate\x12?\n" +
+	"\x10converted_amount\x18\x05 \x01(\v2\x14.currency.v1.DecimalR\x0fconvertedAmount\x125\n" +
+	"\bfrom_leg\x18\x06 \x01(\v2\x1a.currency.v1.ConversionLegR\afromLeg\x121\n" +
+	"\x06to_leg\x18\a \x01(\v2\x1a.currency.v1.ConversionLegR\x05toLeg\"\xd9\x01\n" +
+	"\x15ListCurrenciesRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x10\n" +
+	"\x03tag\x18\x03 \x01(\tR\x03tag\x12\x1f\n" +
+	"\vname_prefix\x18\x04 \x01(\tR\n" +
+	"namePrefix\x126\n" +
+	"\btracking\x18\x05 \x01(\x0e2\x1a.currency.v1.TrackingStateR\btracking\x12\x19\n" +
+	"\border_by\x18\x06 \x01(\tR\aorderBy\"w\n" +
 	"\x16ListCurrenciesResponse\x125\n" +
 	"\n" +
 	"currencies\x18\x01 \x03(\v2\x15.currency.v1.CurrencyR\n" +
-	"currencies\"\xb8\x01\n" +
+	"currencies\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x9b\x02\n" +
 	"\x16GetPriceHistoryRequest\x12\x16\n" +
 	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x129\n" +
 	"\n" +
 	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
 	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x05R\x05limit\"J\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05quote\x18\x05 \x01(\tR\x05quote\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x12,\n" +
+	"\x05order\x18\a \x01(\x0e2\x16.currency.v1.SortOrderR\x05order\"r\n" +
 	"\x14PriceHistoryResponse\x122\n" +
-	"\x06prices\x18\x01 \x03(\v2\x1a.currency.v1.CurrencyPriceR\x06prices2\xe8\x04\n" +
+	"\x06prices\x18\x01 \x03(\v2\x1a.currency.v1.CurrencyPriceR\x06prices\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"/\n" +
+	"\x13StreamPricesRequest\x12\x18\n" +
+	"\asymbols\x18\x01 \x03(\tR\asymbols\"\xfb\x02\n" +
+	"\x06Candle\x127\n" +
+	"\topen_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\bopenTime\x12\x12\n" +
+	"\x04open\x18\x02 \x01(\x01R\x04open\x12\x12\n" +
+	"\x04high\x18\x03 \x01(\x01R\x04high\x12\x10\n" +
+	"\x03low\x18\x04 \x01(\x01R\x03low\x12\x14\n" +
+	"\x05close\x18\x05 \x01(\x01R\x05close\x12\x14\n" +
+	"\x05count\x18\x06 \x01(\x03R\x05count\x123\n" +
+	"\n" +
+	"open_value\x18\a \x01(\v2\x14.currency.v1.DecimalR\topenValue\x123\n" +
+	"\n" +
+	"high_value\x18\b \x01(\v2\x14.currency.v1.DecimalR\thighValue\x121\n" +
+	"\tlow_value\x18\t \x01(\v2\x14.currency.v1.DecimalR\blowValue\x125\n" +
+	"\vclose_value\x18\n" +
+	" \x01(\v2\x14.currency.v1.DecimalR\n" +
+	"closeValue\"\xcf\x01\n" +
+	"\x11GetCandlesRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\binterval\x18\x02 \x01(\tR\binterval\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x14\n" +
+	"\x05quote\x18\x05 \x01(\tR\x05quote\"\x8a\x01\n" +
+	"\x0fCandlesResponse\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\binterval\x18\x02 \x01(\tR\binterval\x12-\n" +
+	"\acandles\x18\x03 \x03(\v2\x13.currency.v1.CandleR\acandles\x12\x14\n" +
+	"\x05quote\x18\x04 \x01(\tR\x05quote\"z\n" +
+	"\fCoinOverride\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x17\n" +
+	"\acoin_id\x18\x02 \x01(\tR\x06coinId\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"I\n" +
+	"\x16SetCoinOverrideRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x17\n" +
+	"\acoin_id\x18\x02 \x01(\tR\x06coinId\"3\n" +
+	"\x19DeleteCoinOverrideRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"T\n" +
+	"\x19ListCoinOverridesResponse\x127\n" +
+	"\toverrides\x18\x01 \x03(\v2\x19.currency.v1.CoinOverrideR\toverrides\"\xb8\x01\n" +
+	"\x16BackfillHistoryRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05quote\x18\x02 \x01(\tR\x05quote\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"'\n" +
+	"\x15GetBackfillJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xfd\x03\n" +
+	"\vBackfillJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05quote\x18\x03 \x01(\tR\x05quote\x129\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x122\n" +
+	"\x06cursor\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x06cursor\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1a\n" +
+	"\bprogress\x18\b \x01(\x01R\bprogress\x12#\n" +
+	"\rfetched_count\x18\t \x01(\x03R\ffetchedCount\x12%\n" +
+	"\x0einserted_count\x18\n" +
+	" \x01(\x03R\rinsertedCount\x12\x14\n" +
+	"\x05error\x18\v \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xc3\x04\n" +
+	"\tAlertRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05quote\x18\x03 \x01(\tR\x05quote\x129\n" +
+	"\tcondition\x18\x04 \x01(\x0e2\x1b.currency.v1.AlertConditionR\tcondition\x122\n" +
+	"\tthreshold\x18\x05 \x01(\v2\x14.currency.v1.DecimalR\tthreshold\x121\n" +
+	"\x06window\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x06window\x125\n" +
+	"\bcooldown\x18\a \x01(\v2\x19.google.protobuf.DurationR\bcooldown\x12\x1d\n" +
+	"\aenabled\x18\b \x01(\bH\x00R\aenabled\x88\x01\x01\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\x12F\n" +
+	"\x11last_triggered_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0flastTriggeredAt\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
+	"\x05armed\x18\r \x01(\bR\x05armedB\n" +
+	"\n" +
+	"\b_enabled\"D\n" +
+	"\x16CreateAlertRuleRequest\x12*\n" +
+	"\x04rule\x18\x01 \x01(\v2\x16.currency.v1.AlertRuleR\x04rule\"%\n" +
+	"\x13GetAlertRuleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"/\n" +
+	"\x15ListAlertRulesRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"F\n" +
+	"\x16ListAlertRulesResponse\x12,\n" +
+	"\x05rules\x18\x01 \x03(\v2\x16.currency.v1.AlertRuleR\x05rules\"\x81\x01\n" +
+	"\x16UpdateAlertRuleRequest\x12*\n" +
+	"\x04rule\x18\x01 \x01(\v2\x16.currency.v1.AlertRuleR\x04rule\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"(\n" +
+	"\x16DeleteAlertRuleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x87\x01\n" +
+	"\x18ListAlertTriggersRequest\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\x03R\x06ruleId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\xf6\x03\n" +
+	"\fAlertTrigger\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\arule_id\x18\x02 \x01(\x03R\x06ruleId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05quote\x18\x04 \x01(\tR\x05quote\x129\n" +
+	"\tcondition\x18\x05 \x01(\x0e2\x1b.currency.v1.AlertConditionR\tcondition\x122\n" +
+	"\tthreshold\x18\x06 \x01(\v2\x14.currency.v1.DecimalR\tthreshold\x12*\n" +
+	"\x05price\x18\a \x01(\v2\x14.currency.v1.DecimalR\x05price\x129\n" +
+	"\n" +
+	"price_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tpriceTime\x12=\n" +
+	"\x0freference_price\x18\t \x01(\v2\x14.currency.v1.DecimalR\x0ereferencePrice\x12;\n" +
+	"\x0echange_percent\x18\n" +
+	" \x01(\v2\x14.currency.v1.DecimalR\rchangePercent\x12=\n" +
+	"\ftriggered_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vtriggeredAt\"z\n" +
+	"\x19ListAlertTriggersResponse\x125\n" +
+	"\btriggers\x18\x01 \x03(\v2\x19.currency.v1.AlertTriggerR\btriggers\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xb1\x03\n" +
+	"\x0fWebhookEndpoint\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x121\n" +
+	"\x06events\x18\x03 \x03(\x0e2\x19.currency.v1.WebhookEventR\x06events\x12\x16\n" +
+	"\x06secret\x18\x04 \x01(\tR\x06secret\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1d\n" +
+	"\aenabled\x18\x06 \x01(\bH\x00R\aenabled\x88\x01\x01\x121\n" +
+	"\x14consecutive_failures\x18\a \x01(\x05R\x13consecutiveFailures\x12;\n" +
+	"\vdisabled_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"disabledAt\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\n" +
+	"\n" +
+	"\b_enabled\"X\n" +
+	"\x1cCreateWebhookEndpointRequest\x128\n" +
+	"\bendpoint\x18\x01 \x01(\v2\x1c.currency.v1.WebhookEndpointR\bendpoint\"+\n" +
+	"\x19GetWebhookEndpointRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"Z\n" +
+	"\x1cListWebhookEndpointsResponse\x12:\n" +
+	"\tendpoints\x18\x01 \x03(\v2\x1c.currency.v1.WebhookEndpointR\tendpoints\"\x95\x01\n" +
+	"\x1cUpdateWebhookEndpointRequest\x128\n" +
+	"\bendpoint\x18\x01 \x01(\v2\x1c.currency.v1.WebhookEndpointR\bendpoint\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\".\n" +
+	"\x1cDeleteWebhookEndpointRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xb7\x01\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\x1f\n" +
+	"\vendpoint_id\x18\x01 \x01(\x03R\n" +
+	"endpointId\x12:\n" +
+	"\x06status\x18\x02 \x01(\x0e2\".currency.v1.WebhookDeliveryStatusR\x06status\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\x87\x04\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vendpoint_id\x18\x02 \x01(\x03R\n" +
+	"endpointId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12/\n" +
+	"\x05event\x18\x04 \x01(\x0e2\x19.currency.v1.WebhookEventR\x05event\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\tR\apayload\x12:\n" +
+	"\x06status\x18\x06 \x01(\x0e2\".currency.v1.WebhookDeliveryStatusR\x06status\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x12(\n" +
+	"\x10last_status_code\x18\b \x01(\x05R\x0elastStatusCode\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\t \x01(\tR\tlastError\x12B\n" +
+	"\x0fnext_attempt_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x12=\n" +
+	"\fdelivered_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x85\x01\n" +
+	"\x1dListWebhookDeliveriesResponse\x12<\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x1c.currency.v1.WebhookDeliveryR\n" +
+	"deliveries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*e\n" +
+	"\rTrackingState\x12\x1e\n" +
+	"\x1aTRACKING_STATE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15TRACKING_STATE_ACTIVE\x10\x01\x12\x19\n" +
+	"\x15TRACKING_STATE_PAUSED\x10\x02*\x8f\x01\n" +
+	"\n" +
+	"LookupMode\x12\x1b\n" +
+	"\x17LOOKUP_MODE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14LOOKUP_MODE_PREVIOUS\x10\x01\x12\x14\n" +
+	"\x10LOOKUP_MODE_NEXT\x10\x02\x12\x17\n" +
+	"\x13LOOKUP_MODE_NEAREST\x10\x03\x12\x1b\n" +
+	"\x17LOOKUP_MODE_INTERPOLATE\x10\x04*P\n" +
+	"\tSortOrder\x12\x1a\n" +
+	"\x16SORT_ORDER_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fSORT_ORDER_DESC\x10\x01\x12\x12\n" +
+	"\x0eSORT_ORDER_ASC\x10\x02*\x8b\x01\n" +
+	"\x0eAlertCondition\x12\x1f\n" +
+	"\x1bALERT_CONDITION_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15ALERT_CONDITION_ABOVE\x10\x01\x12\x19\n" +
+	"\x15ALERT_CONDITION_BELOW\x10\x02\x12\"\n" +
+	"\x1eALERT_CONDITION_PERCENT_CHANGE\x10\x03*\x94\x01\n" +
+	"\fWebhookEvent\x12\x1d\n" +
+	"\x19WEBHOOK_EVENT_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bWEBHOOK_EVENT_PRICE_UPDATED\x10\x01\x12 \n" +
+	"\x1cWEBHOOK_EVENT_CURRENCY_ADDED\x10\x02\x12\"\n" +
+	"\x1eWEBHOOK_EVENT_CURRENCY_REMOVED\x10\x03*\xb0\x01\n" +
+	"\x15WebhookDeliveryStatus\x12'\n" +
+	"#WEBHOOK_DELIVERY_STATUS_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fWEBHOOK_DELIVERY_STATUS_PENDING\x10\x01\x12%\n" +
+	"!WEBHOOK_DELIVERY_STATUS_SUCCEEDED\x10\x02\x12\"\n" +
+	"\x1eWEBHOOK_DELIVERY_STATUS_FAILED\x10\x032\xbb\x10\n" +
 	"\x0fCurrencyService\x12j\n" +
 	"\vAddCurrency\x12\x1f.currency.v1.AddCurrencyRequest\x1a\x1d.currency.v1.CurrencyResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/api/v1/currency\x12o\n" +
-	"\x0eRemoveCurrency\x12\".currency.v1.RemoveCurrencyRequest\x1a\x16.google.protobuf.Empty\"!\x82\xd3\xe4\x93\x02\x1b*\x19/api/v1/currency/{symbol}\x12\x85\x01\n" +
-	"\x10GetCurrencyPrice\x12$.currency.v1.GetCurrencyPriceRequest\x1a\".currency.v1.CurrencyPriceResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/currency/{symbol}/price\x12i\n" +
-	"\x0eListCurrencies\x12\x16.google.protobuf.Empty\x1a#.currency.v1.ListCurrenciesResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/currencies\x12\x84\x01\n" +
-	"\x0fGetPriceHistory\x12#.currency.v1.GetPriceHistoryRequest\x1a!.currency.v1.PriceHistoryResponse\")\x82\xd3\xe4\x93\x02#\x12!/api/v1/currency/{symbol}/historyB9Z7github.com/kk7453603/RybakovTestGo/pkg/api/gen/currencyb\x06proto3"
+	"\x0eRemoveCurrency\x12\".currency.v1.RemoveCurrencyRequest\x1a\x16.google.protobuf.Empty\"!\x82\xd3\xe4\x93\x02\x1b*\x19/api/v1/currency/{symbol}\x12\x89\x01\n" +
+	"\x0eUpdateCurrency\x12\".currency.v1.UpdateCurrencyRequest\x1a\x1d.currency.v1.CurrencyResponse\"4\x82\xd3\xe4\x93\x02.:\bcurrency2\"/api/v1/currency/{currency.symbol}\x12\x83\x01\n" +
+	"\x0fRestoreCurrency\x12#.currency.v1.RestoreCurrencyRequest\x1a\x1d.currency.v1.CurrencyResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/currency/{symbol}/restore\x12x\n" +
+	"\x15ListRemovedCurrencies\x12\x16.google.protobuf.Empty\x1a#.currency.v1.ListCurrenciesResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1/currencies/removed\x12\x85\x01\n" +
+	"\x10GetCurrencyPrice\x12$.currency.v1.GetCurrencyPriceRequest\x1a\".currency.v1.CurrencyPriceResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/currency/{symbol}/price\x12}\n" +
+	"\x0eBatchGetPrices\x12\".currency.v1.BatchGetPricesRequest\x1a#.currency.v1.BatchGetPricesResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/v1/prices:batchGet\x12]\n" +
+	"\aConvert\x12\x1b.currency.v1.ConvertRequest\x1a\x1c.currency.v1.ConvertResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/api/v1/convert\x12u\n" +
+	"\x0eListCurrencies\x12\".currency.v1.ListCurrenciesRequest\x1a#.currency.v1.ListCurrenciesResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/currencies\x12\x84\x01\n" +
+	"\x0fGetPriceHistory\x12#.currency.v1.GetPriceHistoryRequest\x1a!.currency.v1.PriceHistoryResponse\")\x82\xd3\xe4\x93\x02#\x12!/api/v1/currency/{symbol}/history\x12u\n" +
+	"\n" +
+	"GetCandles\x12\x1e.currency.v1.GetCandlesRequest\x1a\x1c.currency.v1.CandlesResponse\")\x82\xd3\xe4\x93\x02#\x12!/api/v1/currency/{symbol}/candles\x12m\n" +
+	"\fStreamPrices\x12 .currency.v1.StreamPricesRequest\x1a\x1a.currency.v1.CurrencyPrice\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/prices/stream0\x01\x12\x7f\n" +
+	"\x0fBackfillHistory\x12#.currency.v1.BackfillHistoryRequest\x1a\x18.currency.v1.BackfillJob\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/api/v1/currency/{symbol}/backfill\x12m\n" +
+	"\x0eGetBackfillJob\x12\".currency.v1.GetBackfillJobRequest\x1a\x18.currency.v1.BackfillJob\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/backfill/{id}\x12\x83\x01\n" +
+	"\x0fSetCoinOverride\x12#.currency.v1.SetCoinOverrideRequest\x1a\x19.currency.v1.CoinOverride\"0\x82\xd3\xe4\x93\x02*:\x01*\x1a%/api/v1/admin/coin-overrides/{symbol}\x12\x83\x01\n" +
+	"\x12DeleteCoinOverride\x12&.currency.v1.DeleteCoinOverrideRequest\x1a\x16.google.protobuf.Empty\"-\x82\xd3\xe4\x93\x02'*%/api/v1/admin/coin-overrides/{symbol}\x12y\n" +
+	"\x11ListCoinOverrides\x12\x16.google.protobuf.Empty\x1a&.currency.v1.ListCoinOverridesResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v1/admin/coin-overrides2\xdf\x05\n" +
+	"\fAlertService\x12r\n" +
+	"\x0fCreateAlertRule\x12#.currency.v1.CreateAlertRuleRequest\x1a\x16.currency.v1.AlertRule\"\"\x82\xd3\xe4\x93\x02\x1c:\x04rule\"\x14/api/v1/alerts/rules\x12k\n" +
+	"\fGetAlertRule\x12 .currency.v1.GetAlertRuleRequest\x1a\x16.currency.v1.AlertRule\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/alerts/rules/{id}\x12w\n" +
+	"\x0eListAlertRules\x12\".currency.v1.ListAlertRulesRequest\x1a#.currency.v1.ListAlertRulesResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/alerts/rules\x12|\n" +
+	"\x0fUpdateAlertRule\x12#.currency.v1.UpdateAlertRuleRequest\x1a\x16.currency.v1.AlertRule\",\x82\xd3\xe4\x93\x02&:\x04rule2\x1e/api/v1/alerts/rules/{rule.id}\x12q\n" +
+	"\x0fDeleteAlertRule\x12#.currency.v1.DeleteAlertRuleRequest\x1a\x16.google.protobuf.Empty\"!\x82\xd3\xe4\x93\x02\x1b*\x19/api/v1/alerts/rules/{id}\x12\x83\x01\n" +
+	"\x11ListAlertTriggers\x12%.currency.v1.ListAlertTriggersRequest\x1a&.currency.v1.ListAlertTriggersResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/alerts/triggers2\xbb\x06\n" +
+	"\x0eWebhookService\x12\x84\x01\n" +
+	"\x15CreateWebhookEndpoint\x12).currency.v1.CreateWebhookEndpointRequest\x1a\x1c.currency.v1.WebhookEndpoint\"\"\x82\xd3\xe4\x93\x02\x1c:\bendpoint\"\x10/api/v1/webhooks\x12y\n" +
+	"\x12GetWebhookEndpoint\x12&.currency.v1.GetWebhookEndpointRequest\x1a\x1c.currency.v1.WebhookEndpoint\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/webhooks/{id}\x12s\n" +
+	"\x14ListWebhookEndpoints\x12\x16.google.protobuf.Empty\x1a).currency.v1.ListWebhookEndpointsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/webhooks\x12\x92\x01\n" +
+	"\x15UpdateWebhookEndpoint\x12).currency.v1.UpdateWebhookEndpointRequest\x1a\x1c.currency.v1.WebhookEndpoint\"0\x82\xd3\xe4\x93\x02*:\bendpoint2\x1e/api/v1/webhooks/{endpoint.id}\x12y\n" +
+	"\x15DeleteWebhookEndpoint\x12).currency.v1.DeleteWebhookEndpointRequest\x1a\x16.google.protobuf.Empty\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/api/v1/webhooks/{id}\x12\xa1\x01\n" +
+	"\x15ListWebhookDeliveries\x12).currency.v1.ListWebhookDeliveriesRequest\x1a*.currency.v1.ListWebhookDeliveriesResponse\"1\x82\xd3\xe4\x93\x02+\x12)/api/v1/webhooks/{endpoint_id}/deliveriesB9Z7github.com/kk7453603/RybakovTestGo/pkg/api/gen/currencyb\x06proto3"
 
 var (
 	file_service_proto_rawDescOnce sync.Once
@@ -631,47 +4343,227 @@ func file_service_proto_rawDescGZIP() []byte {
 	return file_service_proto_rawDescData
 }
 
-var file_service_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_service_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_service_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
 var file_service_proto_goTypes = []any{
-	(*Currency)(nil),                // 0: currency.v1.Currency
-	(*CurrencyPrice)(nil),           // 1: currency.v1.CurrencyPrice
-	(*AddCurrencyRequest)(nil),      // 2: currency.v1.AddCurrencyRequest
-	(*CurrencyResponse)(nil),        // 3: currency.v1.CurrencyResponse
-	(*RemoveCurrencyRequest)(nil),   // 4: currency.v1.RemoveCurrencyRequest
-	(*GetCurrencyPriceRequest)(nil), // 5: currency.v1.GetCurrencyPriceRequest
-	(*CurrencyPriceResponse)(nil),   // 6: currency.v1.CurrencyPriceResponse
-	(*ListCurrenciesResponse)(nil),  // 7: currency.v1.ListCurrenciesResponse
-	(*GetPriceHistoryRequest)(nil),  // 8: currency.v1.GetPriceHistoryRequest
-	(*PriceHistoryResponse)(nil),    // 9: currency.v1.PriceHistoryResponse
-	(*timestamppb.Timestamp)(nil),   // 10: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),           // 11: google.protobuf.Empty
+	(TrackingState)(0),                    // 0: currency.v1.TrackingState
+	(LookupMode)(0),                       // 1: currency.v1.LookupMode
+	(SortOrder)(0),                        // 2: currency.v1.SortOrder
+	(AlertCondition)(0),                   // 3: currency.v1.AlertCondition
+	(WebhookEvent)(0),                     // 4: currency.v1.WebhookEvent
+	(WebhookDeliveryStatus)(0),            // 5: currency.v1.WebhookDeliveryStatus
+	(*Currency)(nil),                      // 6: currency.v1.Currency
+	(*Decimal)(nil),                       // 7: currency.v1.Decimal
+	(*CurrencyPrice)(nil),                 // 8: currency.v1.CurrencyPrice
+	(*AddCurrencyRequest)(nil),            // 9: currency.v1.AddCurrencyRequest
+	(*CurrencyResponse)(nil),              // 10: currency.v1.CurrencyResponse
+	(*RemoveCurrencyRequest)(nil),         // 11: currency.v1.RemoveCurrencyRequest
+	(*UpdateCurrencyRequest)(nil),         // 12: currency.v1.UpdateCurrencyRequest
+	(*RestoreCurrencyRequest)(nil),        // 13: currency.v1.RestoreCurrencyRequest
+	(*GetCurrencyPriceRequest)(nil),       // 14: currency.v1.GetCurrencyPriceRequest
+	(*CurrencyPriceResponse)(nil),         // 15: currency.v1.CurrencyPriceResponse
+	(*BatchGetPricesRequest)(nil),         // 16: currency.v1.BatchGetPricesRequest
+	(*BatchPriceError)(nil),               // 17: currency.v1.BatchPriceError
+	(*BatchPriceResult)(nil),              // 18: currency.v1.BatchPriceResult
+	(*BatchGetPricesResponse)(nil),        // 19: currency.v1.BatchGetPricesResponse
+	(*ConvertRequest)(nil),                // 20: currency.v1.ConvertRequest
+	(*ConversionLeg)(nil),                 // 21: currency.v1.ConversionLeg
+	(*ConvertResponse)(nil),               // 22: currency.v1.ConvertResponse
+	(*ListCurrenciesRequest)(nil),         // 23: currency.v1.ListCurrenciesRequest
+	(*ListCurrenciesResponse)(nil),        // 24: currency.v1.ListCurrenciesResponse
+	(*GetPriceHistoryRequest)(nil),        // 25: currency.v1.GetPriceHistoryRequest
+	(*PriceHistoryResponse)(nil),          // 26: currency.v1.PriceHistoryResponse
+	(*StreamPricesRequest)(nil),           // 27: currency.v1.StreamPricesRequest
+	(*Candle)(nil),                        // 28: currency.v1.Candle
+	(*GetCandlesRequest)(nil),             // 29: currency.v1.GetCandlesRequest
+	(*CandlesResponse)(nil),               // 30: currency.v1.CandlesResponse
+	(*CoinOverride)(nil),                  // 31: currency.v1.CoinOverride
+	(*SetCoinOverrideRequest)(nil),        // 32: currency.v1.SetCoinOverrideRequest
+	(*DeleteCoinOverrideRequest)(nil),     // 33: currency.v1.DeleteCoinOverrideRequest
+	(*ListCoinOverridesResponse)(nil),     // 34: currency.v1.ListCoinOverridesResponse
+	(*BackfillHistoryRequest)(nil),        // 35: currency.v1.BackfillHistoryRequest
+	(*GetBackfillJobRequest)(nil),         // 36: currency.v1.GetBackfillJobRequest
+	(*BackfillJob)(nil),                   // 37: currency.v1.BackfillJob
+	(*AlertRule)(nil),                     // 38: currency.v1.AlertRule
+	(*CreateAlertRuleRequest)(nil),        // 39: currency.v1.CreateAlertRuleRequest
+	(*GetAlertRuleRequest)(nil),           // 40: currency.v1.GetAlertRuleRequest
+	(*ListAlertRulesRequest)(nil),         // 41: currency.v1.ListAlertRulesRequest
+	(*ListAlertRulesResponse)(nil),        // 42: currency.v1.ListAlertRulesResponse
+	(*UpdateAlertRuleRequest)(nil),        // 43: currency.v1.UpdateAlertRuleRequest
+	(*DeleteAlertRuleRequest)(nil),        // 44: currency.v1.DeleteAlertRuleRequest
+	(*ListAlertTriggersRequest)(nil),      // 45: currency.v1.ListAlertTriggersRequest
+	(*AlertTrigger)(nil),                  // 46: currency.v1.AlertTrigger
+	(*ListAlertTriggersResponse)(nil),     // 47: currency.v1.ListAlertTriggersResponse
+	(*WebhookEndpoint)(nil),               // 48: currency.v1.WebhookEndpoint
+	(*CreateWebhookEndpointRequest)(nil),  // 49: currency.v1.CreateWebhookEndpointRequest
+	(*GetWebhookEndpointRequest)(nil),     // 50: currency.v1.GetWebhookEndpointRequest
+	(*ListWebhookEndpointsResponse)(nil),  // 51: currency.v1.ListWebhookEndpointsResponse
+	(*UpdateWebhookEndpointRequest)(nil),  // 52: currency.v1.UpdateWebhookEndpointRequest
+	(*DeleteWebhookEndpointRequest)(nil),  // 53: currency.v1.DeleteWebhookEndpointRequest
+	(*ListWebhookDeliveriesRequest)(nil),  // 54: currency.v1.ListWebhookDeliveriesRequest
+	(*WebhookDelivery)(nil),               // 55: currency.v1.WebhookDelivery
+	(*ListWebhookDeliveriesResponse)(nil), // 56: currency.v1.ListWebhookDeliveriesResponse
+	(*timestamppb.Timestamp)(nil),         // 57: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),         // 58: google.protobuf.FieldMask
+	(*durationpb.Duration)(nil),           // 59: google.protobuf.Duration
+	(*emptypb.Empty)(nil),                 // 60: google.protobuf.Empty
 }
 var file_service_proto_depIdxs = []int32{
-	10, // 0: currency.v1.Currency.created_at:type_name -> google.protobuf.Timestamp
-	10, // 1: currency.v1.Currency.updated_at:type_name -> google.protobuf.Timestamp
-	10, // 2: currency.v1.CurrencyPrice.timestamp:type_name -> google.protobuf.Timestamp
-	0,  // 3: currency.v1.CurrencyResponse.currency:type_name -> currency.v1.Currency
-	10, // 4: currency.v1.GetCurrencyPriceRequest.timestamp:type_name -> google.protobuf.Timestamp
-	1,  // 5: currency.v1.CurrencyPriceResponse.price:type_name -> currency.v1.CurrencyPrice
-	0,  // 6: currency.v1.ListCurrenciesResponse.currencies:type_name -> currency.v1.Currency
-	10, // 7: currency.v1.GetPriceHistoryRequest.start_time:type_name -> google.protobuf.Timestamp
-	10, // 8: currency.v1.GetPriceHistoryRequest.end_time:type_name -> google.protobuf.Timestamp
-	1,  // 9: currency.v1.PriceHistoryResponse.prices:type_name -> currency.v1.CurrencyPrice
-	2,  // 10: currency.v1.CurrencyService.AddCurrency:input_type -> currency.v1.AddCurrencyRequest
-	4,  // 11: currency.v1.CurrencyService.RemoveCurrency:input_type -> currency.v1.RemoveCurrencyRequest
-	5,  // 12: currency.v1.CurrencyService.GetCurrencyPrice:input_type -> currency.v1.GetCurrencyPriceRequest
-	11, // 13: currency.v1.CurrencyService.ListCurrencies:input_type -> google.protobuf.Empty
-	8,  // 14: currency.v1.CurrencyService.GetPriceHistory:input_type -> currency.v1.GetPriceHistoryRequest
-	3,  // 15: currency.v1.CurrencyService.AddCurrency:output_type -> currency.v1.CurrencyResponse
-	11, // 16: currency.v1.CurrencyService.RemoveCurrency:output_type -> google.protobuf.Empty
-	6,  // 17: currency.v1.CurrencyService.GetCurrencyPrice:output_type -> currency.v1.CurrencyPriceResponse
-	7,  // 18: currency.v1.CurrencyService.ListCurrencies:output_type -> currency.v1.ListCurrenciesResponse
-	9,  // 19: currency.v1.CurrencyService.GetPriceHistory:output_type -> currency.v1.PriceHistoryResponse
-	15, // [15:20] is the sub-list for method output_type
-	10, // [10:15] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	57,  // 0: currency.v1.Currency.created_at:type_name -> google.protobuf.Timestamp
+	57,  // 1: currency.v1.Currency.updated_at:type_name -> google.protobuf.Timestamp
+	57,  // 2: currency.v1.Currency.deleted_at:type_name -> google.protobuf.Timestamp
+	0,   // 3: currency.v1.Currency.tracking:type_name -> currency.v1.TrackingState
+	7,   // 4: currency.v1.Currency.market_cap:type_name -> currency.v1.Decimal
+	57,  // 5: currency.v1.CurrencyPrice.timestamp:type_name -> google.protobuf.Timestamp
+	7,   // 6: currency.v1.CurrencyPrice.price_value:type_name -> currency.v1.Decimal
+	0,   // 7: currency.v1.AddCurrencyRequest.tracking:type_name -> currency.v1.TrackingState
+	6,   // 8: currency.v1.CurrencyResponse.currency:type_name -> currency.v1.Currency
+	6,   // 9: currency.v1.UpdateCurrencyRequest.currency:type_name -> currency.v1.Currency
+	58,  // 10: currency.v1.UpdateCurrencyRequest.update_mask:type_name -> google.protobuf.FieldMask
+	57,  // 11: currency.v1.GetCurrencyPriceRequest.timestamp:type_name -> google.protobuf.Timestamp
+	1,   // 12: currency.v1.GetCurrencyPriceRequest.mode:type_name -> currency.v1.LookupMode
+	59,  // 13: currency.v1.GetCurrencyPriceRequest.max_staleness:type_name -> google.protobuf.Duration
+	8,   // 14: currency.v1.CurrencyPriceResponse.price:type_name -> currency.v1.CurrencyPrice
+	57,  // 15: currency.v1.CurrencyPriceResponse.requested_time:type_name -> google.protobuf.Timestamp
+	59,  // 16: currency.v1.CurrencyPriceResponse.distance:type_name -> google.protobuf.Duration
+	57,  // 17: currency.v1.BatchGetPricesRequest.timestamps:type_name -> google.protobuf.Timestamp
+	1,   // 18: currency.v1.BatchGetPricesRequest.mode:type_name -> currency.v1.LookupMode
+	59,  // 19: currency.v1.BatchGetPricesRequest.max_staleness:type_name -> google.protobuf.Duration
+	57,  // 20: currency.v1.BatchPriceResult.requested_time:type_name -> google.protobuf.Timestamp
+	8,   // 21: currency.v1.BatchPriceResult.price:type_name -> currency.v1.CurrencyPrice
+	59,  // 22: currency.v1.BatchPriceResult.distance:type_name -> google.protobuf.Duration
+	17,  // 23: currency.v1.BatchPriceResult.error:type_name -> currency.v1.BatchPriceError
+	18,  // 24: currency.v1.BatchGetPricesResponse.results:type_name -> currency.v1.BatchPriceResult
+	57,  // 25: currency.v1.ConvertRequest.timestamp:type_name -> google.protobuf.Timestamp
+	1,   // 26: currency.v1.ConvertRequest.mode:type_name -> currency.v1.LookupMode
+	59,  // 27: currency.v1.ConvertRequest.max_staleness:type_name -> google.protobuf.Duration
+	8,   // 28: currency.v1.ConversionLeg.price:type_name -> currency.v1.CurrencyPrice
+	59,  // 29: currency.v1.ConversionLeg.distance:type_name -> google.protobuf.Duration
+	7,   // 30: currency.v1.ConvertResponse.amount:type_name -> currency.v1.Decimal
+	7,   // 31: currency.v1.ConvertResponse.rate:type_name -> currency.v1.Decimal
+	7,   // 32: currency.v1.ConvertResponse.converted_amount:type_name -> currency.v1.Decimal
+	21,  // 33: currency.v1.ConvertResponse.from_leg:type_name -> currency.v1.ConversionLeg
+	21,  // 34: currency.v1.ConvertResponse.to_leg:type_name -> currency.v1.ConversionLeg
+	0,   // 35: currency.v1.ListCurrenciesRequest.tracking:type_name -> currency.v1.TrackingState
+	6,   // 36: currency.v1.ListCurrenciesResponse.currencies:type_name -> currency.v1.Currency
+	57,  // 37: currency.v1.GetPriceHistoryRequest.start_time:type_name -> google.protobuf.Timestamp
+	57,  // 38: currency.v1.GetPriceHistoryRequest.end_time:type_name -> google.protobuf.Timestamp
+	2,   // 39: currency.v1.GetPriceHistoryRequest.order:type_name -> currency.v1.SortOrder
+	8,   // 40: currency.v1.PriceHistoryResponse.prices:type_name -> currency.v1.CurrencyPrice
+	57,  // 41: currency.v1.Candle.open_time:type_name -> google.protobuf.Timestamp
+	7,   // 42: currency.v1.Candle.open_value:type_name -> currency.v1.Decimal
+	7,   // 43: currency.v1.Candle.high_value:type_name -> currency.v1.Decimal
+	7,   // 44: currency.v1.Candle.low_value:type_name -> currency.v1.Decimal
+	7,   // 45: currency.v1.Candle.close_value:type_name -> currency.v1.Decimal
+	57,  // 46: currency.v1.GetCandlesRequest.start_time:type_name -> google.protobuf.Timestamp
+	57,  // 47: currency.v1.GetCandlesRequest.end_time:type_name -> google.protobuf.Timestamp
+	28,  // 48: currency.v1.CandlesResponse.candles:type_name -> currency.v1.Candle
+	57,  // 49: currency.v1.CoinOverride.updated_at:type_name -> google.protobuf.Timestamp
+	31,  // 50: currency.v1.ListCoinOverridesResponse.overrides:type_name -> currency.v1.CoinOverride
+	57,  // 51: currency.v1.BackfillHistoryRequest.start_time:type_name -> google.protobuf.Timestamp
+	57,  // 52: currency.v1.BackfillHistoryRequest.end_time:type_name -> google.protobuf.Timestamp
+	57,  // 53: currency.v1.BackfillJob.start_time:type_name -> google.protobuf.Timestamp
+	57,  // 54: currency.v1.BackfillJob.end_time:type_name -> google.protobuf.Timestamp
+	57,  // 55: currency.v1.BackfillJob.cursor:type_name -> google.protobuf.Timestamp
+	57,  // 56: currency.v1.BackfillJob.created_at:type_name -> google.protobuf.Timestamp
+	57,  // 57: currency.v1.BackfillJob.updated_at:type_name -> google.protobuf.Timestamp
+	3,   // 58: currency.v1.AlertRule.condition:type_name -> currency.v1.AlertCondition
+	7,   // 59: currency.v1.AlertRule.threshold:type_name -> currency.v1.Decimal
+	59,  // 60: currency.v1.AlertRule.window:type_name -> google.protobuf.Duration
+	59,  // 61: currency.v1.AlertRule.cooldown:type_name -> google.protobuf.Duration
+	57,  // 62: currency.v1.AlertRule.last_triggered_at:type_name -> google.protobuf.Timestamp
+	57,  // 63: currency.v1.AlertRule.created_at:type_name -> google.protobuf.Timestamp
+	57,  // 64: currency.v1.AlertRule.updated_at:type_name -> google.protobuf.Timestamp
+	38,  // 65: currency.v1.CreateAlertRuleRequest.rule:type_name -> currency.v1.AlertRule
+	38,  // 66: currency.v1.ListAlertRulesResponse.rules:type_name -> currency.v1.AlertRule
+	38,  // 67: currency.v1.UpdateAlertRuleRequest.rule:type_name -> currency.v1.AlertRule
+	58,  // 68: currency.v1.UpdateAlertRuleRequest.update_mask:type_name -> google.protobuf.FieldMask
+	3,   // 69: currency.v1.AlertTrigger.condition:type_name -> currency.v1.AlertCondition
+	7,   // 70: currency.v1.AlertTrigger.threshold:type_name -> currency.v1.Decimal
+	7,   // 71: currency.v1.AlertTrigger.price:type_name -> currency.v1.Decimal
+	57,  // 72: currency.v1.AlertTrigger.price_time:type_name -> google.protobuf.Timestamp
+	7,   // 73: currency.v1.AlertTrigger.reference_price:type_name -> currency.v1.Decimal
+	7,   // 74: currency.v1.AlertTrigger.change_percent:type_name -> currency.v1.Decimal
+	57,  // 75: currency.v1.AlertTrigger.triggered_at:type_name -> google.protobuf.Timestamp
+	46,  // 76: currency.v1.ListAlertTriggersResponse.triggers:type_name -> currency.v1.AlertTrigger
+	4,   // 77: currency.v1.WebhookEndpoint.events:type_name -> currency.v1.WebhookEvent
+	57,  // 78: currency.v1.WebhookEndpoint.disabled_at:type_name -> google.protobuf.Timestamp
+	57,  // 79: currency.v1.WebhookEndpoint.created_at:type_name -> google.protobuf.Timestamp
+	57,  // 80: currency.v1.WebhookEndpoint.updated_at:type_name -> google.protobuf.Timestamp
+	48,  // 81: currency.v1.CreateWebhookEndpointRequest.endpoint:type_name -> currency.v1.WebhookEndpoint
+	48,  // 82: currency.v1.ListWebhookEndpointsResponse.endpoints:type_name -> currency.v1.WebhookEndpoint
+	48,  // 83: currency.v1.UpdateWebhookEndpointRequest.endpoint:type_name -> currency.v1.WebhookEndpoint
+	58,  // 84: currency.v1.UpdateWebhookEndpointRequest.update_mask:type_name -> google.protobuf.FieldMask
+	5,   // 85: currency.v1.ListWebhookDeliveriesRequest.status:type_name -> currency.v1.WebhookDeliveryStatus
+	4,   // 86: currency.v1.WebhookDelivery.event:type_name -> currency.v1.WebhookEvent
+	5,   // 87: currency.v1.WebhookDelivery.status:type_name -> currency.v1.WebhookDeliveryStatus
+	57,  // 88: currency.v1.WebhookDelivery.next_attempt_at:type_name -> google.protobuf.Timestamp
+	57,  // 89: currency.v1.WebhookDelivery.delivered_at:type_name -> google.protobuf.Timestamp
+	57,  // 90: currency.v1.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	55,  // 91: currency.v1.ListWebhookDeliveriesResponse.deliveries:type_name -> currency.v1.WebhookDelivery
+	9,   // 92: currency.v1.CurrencyService.AddCurrency:input_type -> currency.v1.AddCurrencyRequest
+	11,  // 93: currency.v1.CurrencyService.RemoveCurrency:input_type -> currency.v1.RemoveCurrencyRequest
+	12,  // 94: currency.v1.CurrencyService.UpdateCurrency:input_type -> currency.v1.UpdateCurrencyRequest
+	13,  // 95: currency.v1.CurrencyService.RestoreCurrency:input_type -> currency.v1.RestoreCurrencyRequest
+	60,  // 96: currency.v1.CurrencyService.ListRemovedCurrencies:input_type -> google.protobuf.Empty
+	14,  // 97: currency.v1.CurrencyService.GetCurrencyPrice:input_type -> currency.v1.GetCurrencyPriceRequest
+	16,  // 98: currency.v1.CurrencyService.BatchGetPrices:input_type -> currency.v1.BatchGetPricesRequest
+	20,  // 99: currency.v1.CurrencyService.Convert:input_type -> currency.v1.ConvertRequest
+	23,  // 100: currency.v1.CurrencyService.ListCurrencies:input_type -> currency.v1.ListCurrenciesRequest
+	25,  // 101: currency.v1.CurrencyService.GetPriceHistory:input_type -> currency.v1.GetPriceHistoryRequest
+	29,  // 102: currency.v1.CurrencyService.GetCandles:input_type -> currency.v1.GetCandlesRequest
+	27,  // 103: currency.v1.CurrencyService.StreamPrices:input_type -> currency.v1.StreamPricesRequest
+	35,  // 104: currency.v1.CurrencyService.BackfillHistory:input_type -> currency.v1.BackfillHistoryRequest
+	36,  // 105: currency.v1.CurrencyService.GetBackfillJob:input_type -> currency.v1.GetBackfillJobRequest
+	32,  // 106: currency.v1.CurrencyService.SetCoinOverride:input_type -> currency.v1.SetCoinOverrideRequest
+	33,  // 107: currency.v1.CurrencyService.DeleteCoinOverride:input_type -> currency.v1.DeleteCoinOverrideRequest
+	60,  // 108: currency.v1.CurrencyService.ListCoinOverrides:input_type -> google.protobuf.Empty
+	39,  // 109: currency.v1.AlertService.CreateAlertRule:input_type -> currency.v1.CreateAlertRuleRequest
+	40,  // 110: currency.v1.AlertService.GetAlertRule:input_type -> currency.v1.GetAlertRuleRequest
+	41,  // 111: currency.v1.AlertService.ListAlertRules:input_type -> currency.v1.ListAlertRulesRequest
+	43,  // 112: currency.v1.AlertService.UpdateAlertRule:input_type -> currency.v1.UpdateAlertRuleRequest
+	44,  // 113: currency.v1.AlertService.DeleteAlertRule:input_type -> currency.v1.DeleteAlertRuleRequest
+	45,  // 114: currency.v1.AlertService.ListAlertTriggers:input_type -> currency.v1.ListAlertTriggersRequest
+	49,  // 115: currency.v1.WebhookService.CreateWebhookEndpoint:input_type -> currency.v1.CreateWebhookEndpointRequest
+	50,  // 116: currency.v1.WebhookService.GetWebhookEndpoint:input_type -> currency.v1.GetWebhookEndpointRequest
+	60,  // 117: currency.v1.WebhookService.ListWebhookEndpoints:input_type -> google.protobuf.Empty
+	52,  // 118: currency.v1.WebhookService.UpdateWebhookEndpoint:input_type -> currency.v1.UpdateWebhookEndpointRequest
+	53,  // 119: currency.v1.WebhookService.DeleteWebhookEndpoint:input_type -> currency.v1.DeleteWebhookEndpointRequest
+	54,  // 120: currency.v1.WebhookService.ListWebhookDeliveries:input_type -> currency.v1.ListWebhookDeliveriesRequest
+	10,  // 121: currency.v1.CurrencyService.AddCurrency:output_type -> currency.v1.CurrencyResponse
+	60,  // 122: currency.v1.CurrencyService.RemoveCurrency:output_type -> google.protobuf.Empty
+	10,  // 123: currency.v1.CurrencyService.UpdateCurrency:output_type -> currency.v1.CurrencyResponse
+	10,  // 124: currency.v1.CurrencyService.RestoreCurrency:output_type -> currency.v1.CurrencyResponse
+	24,  // 125: currency.v1.CurrencyService.ListRemovedCurrencies:output_type -> currency.v1.ListCurrenciesResponse
+	15,  // 126: currency.v1.CurrencyService.GetCurrencyPrice:output_type -> currency.v1.CurrencyPriceResponse
+	19,  // 127: currency.v1.CurrencyService.BatchGetPrices:output_type -> currency.v1.BatchGetPricesResponse
+	22,  // 128: currency.v1.CurrencyService.Convert:output_type -> currency.v1.ConvertResponse
+	24,  // 129: currency.v1.CurrencyService.ListCurrencies:output_type -> currency.v1.ListCurrenciesResponse
+	26,  // 130: currency.v1.CurrencyService.GetPriceHistory:output_type -> currency.v1.PriceHistoryResponse
+	30,  // 131: currency.v1.CurrencyService.GetCandles:output_type -> currency.v1.CandlesResponse
+	8,   // 132: currency.v1.CurrencyService.StreamPrices:output_type -> currency.v1.CurrencyPrice
+	37,  // 133: currency.v1.CurrencyService.BackfillHistory:output_type -> currency.v1.BackfillJob
+	37,  // 134: currency.v1.CurrencyService.GetBackfillJob:output_type -> currency.v1.BackfillJob
+	31,  // 135: currency.v1.CurrencyService.SetCoinOverride:output_type -> currency.v1.CoinOverride
+	60,  // 136: currency.v1.CurrencyService.DeleteCoinOverride:output_type -> google.protobuf.Empty
+	34,  // 137: currency.v1.CurrencyService.ListCoinOverrides:output_type -> currency.v1.ListCoinOverridesResponse
+	38,  // 138: currency.v1.AlertService.CreateAlertRule:output_type -> currency.v1.AlertRule
+	38,  // 139: currency.v1.AlertService.GetAlertRule:output_type -> currency.v1.AlertRule
+	42,  // 140: currency.v1.AlertService.ListAlertRules:output_type -> currency.v1.ListAlertRulesResponse
+	38,  // 141: currency.v1.AlertService.UpdateAlertRule:output_type -> currency.v1.AlertRule
+	60,  // 142: currency.v1.AlertService.DeleteAlertRule:output_type -> google.protobuf.Empty
+	47,  // 143: currency.v1.AlertService.ListAlertTriggers:output_type -> currency.v1.ListAlertTriggersResponse
+	48,  // 144: currency.v1.WebhookService.CreateWebhookEndpoint:output_type -> currency.v1.WebhookEndpoint
+	48,  // 145: currency.v1.WebhookService.GetWebhookEndpoint:output_type -> currency.v1.WebhookEndpoint
+	51,  // 146: currency.v1.WebhookService.ListWebhookEndpoints:output_type -> currency.v1.ListWebhookEndpointsResponse
+	48,  // 147: currency.v1.WebhookService.UpdateWebhookEndpoint:output_type -> currency.v1.WebhookEndpoint
+	60,  // 148: currency.v1.WebhookService.DeleteWebhookEndpoint:output_type -> google.protobuf.Empty
+	56,  // 149: currency.v1.WebhookService.ListWebhookDeliveries:output_type -> currency.v1.ListWebhookDeliveriesResponse
+	121, // [121:150] is the sub-list for method output_type
+	92,  // [92:121] is the sub-list for method input_type
+	92,  // [92:92] is the sub-list for extension type_name
+	92,  // [92:92] is the sub-list for extension extendee
+	0,   // [0:92] is the sub-list for field type_name
 }
 
 func init() { file_service_proto_init() }
@@ -679,18 +4571,22 @@ func file_service_proto_init() {
 	if File_service_proto != nil {
 		return
 	}
+	file_service_proto_msgTypes[3].OneofWrappers = []any{}
+	file_service_proto_msgTypes[32].OneofWrappers = []any{}
+	file_service_proto_msgTypes[42].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_service_proto_rawDesc), len(file_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   10,
+			NumEnums:      6,
+			NumMessages:   51,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   3,
 		},
 		GoTypes:           file_service_proto_goTypes,
 		DependencyIndexes: file_service_proto_depIdxs,
+		EnumInfos:         file_service_proto_enumTypes,
 		MessageInfos:      file_service_proto_msgTypes,
 	}.Build()
 	File_service_proto = out.File
@@ -20,11 +20,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CurrencyService_AddCurrency_FullMethodName      = "/currency.v1.CurrencyService/AddCurrency"
-	CurrencyService_RemoveCurrency_FullMethodName   = "/currency.v1.CurrencyService/RemoveCurrency"
-	CurrencyService_GetCurrencyPrice_FullMethodName = "/currency.v1.CurrencyService/GetCurrencyPrice"
-	CurrencyService_ListCurrencies_FullMethodName   = "/currency.v1.CurrencyService/ListCurrencies"
-	CurrencyService_GetPriceHistory_FullMethodName  = "/currency.v1.CurrencyService/GetPriceHistory"
+	CurrencyService_AddCurrency_FullMethodName           = "/currency.v1.CurrencyService/AddCurrency"
+	CurrencyService_RemoveCurrency_FullMethodName        = "/currency.v1.CurrencyService/RemoveCurrency"
+	CurrencyService_UpdateCurrency_FullMethodName        = "/currency.v1.CurrencyService/UpdateCurrency"
+	CurrencyService_RestoreCurrency_FullMethodName       = "/currency.v1.CurrencyService/RestoreCurrency"
+	CurrencyService_ListRemovedCurrencies_FullMethodName = "/currency.v1.CurrencyService/ListRemovedCurrencies"
+	CurrencyService_GetCurrencyPrice_FullMethodName      = "/currency.v1.CurrencyService/GetCurrencyPrice"
+	CurrencyService_BatchGetPrices_FullMethodName        = "/currency.v1.CurrencyService/BatchGetPrices"
+	CurrencyService_Convert_FullMethodName               = "/currency.v1.CurrencyService/Convert"
+	CurrencyService_ListCurrencies_FullMethodName        = "/currency.v1.CurrencyService/ListCurrencies"
+	CurrencyService_GetPriceHistory_FullMethodName       = "/currency.v1.CurrencyService/GetPriceHistory"
+	CurrencyService_GetCandles_FullMethodName            = "/currency.v1.CurrencyService/GetCandles"
+	CurrencyService_StreamPrices_FullMethodName          = "/currency.v1.CurrencyService/StreamPrices"
+	CurrencyService_BackfillHistory_FullMethodName       = "/currency.v1.CurrencyService/BackfillHistory"
+	CurrencyService_GetBackfillJob_FullMethodName        = "/currency.v1.CurrencyService/GetBackfillJob"
+	CurrencyService_SetCoinOverride_FullMethodName       = "/currency.v1.CurrencyService/SetCoinOverride"
+	CurrencyService_DeleteCoinOverride_FullMethodName    = "/currency.v1.CurrencyService/DeleteCoinOverride"
+	CurrencyService_ListCoinOverrides_FullMethodName     = "/currency.v1.CurrencyService/ListCoinOverrides"
 )
 
 // CurrencyServiceClient is the client API for CurrencyService service.
@@ -37,12 +49,37 @@ type CurrencyServiceClient interface {
 	AddCurrency(ctx context.Context, in *AddCurrencyRequest, opts ...grpc.CallOption) (*CurrencyResponse, error)
 	// Удаление криптовалюты из списка наблюдения
 	RemoveCurrency(ctx context.Context, in *RemoveCurrencyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Частичное изменение криптовалюты: обновляются только поля из update_mask
+	UpdateCurrency(ctx context.Context, in *UpdateCurrencyRequest, opts ...grpc.CallOption) (*CurrencyResponse, error)
+	// Восстановление удалённой, но ещё не очищенной криптовалюты
+	RestoreCurrency(ctx context.Context, in *RestoreCurrencyRequest, opts ...grpc.CallOption) (*CurrencyResponse, error)
+	// Список удалённых криптовалют, ожидающих очистки
+	ListRemovedCurrencies(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCurrenciesResponse, error)
 	// Получение цены конкретной криптовалюты
 	GetCurrencyPrice(ctx context.Context, in *GetCurrencyPriceRequest, opts ...grpc.CallOption) (*CurrencyPriceResponse, error)
-	// Получение списка всех отслеживаемых криптовалют
-	ListCurrencies(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCurrenciesResponse, error)
+	// Цены многих криптовалют на несколько моментов времени за один запрос.
+	// Ошибки отдельных элементов возвращаются в их результатах и не прерывают пакет.
+	BatchGetPrices(ctx context.Context, in *BatchGetPricesRequest, opts ...grpc.CallOption) (*BatchGetPricesResponse, error)
+	// Пересчёт суммы из одной валюты в другую через их цены в USD
+	Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error)
+	// Постраничный список отслеживаемых криптовалют с фильтрами и сортировкой
+	ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error)
 	// Получение исторических данных о ценах
 	GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*PriceHistoryResponse, error)
+	// Получение OHLC-свечей за период
+	GetCandles(ctx context.Context, in *GetCandlesRequest, opts ...grpc.CallOption) (*CandlesResponse, error)
+	// Поток новых цен по подписанным криптовалютам (через gateway - Server-Sent Events)
+	StreamPrices(ctx context.Context, in *StreamPricesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CurrencyPrice], error)
+	// Запуск фоновой загрузки истории цен за период
+	BackfillHistory(ctx context.Context, in *BackfillHistoryRequest, opts ...grpc.CallOption) (*BackfillJob, error)
+	// Состояние задачи загрузки истории
+	GetBackfillJob(ctx context.Context, in *GetBackfillJobRequest, opts ...grpc.CallOption) (*BackfillJob, error)
+	// Привязка тикера к идентификатору монеты провайдера (для неоднозначных тикеров)
+	SetCoinOverride(ctx context.Context, in *SetCoinOverrideRequest, opts ...grpc.CallOption) (*CoinOverride, error)
+	// Удаление привязки тикера
+	DeleteCoinOverride(ctx context.Context, in *DeleteCoinOverrideRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Список привязок тикеров
+	ListCoinOverrides(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCoinOverridesResponse, error)
 }
 
 type currencyServiceClient struct {
@@ -73,6 +110,36 @@ func (c *currencyServiceClient) RemoveCurrency(ctx context.Context, in *RemoveCu
 	return out, nil
 }
 
+func (c *currencyServiceClient) UpdateCurrency(ctx context.Context, in *UpdateCurrencyRequest, opts ...grpc.CallOption) (*CurrencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CurrencyResponse)
+	err := c.cc.Invoke(ctx, CurrencyService_UpdateCurrency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyServiceClient) RestoreCurrency(ctx context.Context, in *RestoreCurrencyRequest, opts ...grpc.CallOption) (*CurrencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CurrencyResponse)
+	err := c.cc.Invoke(ctx, CurrencyService_RestoreCurrency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyServiceClient) ListRemovedCurrencies(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCurrenciesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCurrenciesResponse)
+	err := c.cc.Invoke(ctx, CurrencyService_ListRemovedCurrencies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *currencyServiceClient) GetCurrencyPrice(ctx context.Context, in *GetCurrencyPriceRequest, opts ...grpc.CallOption) (*CurrencyPriceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CurrencyPriceResponse)
@@ -83,7 +150,27 @@ func (c *currencyServiceClient) GetCurrencyPrice(ctx context.Context, in *GetCur
 	return out, nil
 }
 
-func (c *currencyServiceClient) ListCurrencies(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCurrenciesResponse, error) {
+func (c *currencyServiceClient) BatchGetPrices(ctx context.Context, in *BatchGetPricesRequest, opts ...grpc.CallOption) (*BatchGetPricesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetPricesResponse)
+	err := c.cc.Invoke(ctx, CurrencyService_BatchGetPrices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyServiceClient) Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConvertResponse)
+	err := c.cc.Invoke(ctx, CurrencyService_Convert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyServiceClient) ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListCurrenciesResponse)
 	err := c.cc.Invoke(ctx, CurrencyService_ListCurrencies_FullMethodName, in, out, cOpts...)
@@ -103,6 +190,85 @@ func (c *currencyServiceClient) GetPriceHistory(ctx context.Context, in *GetPric
 	return out, nil
 }
 
+func (c *currencyServiceClient) GetCandles(ctx context.Context, in *GetCandlesRequest, opts ...grpc.CallOption) (*CandlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CandlesResponse)
+	err := c.cc.Invoke(ctx, CurrencyService_GetCandles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyServiceClient) StreamPrices(ctx context.Context, in *StreamPricesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CurrencyPrice], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CurrencyService_ServiceDesc.Streams[0], CurrencyService_StreamPrices_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamPricesRequest, CurrencyPrice]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CurrencyService_StreamPricesClient = grpc.ServerStreamingClient[CurrencyPrice]
+
+func (c *currencyServiceClient) BackfillHistory(ctx context.Context, in *BackfillHistoryRequest, opts ...grpc.CallOption) (*BackfillJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BackfillJob)
+	err := c.cc.Invoke(ctx, CurrencyService_BackfillHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyServiceClient) GetBackfillJob(ctx context.Context, in *GetBackfillJobRequest, opts ...grpc.CallOption) (*BackfillJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BackfillJob)
+	err := c.cc.Invoke(ctx, CurrencyService_GetBackfillJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyServiceClient) SetCoinOverride(ctx context.Context, in *SetCoinOverrideRequest, opts ...grpc.CallOption) (*CoinOverride, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CoinOverride)
+	err := c.cc.Invoke(ctx, CurrencyService_SetCoinOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyServiceClient) DeleteCoinOverride(ctx context.Context, in *DeleteCoinOverrideRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CurrencyService_DeleteCoinOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyServiceClient) ListCoinOverrides(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCoinOverridesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCoinOverridesResponse)
+	err := c.cc.Invoke(ctx, CurrencyService_ListCoinOverrides_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CurrencyServiceServer is the server API for CurrencyService service.
 // All implementations must embed UnimplementedCurrencyServiceServer
 // for forward compatibility.
@@ -113,12 +279,37 @@ type CurrencyServiceServer interface {
 	AddCurrency(context.Context, *AddCurrencyRequest) (*CurrencyResponse, error)
 	// Удаление криптовалюты из списка наблюдения
 	RemoveCurrency(context.Context, *RemoveCurrencyRequest) (*emptypb.Empty, error)
+	// Частичное изменение криптовалюты: обновляются только поля из update_mask
+	UpdateCurrency(context.Context, *UpdateCurrencyRequest) (*CurrencyResponse, error)
+	// Восстановление удалённой, но ещё не очищенной криптовалюты
+	RestoreCurrency(context.Context, *RestoreCurrencyRequest) (*CurrencyResponse, error)
+	// Список удалённых криптовалют, ожидающих очистки
+	ListRemovedCurrencies(context.Context, *emptypb.Empty) (*ListCurrenciesResponse, error)
 	// Получение цены конкретной криптовалюты
 	GetCurrencyPrice(context.Context, *GetCurrencyPriceRequest) (*CurrencyPriceResponse, error)
-	// Получение списка всех отслеживаемых криптовалют
-	ListCurrencies(context.Context, *emptypb.Empty) (*ListCurrenciesResponse, error)
+	// Цены многих криптовалют на несколько моментов времени за один запрос.
+	// Ошибки отдельных элементов возвращаются в их результатах и не прерывают пакет.
+	BatchGetPrices(context.Context, *BatchGetPricesRequest) (*BatchGetPricesResponse, error)
+	// Пересчёт суммы из одной валюты в другую через их цены в USD
+	Convert(context.Context, *ConvertRequest) (*ConvertResponse, error)
+	// Постраничный список отслеживаемых криптовалют с фильтрами и сортировкой
+	ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error)
 	// Получение исторических данных о ценах
 	GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*PriceHistoryResponse, error)
+	// Получение OHLC-свечей за период
+	GetCandles(context.Context, *GetCandlesRequest) (*CandlesResponse, error)
+	// Поток новых цен по подписанным криптовалютам (через gateway - Server-Sent Events)
+	StreamPrices(*StreamPricesRequest, grpc.ServerStreamingServer[CurrencyPrice]) error
+	// Запуск фоновой загрузки истории цен за период
+	BackfillHistory(context.Context, *BackfillHistoryRequest) (*BackfillJob, error)
+	// Состояние задачи загрузки истории
+	GetBackfillJob(context.Context, *GetBackfillJobRequest) (*BackfillJob, error)
+	// Привязка тикера к идентификатору монеты провайдера (для неоднозначных тикеров)
+	SetCoinOverride(context.Context, *SetCoinOverrideRequest) (*CoinOverride, error)
+	// Удаление привязки тикера
+	DeleteCoinOverride(context.Context, *DeleteCoinOverrideRequest) (*emptypb.Empty, error)
+	// Список привязок тикеров
+	ListCoinOverrides(context.Context, *emptypb.Empty) (*ListCoinOverridesResponse, error)
 	mustEmbedUnimplementedCurrencyServiceServer()
 }
 
@@ -135,15 +326,51 @@ func (UnimplementedCurrencyServiceServer) AddCurrency(context.Context, *AddCurre
 func (UnimplementedCurrencyServiceServer) RemoveCurrency(context.Context, *RemoveCurrencyRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveCurrency not implemented")
 }
+func (UnimplementedCurrencyServiceServer) UpdateCurrency(context.Context, *UpdateCurrencyRequest) (*CurrencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCurrency not implemented")
+}
+func (UnimplementedCurrencyServiceServer) RestoreCurrency(context.Context, *RestoreCurrencyRequest) (*CurrencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreCurrency not implemented")
+}
+func (UnimplementedCurrencyServiceServer) ListRemovedCurrencies(context.Context, *emptypb.Empty) (*ListCurrenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRemovedCurrencies not implemented")
+}
 func (UnimplementedCurrencyServiceServer) GetCurrencyPrice(context.Context, *GetCurrencyPriceRequest) (*CurrencyPriceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCurrencyPrice not implemented")
 }
-func (UnimplementedCurrencyServiceServer) ListCurrencies(context.Context, *emptypb.Empty) (*ListCurrenciesResponse, error) {
+func (UnimplementedCurrencyServiceServer) BatchGetPrices(context.Context, *BatchGetPricesRequest) (*BatchGetPricesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetPrices not implemented")
+}
+func (UnimplementedCurrencyServiceServer) Convert(context.Context, *ConvertRequest) (*ConvertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Convert not implemented")
+}
+func (UnimplementedCurrencyServiceServer) ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListCurrencies not implemented")
 }
 func (UnimplementedCurrencyServiceServer) GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*PriceHistoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPriceHistory not implemented")
 }
+func (UnimplementedCurrencyServiceServer) GetCandles(context.Context, *GetCandlesRequest) (*CandlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCandles not implemented")
+}
+func (UnimplementedCurrencyServiceServer) StreamPrices(*StreamPricesRequest, grpc.ServerStreamingServer[CurrencyPrice]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPrices not implemented")
+}
+func (UnimplementedCurrencyServiceServer) BackfillHistory(context.Context, *BackfillHistoryRequest) (*BackfillJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackfillHistory not implemented")
+}
+func (UnimplementedCurrencyServiceServer) GetBackfillJob(context.Context, *GetBackfillJobRequest) (*BackfillJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBackfillJob not implemented")
+}
+func (UnimplementedCurrencyServiceServer) SetCoinOverride(context.Context, *SetCoinOverrideRequest) (*CoinOverride, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCoinOverride not implemented")
+}
+func (UnimplementedCurrencyServiceServer) DeleteCoinOverride(context.Context, *DeleteCoinOverrideRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCoinOverride not implemented")
+}
+func (UnimplementedCurrencyServiceServer) ListCoinOverrides(context.Context, *emptypb.Empty) (*ListCoinOverridesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCoinOverrides not implemented")
+}
 func (UnimplementedCurrencyServiceServer) mustEmbedUnimplementedCurrencyServiceServer() {}
 func (UnimplementedCurrencyServiceServer) testEmbeddedByValue()                         {}
 
@@ -201,6 +428,60 @@ func _CurrencyService_RemoveCurrency_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CurrencyService_UpdateCurrency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCurrencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).UpdateCurrency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CurrencyService_UpdateCurrency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).UpdateCurrency(ctx, req.(*UpdateCurrencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyService_RestoreCurrency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreCurrencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).RestoreCurrency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CurrencyService_RestoreCurrency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).RestoreCurrency(ctx, req.(*RestoreCurrencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyService_ListRemovedCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).ListRemovedCurrencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CurrencyService_ListRemovedCurrencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).ListRemovedCurrencies(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CurrencyService_GetCurrencyPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetCurrencyPriceRequest)
 	if err := dec(in); err != nil {
@@ -219,8 +500,44 @@ func _CurrencyService_GetCurrencyPrice_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CurrencyService_BatchGetPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetPricesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).BatchGetPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CurrencyService_BatchGetPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).BatchGetPrices(ctx, req.(*BatchGetPricesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyService_Convert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).Convert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CurrencyService_Convert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).Convert(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CurrencyService_ListCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(ListCurrenciesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -232,7 +549,7 @@ func _CurrencyService_ListCurrencies_Handler(srv interface{}, ctx context.Contex
 		FullMethod: CurrencyService_ListCurrencies_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CurrencyServiceServer).ListCurrencies(ctx, req.(*emptypb.Empty))
+		return srv.(CurrencyServiceServer).ListCurrencies(ctx, req.(*ListCurrenciesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -255,6 +572,125 @@ func _CurrencyService_GetPriceHistory_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CurrencyService_GetCandles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCandlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).GetCandles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CurrencyService_GetCandles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).GetCandles(ctx, req.(*GetCandlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyService_StreamPrices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPricesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CurrencyServiceServer).StreamPrices(m, &grpc.GenericServerStream[StreamPricesRequest, CurrencyPrice]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CurrencyService_StreamPricesServer = grpc.ServerStreamingServer[CurrencyPrice]
+
+func _CurrencyService_BackfillHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackfillHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).BackfillHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CurrencyService_BackfillHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).BackfillHistory(ctx, req.(*BackfillHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyService_GetBackfillJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBackfillJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).GetBackfillJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CurrencyService_GetBackfillJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).GetBackfillJob(ctx, req.(*GetBackfillJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyService_SetCoinOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCoinOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).SetCoinOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CurrencyService_SetCoinOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).SetCoinOverride(ctx, req.(*SetCoinOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyService_DeleteCoinOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCoinOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).DeleteCoinOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CurrencyService_DeleteCoinOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).DeleteCoinOverride(ctx, req.(*DeleteCoinOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyService_ListCoinOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).ListCoinOverrides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CurrencyService_ListCoinOverrides_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).ListCoinOverrides(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CurrencyService_ServiceDesc is the grpc.ServiceDesc for CurrencyService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -271,8 +707,28 @@ var CurrencyService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _CurrencyService_RemoveCurrency_Handler,
 		},
 		{
-			MethodName: "GetCurrencyPrice",
-			Handler:    _CurrencyService_GetCurrencyPrice_Handler,
+			MethodName: "UpdateCurrency",
+			Handler:    _CurrencyService_UpdateCurrency_Handler,
+		},
+		{
+			MethodName: "RestoreCurrency",
+			Handler:    _CurrencyService_RestoreCurrency_Handler,
+		},
+		{
+			MethodName: "ListRemovedCurrencies",
+			Handler:    _CurrencyService_ListRemovedCurrencies_Handler,
+		},
+		{
+			MethodName: "GetCurrencyPrice",
+			Handler:    _CurrencyService_GetCurrencyPrice_Handler,
+		},
+		{
+			MethodName: "BatchGetPrices",
+			Handler:    _CurrencyService_BatchGetPrices_Handler,
+		},
+		{
+			MethodName: "Convert",
+			Handler:    _CurrencyService_Convert_Handler,
 		},
 		{
 			MethodName: "ListCurrencies",
@@ -282,6 +738,652 @@ var CurrencyService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPriceHistory",
 			Handler:    _CurrencyService_GetPriceHistory_Handler,
 		},
+		{
+			MethodName: "GetCandles",
+			Handler:    _CurrencyService_GetCandles_Handler,
+		},
+		{
+			MethodName: "BackfillHistory",
+			Handler:    _CurrencyService_BackfillHistory_Handler,
+		},
+		{
+			MethodName: "GetBackfillJob",
+			Handler:    _CurrencyService_GetBackfillJob_Handler,
+		},
+		{
+			MethodName: "SetCoinOverride",
+			Handler:    _CurrencyService_SetCoinOverride_Handler,
+		},
+		{
+			MethodName: "DeleteCoinOverride",
+			Handler:    _CurrencyService_DeleteCoinOverride_Handler,
+		},
+		{
+			MethodName: "ListCoinOverrides",
+			Handler:    _CurrencyService_ListCoinOverrides_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPrices",
+			Handler:       _CurrencyService_StreamPrices_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "service.proto",
+}
+
+const (
+	AlertService_CreateAlertRule_FullMethodName   = "/currency.v1.AlertService/CreateAlertRule"
+	AlertService_GetAlertRule_FullMethodName      = "/currency.v1.AlertService/GetAlertRule"
+	AlertService_ListAlertRules_FullMethodName    = "/currency.v1.AlertService/ListAlertRules"
+	AlertService_UpdateAlertRule_FullMethodName   = "/currency.v1.AlertService/UpdateAlertRule"
+	AlertService_DeleteAlertRule_FullMethodName   = "/currency.v1.AlertService/DeleteAlertRule"
+	AlertService_ListAlertTriggers_FullMethodName = "/currency.v1.AlertService/ListAlertTriggers"
+)
+
+// AlertServiceClient is the client API for AlertService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Сервис оповещений о ценах: правила проверяются на каждой новой сохранённой цене
+type AlertServiceClient interface {
+	// Создание правила оповещения
+	CreateAlertRule(ctx context.Context, in *CreateAlertRuleRequest, opts ...grpc.CallOption) (*AlertRule, error)
+	// Получение правила по идентификатору
+	GetAlertRule(ctx context.Context, in *GetAlertRuleRequest, opts ...grpc.CallOption) (*AlertRule, error)
+	// Список правил, при заданном symbol - только правила этой валюты
+	ListAlertRules(ctx context.Context, in *ListAlertRulesRequest, opts ...grpc.CallOption) (*ListAlertRulesResponse, error)
+	// Частичное изменение правила (AIP-134)
+	UpdateAlertRule(ctx context.Context, in *UpdateAlertRuleRequest, opts ...grpc.CallOption) (*AlertRule, error)
+	// Удаление правила вместе с историей его срабатываний
+	DeleteAlertRule(ctx context.Context, in *DeleteAlertRuleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// История срабатываний от новых к старым
+	ListAlertTriggers(ctx context.Context, in *ListAlertTriggersRequest, opts ...grpc.CallOption) (*ListAlertTriggersResponse, error)
+}
+
+type alertServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAlertServiceClient(cc grpc.ClientConnInterface) AlertServiceClient {
+	return &alertServiceClient{cc}
+}
+
+func (c *alertServiceClient) CreateAlertRule(ctx context.Context, in *CreateAlertRuleRequest, opts ...grpc.CallOption) (*AlertRule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AlertRule)
+	err := c.cc.Invoke(ctx, AlertService_CreateAlertRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertServiceClient) GetAlertRule(ctx context.Context, in *GetAlertRuleRequest, opts ...grpc.CallOption) (*AlertRule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AlertRule)
+	err := c.cc.Invoke(ctx, AlertService_GetAlertRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertServiceClient) ListAlertRules(ctx context.Context, in *ListAlertRulesRequest, opts ...grpc.CallOption) (*ListAlertRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAlertRulesResponse)
+	err := c.cc.Invoke(ctx, AlertService_ListAlertRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertServiceClient) UpdateAlertRule(ctx context.Context, in *UpdateAlertRuleRequest, opts ...grpc.CallOption) (*AlertRule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AlertRule)
+	err := c.cc.Invoke(ctx, AlertService_UpdateAlertRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertServiceClient) DeleteAlertRule(ctx context.Context, in *DeleteAlertRuleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AlertService_DeleteAlertRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertServiceClient) ListAlertTriggers(ctx context.Context, in *ListAlertTriggersRequest, opts ...grpc.CallOption) (*ListAlertTriggersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAlertTriggersResponse)
+	err := c.cc.Invoke(ctx, AlertService_ListAlertTriggers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AlertServiceServer is the server API for AlertService service.
+// All implementations must embed UnimplementedAlertServiceServer
+// for forward compatibility.
+//
+// Сервис оповещений о ценах: правила проверяются на каждой новой сохранённой цене
+type AlertServiceServer interface {
+	// Создание правила оповещения
+	CreateAlertRule(context.Context, *CreateAlertRuleRequest) (*AlertRule, error)
+	// Получение правила по идентификатору
+	GetAlertRule(context.Context, *GetAlertRuleRequest) (*AlertRule, error)
+	// Список правил, при заданном symbol - только правила этой валюты
+	ListAlertRules(context.Context, *ListAlertRulesRequest) (*ListAlertRulesResponse, error)
+	// Частичное изменение правила (AIP-134)
+	UpdateAlertRule(context.Context, *UpdateAlertRuleRequest) (*AlertRule, error)
+	// Удаление правила вместе с историей его срабатываний
+	DeleteAlertRule(context.Context, *DeleteAlertRuleRequest) (*emptypb.Empty, error)
+	// История срабатываний от новых к старым
+	ListAlertTriggers(context.Context, *ListAlertTriggersRequest) (*ListAlertTriggersResponse, error)
+	mustEmbedUnimplementedAlertServiceServer()
+}
+
+// UnimplementedAlertServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAlertServiceServer struct{}
+
+func (UnimplementedAlertServiceServer) CreateAlertRule(context.Context, *CreateAlertRuleRequest) (*AlertRule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAlertRule not implemented")
+}
+func (UnimplementedAlertServiceServer) GetAlertRule(context.Context, *GetAlertRuleRequest) (*AlertRule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAlertRule not implemented")
+}
+func (UnimplementedAlertServiceServer) ListAlertRules(context.Context, *ListAlertRulesRequest) (*ListAlertRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAlertRules not implemented")
+}
+func (UnimplementedAlertServiceServer) UpdateAlertRule(context.Context, *UpdateAlertRuleRequest) (*AlertRule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAlertRule not implemented")
+}
+func (UnimplementedAlertServiceServer) DeleteAlertRule(context.Context, *DeleteAlertRuleRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAlertRule not implemented")
+}
+func (UnimplementedAlertServiceServer) ListAlertTriggers(context.Context, *ListAlertTriggersRequest) (*ListAlertTriggersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAlertTriggers not implemented")
+}
+func (UnimplementedAlertServiceServer) mustEmbedUnimplementedAlertServiceServer() {}
+func (UnimplementedAlertServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAlertServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AlertServiceServer will
+// result in compilation errors.
+type UnsafeAlertServiceServer interface {
+	mustEmbedUnimplementedAlertServiceServer()
+}
+
+func RegisterAlertServiceServer(s grpc.ServiceRegistrar, srv AlertServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAlertServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AlertService_ServiceDesc, srv)
+}
+
+func _AlertService_CreateAlertRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAlertRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertServiceServer).CreateAlertRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlertService_CreateAlertRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertServiceServer).CreateAlertRule(ctx, req.(*CreateAlertRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlertService_GetAlertRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAlertRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertServiceServer).GetAlertRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlertService_GetAlertRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertServiceServer).GetAlertRule(ctx, req.(*GetAlertRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlertService_ListAlertRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlertRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertServiceServer).ListAlertRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlertService_ListAlertRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertServiceServer).ListAlertRules(ctx, req.(*ListAlertRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlertService_UpdateAlertRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAlertRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertServiceServer).UpdateAlertRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlertService_UpdateAlertRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertServiceServer).UpdateAlertRule(ctx, req.(*UpdateAlertRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlertService_DeleteAlertRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAlertRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertServiceServer).DeleteAlertRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlertService_DeleteAlertRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertServiceServer).DeleteAlertRule(ctx, req.(*DeleteAlertRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlertService_ListAlertTriggers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlertTriggersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertServiceServer).ListAlertTriggers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlertService_ListAlertTriggers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertServiceServer).ListAlertTriggers(ctx, req.(*ListAlertTriggersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AlertService_ServiceDesc is the grpc.ServiceDesc for AlertService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AlertService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "currency.v1.AlertService",
+	HandlerType: (*AlertServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAlertRule",
+			Handler:    _AlertService_CreateAlertRule_Handler,
+		},
+		{
+			MethodName: "GetAlertRule",
+			Handler:    _AlertService_GetAlertRule_Handler,
+		},
+		{
+			MethodName: "ListAlertRules",
+			Handler:    _AlertService_ListAlertRules_Handler,
+		},
+		{
+			MethodName: "UpdateAlertRule",
+			Handler:    _AlertService_UpdateAlertRule_Handler,
+		},
+		{
+			MethodName: "DeleteAlertRule",
+			Handler:    _AlertService_DeleteAlertRule_Handler,
+		},
+		{
+			MethodName: "ListAlertTriggers",
+			Handler:    _AlertService_ListAlertTriggers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "service.proto",
+}
+
+const (
+	WebhookService_CreateWebhookEndpoint_FullMethodName = "/currency.v1.WebhookService/CreateWebhookEndpoint"
+	WebhookService_GetWebhookEndpoint_FullMethodName    = "/currency.v1.WebhookService/GetWebhookEndpoint"
+	WebhookService_ListWebhookEndpoints_FullMethodName  = "/currency.v1.WebhookService/ListWebhookEndpoints"
+	WebhookService_UpdateWebhookEndpoint_FullMethodName = "/currency.v1.WebhookService/UpdateWebhookEndpoint"
+	WebhookService_DeleteWebhookEndpoint_FullMethodName = "/currency.v1.WebhookService/DeleteWebhookEndpoint"
+	WebhookService_ListWebhookDeliveries_FullMethodName = "/currency.v1.WebhookService/ListWebhookDeliveries"
+)
+
+// WebhookServiceClient is the client API for WebhookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Сервис webhook: получатели событий и журнал доставок
+type WebhookServiceClient interface {
+	// Регистрация получателя. Секрет подписи возвращается только в этом ответе.
+	CreateWebhookEndpoint(ctx context.Context, in *CreateWebhookEndpointRequest, opts ...grpc.CallOption) (*WebhookEndpoint, error)
+	// Получение получателя по идентификатору
+	GetWebhookEndpoint(ctx context.Context, in *GetWebhookEndpointRequest, opts ...grpc.CallOption) (*WebhookEndpoint, error)
+	// Список всех получателей
+	ListWebhookEndpoints(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListWebhookEndpointsResponse, error)
+	// Частичное изменение получателя (AIP-134), включение сбрасывает счётчик ошибок
+	UpdateWebhookEndpoint(ctx context.Context, in *UpdateWebhookEndpointRequest, opts ...grpc.CallOption) (*WebhookEndpoint, error)
+	// Удаление получателя вместе с журналом его доставок
+	DeleteWebhookEndpoint(ctx context.Context, in *DeleteWebhookEndpointRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Журнал доставок получателя от новых к старым
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+}
+
+type webhookServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhookServiceClient(cc grpc.ClientConnInterface) WebhookServiceClient {
+	return &webhookServiceClient{cc}
+}
+
+func (c *webhookServiceClient) CreateWebhookEndpoint(ctx context.Context, in *CreateWebhookEndpointRequest, opts ...grpc.CallOption) (*WebhookEndpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookEndpoint)
+	err := c.cc.Invoke(ctx, WebhookService_CreateWebhookEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) GetWebhookEndpoint(ctx context.Context, in *GetWebhookEndpointRequest, opts ...grpc.CallOption) (*WebhookEndpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookEndpoint)
+	err := c.cc.Invoke(ctx, WebhookService_GetWebhookEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListWebhookEndpoints(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListWebhookEndpointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookEndpointsResponse)
+	err := c.cc.Invoke(ctx, WebhookService_ListWebhookEndpoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) UpdateWebhookEndpoint(ctx context.Context, in *UpdateWebhookEndpointRequest, opts ...grpc.CallOption) (*WebhookEndpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookEndpoint)
+	err := c.cc.Invoke(ctx, WebhookService_UpdateWebhookEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) DeleteWebhookEndpoint(ctx context.Context, in *DeleteWebhookEndpointRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, WebhookService_DeleteWebhookEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, WebhookService_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhookServiceServer is the server API for WebhookService service.
+// All implementations must embed UnimplementedWebhookServiceServer
+// for forward compatibility.
+//
+// Сервис webhook: получатели событий и журнал доставок
+type WebhookServiceServer interface {
+	// Регистрация получателя. Секрет подписи возвращается только в этом ответе.
+	CreateWebhookEndpoint(context.Context, *CreateWebhookEndpointRequest) (*WebhookEndpoint, error)
+	// Получение получателя по идентификатору
+	GetWebhookEndpoint(context.Context, *GetWebhookEndpointRequest) (*WebhookEndpoint, error)
+	// Список всех получателей
+	ListWebhookEndpoints(context.Context, *emptypb.Empty) (*ListWebhookEndpointsResponse, error)
+	// Частичное изменение получателя (AIP-134), включение сбрасывает счётчик ошибок
+	UpdateWebhookEndpoint(context.Context, *UpdateWebhookEndpointRequest) (*WebhookEndpoint, error)
+	// Удаление получателя вместе с журналом его доставок
+	DeleteWebhookEndpoint(context.Context, *DeleteWebhookEndpointRequest) (*emptypb.Empty, error)
+	// Журнал доставок получателя от новых к старым
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+// UnimplementedWebhookServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWebhookServiceServer struct{}
+
+func (UnimplementedWebhookServiceServer) CreateWebhookEndpoint(context.Context, *CreateWebhookEndpointRequest) (*WebhookEndpoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhookEndpoint not implemented")
+}
+func (UnimplementedWebhookServiceServer) GetWebhookEndpoint(context.Context, *GetWebhookEndpointRequest) (*WebhookEndpoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhookEndpoint not implemented")
+}
+func (UnimplementedWebhookServiceServer) ListWebhookEndpoints(context.Context, *emptypb.Empty) (*ListWebhookEndpointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookEndpoints not implemented")
+}
+func (UnimplementedWebhookServiceServer) UpdateWebhookEndpoint(context.Context, *UpdateWebhookEndpointRequest) (*WebhookEndpoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWebhookEndpoint not implemented")
+}
+func (UnimplementedWebhookServiceServer) DeleteWebhookEndpoint(context.Context, *DeleteWebhookEndpointRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhookEndpoint not implemented")
+}
+func (UnimplementedWebhookServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedWebhookServiceServer) mustEmbedUnimplementedWebhookServiceServer() {}
+func (UnimplementedWebhookServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeWebhookServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhookServiceServer will
+// result in compilation errors.
+type UnsafeWebhookServiceServer interface {
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+func RegisterWebhookServiceServer(s grpc.ServiceRegistrar, srv WebhookServiceServer) {
+	// If the following call pancis, it indicates UnimplementedWebhookServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WebhookService_ServiceDesc, srv)
+}
+
+func _WebhookService_CreateWebhookEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).CreateWebhookEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_CreateWebhookEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).CreateWebhookEndpoint(ctx, req.(*CreateWebhookEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_GetWebhookEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhookEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).GetWebhookEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_GetWebhookEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).GetWebhookEndpoint(ctx, req.(*GetWebhookEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ListWebhookEndpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).ListWebhookEndpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_ListWebhookEndpoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).ListWebhookEndpoints(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_UpdateWebhookEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWebhookEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).UpdateWebhookEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_UpdateWebhookEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).UpdateWebhookEndpoint(ctx, req.(*UpdateWebhookEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_DeleteWebhookEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).DeleteWebhookEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_DeleteWebhookEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).DeleteWebhookEndpoint(ctx, req.(*DeleteWebhookEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WebhookService_ServiceDesc is the grpc.ServiceDesc for WebhookService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebhookService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "currency.v1.WebhookService",
+	HandlerType: (*WebhookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWebhookEndpoint",
+			Handler:    _WebhookService_CreateWebhookEndpoint_Handler,
+		},
+		{
+			MethodName: "GetWebhookEndpoint",
+			Handler:    _WebhookService_GetWebhookEndpoint_Handler,
+		},
+		{
+			MethodName: "ListWebhookEndpoints",
+			Handler:    _WebhookService_ListWebhookEndpoints_Handler,
+		},
+		{
+			MethodName: "UpdateWebhookEndpoint",
+			Handler:    _WebhookService_UpdateWebhookEndpoint_Handler,
+		},
+		{
+			MethodName: "DeleteWebhookEndpoint",
+			Handler:    _WebhookService_DeleteWebhookEndpoint_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _WebhookService_ListWebhookDeliveries_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "service.proto",